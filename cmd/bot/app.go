@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/tracker-tv/github-policy-bots/internal/config"
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
+	"github.com/tracker-tv/github-policy-bots/internal/notify"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// app holds what the commands share, loaded once by main.
+type app struct {
+	cfg        *config.Config
+	workflows  []models.PolicyWorkflow
+	settings   []models.PolicySettings
+	protection []models.PolicyProtection
+	exemptions []models.Exemption
+	gh         github.Client
+	metrics    *metrics.Metrics
+	notifiers  []notify.Notifier
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/state"
+)
+
+// query prints the history recorded at statePath:
+//
+//	drift [-policy NAME]               repositories out of compliance and since when
+//	history -repo OWNER/NAME [-since]  evaluations of a repository
+//	adoption [-policy NAME] [-since]   share of compliant repositories per run, and pull requests opened
+func query(ctx context.Context, statePath string, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	policyName := flags.String("policy", "", "only show this policy")
+	repoName := flags.String("repo", "", "full name of the repository (history only)")
+	since := flags.Duration("since", 0, "only show the last duration, e.g. 168h")
+	flags.Parse(args)
+
+	if statePath == "" {
+		return errors.New("TTV_STATE_PATH is required to query the history")
+	}
+
+	store, err := state.OpenBolt(statePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	now := time.Now()
+	var from time.Time
+	if *since > 0 {
+		from = now.Add(-*since)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	switch command {
+	case "drift":
+		evaluations, err := store.Evaluations(ctx, time.Time{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "REPOSITORY\tPOLICY\tSTATUS\tSINCE\tAGE\tPR")
+		for _, d := range state.Drifts(evaluations) {
+			if *policyName != "" && d.Policy != *policyName {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				d.Repository, d.Policy, d.Status, d.Since.Format(time.DateTime), d.Age(now).Round(time.Minute), d.PRURL)
+		}
+
+	case "history":
+		if *repoName == "" {
+			return errors.New("history: -repo is required")
+		}
+		evaluations, err := store.History(ctx, *repoName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "RUN\tEVALUATED\tPOLICY\tSTATUS\tACTION\tPR\tCONTENT")
+		for _, e := range evaluations {
+			if e.EvaluatedAt.Before(from) || (*policyName != "" && e.Policy != *policyName) {
+				continue
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				e.RunID, e.EvaluatedAt.Format(time.DateTime), e.Policy, e.Status, e.Action, e.PRURL, shortHash(e.ContentHash))
+		}
+
+	case "adoption":
+		runs, err := store.Runs(ctx, from)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "RUN\tSTARTED\tPOLICY\tCOMPLIANT\tTOTAL\tRATE")
+		for _, a := range state.Adoptions(runs) {
+			if *policyName != "" && a.Policy != *policyName {
+				continue
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%.1f%%\n",
+				a.RunID, a.StartedAt.Format(time.DateTime), a.Policy, a.Compliant, a.Total, 100*a.Rate())
+		}
+
+		evaluations, err := store.Evaluations(ctx, time.Time{})
+		if err != nil {
+			return err
+		}
+		opened := state.PullRequestsOpened(evaluations, from)
+		fmt.Fprintln(w, "\nPOLICY\tPRS OPENED")
+		for _, policy := range slices.Sorted(maps.Keys(opened)) {
+			if *policyName != "" && policy != *policyName {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%d\n", policy, opened[policy])
+		}
+	}
+
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
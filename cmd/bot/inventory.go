@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// inventory lists the actions and reusable workflows used by the workflows of
+// every repository, and the repositories and files using each of them.
+func inventory(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("inventory", flag.ExitOnError)
+	format := flags.String("format", "csv", "output format, csv or json")
+	output := flags.String("output", "", "file to write the inventory to, stdout by default")
+	action := flags.String("action", "", "only list the actions starting with this prefix, e.g. actions/checkout")
+	archived := flags.Bool("archived", false, "include archived repositories")
+	flags.Parse(args)
+
+	var write func(io.Writer, []models.ActionUsage) error
+	switch *format {
+	case "csv":
+		write = report.WriteInventoryCSV
+	case "json":
+		write = report.WriteInventoryJSON
+	default:
+		return fmt.Errorf("unknown format %q, want csv or json", *format)
+	}
+
+	repos, err := service.NewRepositoriesService(a.gh).ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	if !*archived {
+		repos = slices.DeleteFunc(repos, func(r models.Repository) bool { return r.Archived })
+	}
+
+	usages, err := service.NewInventoryService(a.gh).Collect(ctx, repos)
+	if err != nil {
+		return err
+	}
+	if *action != "" {
+		usages = slices.DeleteFunc(usages, func(u models.ActionUsage) bool { return !strings.HasPrefix(u.Action, *action) })
+	}
+	logging.FromContext(ctx).Info("inventory collected", "repositories", len(repos), "actions", len(usages))
+
+	if *output == "" {
+		return write(os.Stdout, usages)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := write(f, usages); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -4,54 +4,164 @@ import (
 	"context"
 	"embed"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/tracker-tv/github-policy-bots/internal/config"
 	"github.com/tracker-tv/github-policy-bots/internal/github"
-	"github.com/tracker-tv/github-policy-bots/internal/orchestrator"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
+	"github.com/tracker-tv/github-policy-bots/internal/notify"
 	"github.com/tracker-tv/github-policy-bots/internal/policy"
-	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/internal/tracing"
+	"github.com/tracker-tv/github-policy-bots/models"
 )
 
 //go:embed policies/*.json
 var embeddedPolicies embed.FS
 
+// org is the organization whose repositories the bot manages.
+const org = "tracker-tv"
+
 func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	command, args := "run", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	// Policy tests run offline and need neither a token nor the configuration
+	if command == "test" {
+		if err := testPolicies(context.Background(), args); err != nil {
+			fatal(logger, "test failed", err)
+		}
+		return
+	}
+
+	// History queries only read the state file, they need neither a token nor the policies
+	switch command {
+	case "drift", "history", "adoption":
+		if err := query(context.Background(), os.Getenv("TTV_STATE_PATH"), command, args); err != nil {
+			fatal(logger, command+" failed", err)
+		}
+		return
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
-		panic(fmt.Sprintf("failed to load config: %v", err))
+		fatal(logger, "failed to load config", err)
 	}
 
-	data, err := embeddedPolicies.ReadFile("policies/github-actions.json")
+	logger, err = logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
 	if err != nil {
-		log.Fatalln(err)
+		fatal(slog.Default(), "failed to configure logging", err)
 	}
+	slog.SetDefault(logger)
 
-	workflows, err := policy.FromJSON(data)
+	workflows, err := loadPolicies("")
 	if err != nil {
-		log.Fatalln(err)
+		fatal(logger, "failed to load policies", err)
 	}
 
-	fmt.Printf("Policies: %+v\n", workflows)
+	data, err := embeddedPolicies.ReadFile("policies/repository-settings.json")
+	if err != nil {
+		fatal(logger, "failed to read settings policies", err)
+	}
+
+	settings, err := policy.SettingsFromJSON(data)
+	if err != nil {
+		fatal(logger, "failed to parse settings policies", err)
+	}
 
-	ghClient := github.New(cfg.GithubPAT, "tracker-tv")
+	data, err = embeddedPolicies.ReadFile("policies/branch-protection.json")
+	if err != nil {
+		fatal(logger, "failed to read protection policies", err)
+	}
 
-	repoSvc := service.NewRepositoriesService(ghClient)
-	policySvc := service.NewPolicyService(workflows, ghClient)
-	remediationSvc := service.NewRemediationService(ghClient)
+	protection, err := policy.ProtectionFromJSON(data)
+	if err != nil {
+		fatal(logger, "failed to parse protection policies", err)
+	}
 
-	bot := orchestrator.NewGithubActionsBot(repoSvc, policySvc, remediationSvc)
+	data, err = embeddedPolicies.ReadFile("policies/exemptions.json")
+	if err != nil {
+		fatal(logger, "failed to read exemptions", err)
+	}
 
-	results, err := bot.Run(context.Background())
+	exemptions, err := policy.ExemptionsFromJSON(data)
 	if err != nil {
-		log.Fatal(err)
+		fatal(logger, "failed to parse exemptions", err)
 	}
 
-	for _, r := range results {
-		if r.Error != nil {
-			fmt.Printf("Error: %s in %s - %v\n", r.Drift.Policy.Name, r.Drift.Repository.FullName, r.Error)
-		} else {
-			fmt.Printf("Remediation: %s in %s - %s (%s)\n", r.Drift.Policy.Name, r.Drift.Repository.FullName, r.Action, r.PRURL)
+	logger.Info("policies loaded", "policies", len(workflows), "settings", len(settings), "protection", len(protection), "exemptions", len(exemptions))
+
+	var notifiers []notify.Notifier
+	if cfg.NotifiersFile != "" {
+		data, err := os.ReadFile(cfg.NotifiersFile)
+		if err != nil {
+			fatal(logger, "failed to read notifiers", err)
+		}
+		notifiers, err = notify.Load(data, os.Getenv)
+		if err != nil {
+			fatal(logger, "failed to configure notifiers", err)
 		}
 	}
+
+	m := metrics.New()
+	ghClient := github.New(cfg.GithubPAT, org, github.WithMetrics(m))
+	a := &app{cfg: cfg, workflows: workflows, settings: settings, protection: protection, exemptions: exemptions, gh: ghClient, metrics: m, notifiers: notifiers}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = logging.NewContext(ctx, logger.With("command", command))
+
+	// Spans go to stderr so they do not mix with the output of preview
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracesExporter, os.Stderr)
+	if err != nil {
+		fatal(logger, "failed to configure tracing", err)
+	}
+
+	switch command {
+	case "run":
+		err = run(ctx, a, args)
+	case "serve":
+		err = serve(ctx, a)
+	case "preview":
+		err = preview(ctx, workflows, settings, protection, ghClient, args)
+	case "inventory":
+		err = inventory(ctx, a, args)
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		logger.Warn("could not flush traces", logging.Error(shutdownErr))
+	}
+	if err != nil {
+		stop()
+		fatal(logger, command+" failed", err)
+	}
+}
+
+// loadPolicies parses the policies of path, or the embedded ones when path is empty.
+func loadPolicies(path string) ([]models.PolicyWorkflow, error) {
+	var data []byte
+	var err error
+	if path == "" {
+		data, err = embeddedPolicies.ReadFile("policies/github-actions.json")
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy.FromJSON(data)
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, logging.Error(err))
+	os.Exit(1)
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// preview prints the workflows the policies would commit to a repository and
+// the settings and protection they would change, without changing anything.
+func preview(ctx context.Context, workflows []models.PolicyWorkflow, settings []models.PolicySettings, protection []models.PolicyProtection, ghClient github.Client, args []string) error {
+	flags := flag.NewFlagSet("preview", flag.ExitOnError)
+	repoName := flags.String("repo", "", "name of the repository to render policies for (required)")
+	policyName := flags.String("policy", "", "only render this policy")
+	flags.Parse(args)
+
+	if *repoName == "" {
+		return errors.New("preview: -repo is required")
+	}
+
+	repoSvc := service.NewRepositoriesService(ghClient)
+	policySvc := service.NewPolicyService(workflows, ghClient)
+
+	repos, err := repoSvc.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var repo *models.Repository
+	for i := range repos {
+		if repos[i].Name == *repoName {
+			repo = &repos[i]
+			break
+		}
+	}
+	if repo == nil {
+		return fmt.Errorf("preview: repository %s not found", *repoName)
+	}
+
+	files, err := repoSvc.ListFiles(ctx, repo.Name)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := policySvc.Render(ctx, *repo, files)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rendered {
+		if *policyName != "" && r.Policy.Name != *policyName {
+			continue
+		}
+		fmt.Printf("--- %s (policy %s)\n%s\n", r.TargetPath, r.Policy.Name, r.Content)
+	}
+
+	settingsEvaluation, err := service.NewSettingsService(settings, ghClient).Ensure(ctx, *repo, files)
+	if err != nil {
+		return err
+	}
+	// The managed checks come from the workflows committed in the repository
+	workflowEvaluation, err := policySvc.Ensure(ctx, *repo, files)
+	if err != nil {
+		return err
+	}
+	protectionEvaluation, err := service.NewProtectionService(protection, ghClient).Ensure(ctx, *repo, files, workflowEvaluation)
+	if err != nil {
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(protectionEvaluation.Errors)) {
+		if *policyName == "" || name == *policyName {
+			return fmt.Errorf("policy %s: %w", name, protectionEvaluation.Errors[name])
+		}
+	}
+	for _, d := range append(settingsEvaluation.Deviations, protectionEvaluation.Deviations...) {
+		if *policyName != "" && d.Policy.Name != *policyName {
+			continue
+		}
+		kind := "settings"
+		if d.Protection != nil {
+			kind = "protection of " + d.Protection.Branch
+		}
+		fmt.Printf("--- %s (policy %s)\n", kind, d.Policy.Name)
+		for _, change := range d.Settings {
+			fmt.Println(change)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
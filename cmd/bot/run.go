@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/orchestrator"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/internal/state"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// run evaluates every repository once, or every cfg.RunInterval until ctx is
+// done while serving metrics on cfg.MetricsAddr. With -changed-sources, it
+// runs once and only for the policies whose source changed.
+func run(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	changedSources := flags.String("changed-sources", "", "only enforce the policies whose source changed in owner/repo@base..head")
+	flags.Parse(args)
+
+	if *changedSources != "" {
+		if a.cfg.RunInterval > 0 {
+			return errors.New("-changed-sources cannot be combined with TTV_RUN_INTERVAL")
+		}
+		return runChangedSources(ctx, a, *changedSources)
+	}
+
+	if a.cfg.RunInterval <= 0 {
+		if err := runOnce(ctx, a); err != nil {
+			return err
+		}
+		return a.publishMetrics()
+	}
+
+	logger := logging.FromContext(ctx)
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- a.metrics.Serve(ctx, a.cfg.MetricsAddr) }()
+	logger.Info("serving metrics", "addr", a.cfg.MetricsAddr, "interval", a.cfg.RunInterval)
+
+	ticker := time.NewTicker(a.cfg.RunInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := runOnce(ctx, a); err != nil {
+			logger.Error("run failed", logging.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serveErr:
+			return fmt.Errorf("serving metrics: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// runChangedSources enforces the policies whose source file changed in the
+// commit range spec across every repository.
+func runChangedSources(ctx context.Context, a *app, spec string) error {
+	change, err := service.ParseSourceChange(spec)
+	if err != nil {
+		return err
+	}
+
+	changed, err := service.NewSourceService(a.workflows, a.gh, org).ChangedPolicies(ctx, change)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.FromContext(ctx)
+	if len(changed) == 0 {
+		logger.Info("no policy source changed", "compare", change.CompareURL())
+		return nil
+	}
+
+	names := make([]string, 0, len(changed))
+	for _, policy := range changed {
+		names = append(names, policy.Name)
+	}
+	logger.Info("policy sources changed", "policies", names, "compare", change.CompareURL())
+
+	if err := runOnce(ctx, a, service.OnlyPolicies(names...), service.WithSourceChange(change)); err != nil {
+		return err
+	}
+	return a.publishMetrics()
+}
+
+// publishMetrics pushes the metrics of a single run and/or writes them to a textfile.
+func (a *app) publishMetrics() error {
+	if a.cfg.MetricsPushgatewayURL != "" {
+		if err := a.metrics.Push(a.cfg.MetricsPushgatewayURL); err != nil {
+			return fmt.Errorf("pushing metrics: %w", err)
+		}
+	}
+	if a.cfg.MetricsTextfile != "" {
+		if err := a.metrics.WriteTextfile(a.cfg.MetricsTextfile); err != nil {
+			return fmt.Errorf("writing metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+func runOnce(ctx context.Context, a *app, policyOpts ...service.PolicyOption) error {
+	cfg := a.cfg
+	var runReport report.Report
+	bot := a.newBot(policyOpts, orchestrator.WithReport(&runReport))
+
+	results, err := bot.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.FromContext(ctx)
+	for _, r := range results {
+		logResult(logger, r)
+	}
+
+	for _, e := range service.ExpiringExemptions(a.exemptions, time.Now(), cfg.ExemptionWarningDays) {
+		logger.Warn("exemption expiring",
+			logging.Repo(e.Repository), logging.Policy(e.Policy), "expires", e.Expires.Format(time.DateOnly), "approver", e.Approver)
+	}
+
+	if cfg.SARIFUpload {
+		uploadSARIF(ctx, service.NewCodeScanningService(a.gh), &runReport)
+	}
+
+	// A run restricted to some policies would drop the others from the matrix
+	if cfg.DashboardRepo != "" && len(policyOpts) == 0 {
+		publishDashboard(ctx, service.NewDashboardService(a.gh, cfg.DashboardRepo, cfg.DashboardTitle), &runReport)
+	}
+
+	if cfg.StatePath != "" {
+		run, evaluations := state.FromReport(&runReport)
+		if err := recordRun(ctx, cfg.StatePath, run, evaluations); err != nil {
+			return err
+		}
+	}
+
+	return writeReports(&runReport, cfg.Reports)
+}
+
+// recordRun adds the run to the history kept at path. The store is only open
+// while recording, so the history can be queried while the bot serves.
+func recordRun(ctx context.Context, path string, run state.Run, evaluations []state.Evaluation) error {
+	store, err := state.OpenBolt(path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	run, err = store.Record(ctx, run, evaluations)
+	if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Debug("run recorded", "run", run.ID, "evaluations", len(evaluations))
+	return nil
+}
+
+// newBot wires the services shared by every command that enforces policies.
+func (a *app) newBot(policyOpts []service.PolicyOption, opts ...orchestrator.Option) *orchestrator.GithubActionsBot {
+	cfg := a.cfg
+	repoSvc := service.NewRepositoriesService(a.gh)
+	defaults := []service.PolicyOption{service.WithExemptions(a.exemptions)}
+	if cfg.PinActions {
+		// The pinning cache lives as long as the bot, a single run
+		defaults = append(defaults, service.WithActionPinning())
+	}
+	policySvc := service.NewPolicyService(a.workflows, a.gh, append(defaults, policyOpts...)...)
+	remediationSvc := service.NewRemediationService(a.gh, service.WithPullRequestDefaults(models.PullRequestOptions{
+		Labels:                  cfg.PRLabels,
+		Assignees:               cfg.PRAssignees,
+		Reviewers:               cfg.PRReviewers,
+		TeamReviewers:           cfg.PRTeamReviewers,
+		Draft:                   cfg.PRDraft,
+		ReviewersFromCodeowners: cfg.PRReviewersFromCodeowners,
+	}))
+
+	defaultOpts := []orchestrator.Option{orchestrator.WithMetrics(a.metrics), orchestrator.WithNotifiers(a.notifiers...)}
+	// Settings and protection do not come from policy sources, a run restricted to some policies leaves them out
+	if len(policyOpts) == 0 {
+		defaultOpts = append(defaultOpts,
+			orchestrator.WithSettings(service.NewSettingsService(a.settings, a.gh, service.WithSettingsExemptions(a.exemptions))),
+			orchestrator.WithProtection(service.NewProtectionService(a.protection, a.gh, service.WithProtectionExemptions(a.exemptions))),
+		)
+	}
+	opts = append(defaultOpts, opts...)
+	return orchestrator.NewGithubActionsBot(repoSvc, policySvc, remediationSvc, opts...)
+}
+
+func logResult(logger *slog.Logger, r service.RemediationResult) {
+	logger = logger.With(
+		logging.Repo(r.Drift.Repository.FullName),
+		logging.Policy(r.Drift.Policy.Name),
+		logging.Action(string(r.Drift.Action)),
+		logging.Path(r.Drift.TargetPath),
+	)
+
+	switch {
+	case r.Drift.Action == models.PolicyActionInvalidConfig:
+		logger.Warn("invalid repository config", "reason", r.Drift.Reason)
+	case r.Drift.Action == models.PolicyActionUnpinned:
+		logger.Warn("unpinned actions", "actions", r.Drift.UnpinnedActions)
+	case r.Drift.Action == models.PolicyActionSettings && r.Error == nil:
+		logger.Info("settings", "result", r.Action, "settings", service.DescribeSettings(r.Drift.Settings))
+	case r.Drift.Action == models.PolicyActionProtection && r.Error == nil:
+		logger.Info("protection", "result", r.Action, "protection", service.DescribeSettings(r.Drift.Settings))
+	case r.Drift.Action == models.PolicyActionExempt:
+		e := r.Drift.Exemption
+		logger.Info("deviation exempt", "reason", e.Reason, "approver", e.Approver, "expires", e.Expires.Format(time.DateOnly))
+	case r.Error != nil:
+		logger.Error("remediation failed", "result", r.Action, "pr", r.PRURL, logging.Error(r.Error))
+	case r.Drift.Comparison != "":
+		logger.Info("remediation", "result", r.Action, "pr", r.PRURL, "comparison", r.Drift.Comparison)
+	default:
+		logger.Info("remediation", "result", r.Action, "pr", r.PRURL)
+	}
+
+	if r.AutoMergeReason != "" {
+		logger.Warn("auto-merge not enabled", "reason", r.AutoMergeReason)
+	}
+}
+
+// uploadSARIF uploads the deviations of every evaluated repository to its own
+// code scanning. Repositories without deviations get an empty log so fixed alerts are closed.
+func uploadSARIF(ctx context.Context, codeScanning service.CodeScanningService, r *report.Report) {
+	for _, repo := range r.Repositories {
+		if repo.Error != "" {
+			continue
+		}
+		logger := logging.FromContext(ctx).With(logging.Repo(repo.FullName))
+
+		var buf bytes.Buffer
+		if err := report.WriteRepositorySARIF(&buf, repo); err != nil {
+			logger.Warn("could not build SARIF", logging.Error(err))
+			continue
+		}
+		if err := codeScanning.Upload(ctx, models.Repository{Name: repo.Name, FullName: repo.FullName}, buf.Bytes()); err != nil {
+			logger.Warn("could not upload SARIF", logging.Error(err))
+		}
+	}
+}
+
+// publishDashboard shows the compliance matrix of r in the dashboard issue.
+func publishDashboard(ctx context.Context, dashboard service.DashboardService, r *report.Report) {
+	logger := logging.FromContext(ctx)
+
+	var buf bytes.Buffer
+	if err := (report.DashboardWriter{}).Write(&buf, r); err != nil {
+		logger.Warn("could not render dashboard", logging.Error(err))
+		return
+	}
+	url, err := dashboard.Publish(ctx, buf.String())
+	if err != nil {
+		logger.Warn("could not publish dashboard", logging.Error(err))
+		return
+	}
+	logger.Info("dashboard published", "url", url)
+}
+
+// writeReports writes r once per format=path spec.
+func writeReports(r *report.Report, specs []string) error {
+	for _, spec := range specs {
+		format, path, ok := strings.Cut(spec, "=")
+		if !ok || path == "" {
+			return fmt.Errorf("invalid report %q, expected format=path", spec)
+		}
+
+		writer, err := report.WriterFor(format)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("creating %s report: %w", format, err)
+		}
+		if err := writer.Write(f, r); err != nil {
+			f.Close()
+			return fmt.Errorf("writing %s report: %w", format, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("writing %s report: %w", format, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/orchestrator"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/state"
+	"github.com/tracker-tv/github-policy-bots/internal/webhook"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// serve receives webhooks on cfg.WebhookAddr and evaluates the repositories
+// they affect until ctx is done. Metrics are served on the same address.
+func serve(ctx context.Context, a *app) error {
+	cfg := a.cfg
+	if cfg.WebhookSecret == "" {
+		return errors.New("TTV_WEBHOOK_SECRET is required to verify webhooks")
+	}
+
+	logger := logging.FromContext(ctx)
+	queue := webhook.NewQueue()
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /webhook", webhook.NewHandler([]byte(cfg.WebhookSecret), queue, a.workflows))
+	mux.Handle("GET /metrics", a.metrics.Handler())
+
+	server := &http.Server{
+		Addr:              cfg.WebhookAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		queue.Run(ctx, cfg.WebhookWorkers, cfg.WebhookGracePeriod, func(ctx context.Context, repo models.Repository) {
+			// A bot per evaluation, so tags resolved for pinning are not cached forever
+			var repoReport report.Report
+			for _, r := range a.newBot(nil, orchestrator.WithReport(&repoReport)).RunRepository(ctx, repo) {
+				logResult(logger, r)
+			}
+
+			if cfg.StatePath != "" {
+				run, evaluations := state.FromReport(&repoReport)
+				run.Repository = repo.FullName
+				if err := recordRun(ctx, cfg.StatePath, run, evaluations); err != nil {
+					logger.Warn("could not record evaluation", logging.Repo(repo.FullName), logging.Error(err))
+				}
+			}
+		})
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("serving webhooks", "addr", cfg.WebhookAddr, "workers", cfg.WebhookWorkers)
+	err := server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		// Let the evaluations in progress finish before exiting, within the grace period
+		<-workersDone
+		if dropped := queue.Len(); dropped > 0 {
+			logger.Warn("repositories left in queue", "count", dropped)
+		}
+		return nil
+	}
+	return err
+}
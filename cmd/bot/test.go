@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/internal/policytest"
+)
+
+// testPolicies checks the policies against fixture repositories offline and
+// compares the outcome with their golden files.
+func testPolicies(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	policiesFile := flags.String("policies", "", "JSON file of the policies to test, the embedded ones by default")
+	fixtures := flags.String("fixtures", "policytest", "directory of the fixture repositories, or a single fixture")
+	update := flags.Bool("update", false, "rewrite the golden files with the outcome")
+	var opts []policytest.Option
+	flags.Func("source", "read the sources whose URL starts with `prefix=dir` from dir, repeatable", func(value string) error {
+		prefix, dir, ok := strings.Cut(value, "=")
+		if !ok || prefix == "" || dir == "" {
+			return fmt.Errorf("want prefix=dir, got %q", value)
+		}
+		opts = append(opts, policytest.WithSources(prefix, dir))
+		return nil
+	})
+	flags.Parse(args)
+
+	workflows, err := loadPolicies(*policiesFile)
+	if err != nil {
+		return fmt.Errorf("loading policies: %w", err)
+	}
+
+	// sources/ of the fixtures directory mirrors the source URLs, e.g.
+	// sources/raw.githubusercontent.com/tracker-tv/github-actions-ttv/...
+	if info, err := os.Stat(filepath.Join(*fixtures, "sources")); err == nil && info.IsDir() {
+		opts = append([]policytest.Option{policytest.WithSources("https://", filepath.Join(*fixtures, "sources"))}, opts...)
+	}
+	opts = append(opts, policytest.WithUpdate(*update))
+
+	results, err := policytest.New(workflows, opts...).Run(ctx, *fixtures)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Updated:
+			fmt.Printf("updated %s\n", r.Fixture)
+		case r.Passed():
+			fmt.Printf("ok      %s\n", r.Fixture)
+		default:
+			failed++
+			fmt.Printf("FAIL    %s\n", r.Fixture)
+			for _, diff := range r.Diffs {
+				fmt.Println(diff)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d fixtures failed", failed, len(results))
+	}
+	return nil
+}
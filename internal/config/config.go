@@ -1,9 +1,66 @@
 package config
 
-import "github.com/caarlos0/env/v11"
+import (
+	"time"
+
+	"github.com/caarlos0/env/v11"
+)
 
 type Config struct {
 	GithubPAT string `env:"TTV_GITHUB_PAT,required"`
+
+	LogLevel  string `env:"TTV_LOG_LEVEL" envDefault:"info"`  // debug, info, warn or error
+	LogFormat string `env:"TTV_LOG_FORMAT" envDefault:"text"` // text or json
+
+	// Trace exporter: otlp, stdout or empty to disable tracing. The OTLP
+	// exporter reads the standard OTEL_EXPORTER_OTLP_* variables.
+	TracesExporter string `env:"TTV_TRACES_EXPORTER"`
+
+	// Pull request metadata applied to every policy PR, merged with the policy's own settings.
+	PRLabels                  []string `env:"TTV_PR_LABELS"`
+	PRAssignees               []string `env:"TTV_PR_ASSIGNEES"`
+	PRReviewers               []string `env:"TTV_PR_REVIEWERS"`
+	PRTeamReviewers           []string `env:"TTV_PR_TEAM_REVIEWERS"`
+	PRDraft                   bool     `env:"TTV_PR_DRAFT"`
+	PRReviewersFromCodeowners bool     `env:"TTV_PR_REVIEWERS_FROM_CODEOWNERS"`
+
+	// Pin the third-party actions of managed workflows to commit SHAs, and
+	// report the unpinned ones of the workflows no policy manages.
+	PinActions bool `env:"TTV_PIN_ACTIONS"`
+
+	// Exemptions expiring within this many days are listed at the end of a run.
+	ExemptionWarningDays int `env:"TTV_EXEMPTION_WARNING_DAYS" envDefault:"14"`
+
+	// Run reports to write, as format=path pairs, e.g. "json=report.json,junit=junit.xml".
+	Reports []string `env:"TTV_REPORTS"`
+
+	// Upload the deviations of every repository to its code scanning alerts.
+	SARIFUpload bool `env:"TTV_SARIF_UPLOAD"`
+
+	// Repository of the organization holding the compliance dashboard issue. Empty disables the dashboard.
+	DashboardRepo  string `env:"TTV_DASHBOARD_REPO"`
+	DashboardTitle string `env:"TTV_DASHBOARD_TITLE" envDefault:"Policy compliance"`
+
+	// YAML file describing where run outcomes are sent (Slack, webhooks, email).
+	NotifiersFile string `env:"TTV_NOTIFIERS_FILE"`
+
+	// BoltDB file recording the history of runs. Empty disables the history.
+	StatePath string `env:"TTV_STATE_PATH"`
+
+	// Run every interval and serve metrics on MetricsAddr. A zero interval runs once.
+	RunInterval time.Duration `env:"TTV_RUN_INTERVAL"`
+	MetricsAddr string        `env:"TTV_METRICS_ADDR" envDefault:":9090"`
+
+	// Where a single run publishes its metrics: a Pushgateway URL and/or a node exporter textfile.
+	MetricsPushgatewayURL string `env:"TTV_METRICS_PUSHGATEWAY_URL"`
+	MetricsTextfile       string `env:"TTV_METRICS_TEXTFILE"`
+
+	// Webhook server of the serve command. Deliveries are verified against WebhookSecret.
+	WebhookAddr    string `env:"TTV_WEBHOOK_ADDR" envDefault:":8080"`
+	WebhookSecret  string `env:"TTV_WEBHOOK_SECRET"`
+	WebhookWorkers int    `env:"TTV_WEBHOOK_WORKERS" envDefault:"4"`
+	// How long the evaluations in progress may take to finish once the server is stopped.
+	WebhookGracePeriod time.Duration `env:"TTV_WEBHOOK_GRACE_PERIOD" envDefault:"1m"`
 }
 
 func Load() (*Config, error) {
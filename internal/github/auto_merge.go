@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAutoMergeUnavailable is returned by EnableAutoMerge when GitHub refuses to
+// enable auto-merge because of the repository or branch configuration.
+var ErrAutoMergeUnavailable = errors.New("auto-merge unavailable")
+
+const enableAutoMergeMutation = `mutation($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) {
+    clientMutationId
+  }
+}`
+
+// autoMergeRefusals are fragments of the GraphQL error messages returned when
+// auto-merge is disabled for the repository or cannot apply to the base branch.
+var autoMergeRefusals = []string{
+	"auto merge is not allowed",
+	"protected branch rules not configured",
+	"pull request is in clean status",
+	"pull request is in unstable status",
+}
+
+func (c *client) EnableAutoMerge(ctx context.Context, pullRequestID, mergeMethod string) error {
+	variables := map[string]any{
+		"pullRequestId": pullRequestID,
+		"mergeMethod":   strings.ToUpper(mergeMethod),
+	}
+
+	err := c.graphql.Do(ctx, enableAutoMergeMutation, variables, nil)
+
+	var gqlErrs GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		for _, gqlErr := range gqlErrs {
+			message := strings.ToLower(gqlErr.Message)
+			for _, refusal := range autoMergeRefusals {
+				if strings.Contains(message, refusal) {
+					return fmt.Errorf("%w: %s", ErrAutoMergeUnavailable, gqlErr.Message)
+				}
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+func TestEnableAutoMerge_Success(t *testing.T) {
+	ctx := context.Background()
+	gql := github.NewMockGraphQLAdapter(t)
+
+	gql.
+		EXPECT().
+		Do(mock.Anything, enableAutoMergeMutation, map[string]any{
+			"pullRequestId": "PR_kwDO123",
+			"mergeMethod":   "SQUASH",
+		}, nil).
+		Once().
+		Return(nil)
+
+	c := &client{graphql: gql, org: "org-name"}
+
+	err := c.EnableAutoMerge(ctx, "PR_kwDO123", "squash")
+
+	assert.NoError(t, err)
+}
+
+func TestEnableAutoMerge_NotAllowed(t *testing.T) {
+	ctx := context.Background()
+	gql := github.NewMockGraphQLAdapter(t)
+
+	gql.
+		EXPECT().
+		Do(mock.Anything, mock.Anything, mock.Anything, nil).
+		Once().
+		Return(GraphQLErrors{{Type: "UNPROCESSABLE", Message: "Pull request Auto merge is not allowed for this repository"}})
+
+	c := &client{graphql: gql, org: "org-name"}
+
+	err := c.EnableAutoMerge(ctx, "PR_kwDO123", "merge")
+
+	assert.ErrorIs(t, err, ErrAutoMergeUnavailable)
+	assert.Contains(t, err.Error(), "not allowed for this repository")
+}
+
+func TestEnableAutoMerge_BranchProtection(t *testing.T) {
+	ctx := context.Background()
+	gql := github.NewMockGraphQLAdapter(t)
+
+	gql.
+		EXPECT().
+		Do(mock.Anything, mock.Anything, mock.Anything, nil).
+		Once().
+		Return(GraphQLErrors{{Message: "Pull request Protected branch rules not configured for this branch"}})
+
+	c := &client{graphql: gql, org: "org-name"}
+
+	err := c.EnableAutoMerge(ctx, "PR_kwDO123", "rebase")
+
+	assert.ErrorIs(t, err, ErrAutoMergeUnavailable)
+}
+
+func TestEnableAutoMerge_OtherError(t *testing.T) {
+	ctx := context.Background()
+	gql := github.NewMockGraphQLAdapter(t)
+
+	gql.
+		EXPECT().
+		Do(mock.Anything, mock.Anything, mock.Anything, nil).
+		Once().
+		Return(errors.New("connection reset"))
+
+	c := &client{graphql: gql, org: "org-name"}
+
+	err := c.EnableAutoMerge(ctx, "PR_kwDO123", "merge")
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAutoMergeUnavailable)
+}
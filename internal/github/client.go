@@ -3,8 +3,13 @@ package github
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	gh "github.com/google/go-github/v80/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
 )
 
 type Client interface {
@@ -12,19 +17,55 @@ type Client interface {
 	ListAllRepos(ctx context.Context) ([]*gh.Repository, error)
 	GetContentsRaw(ctx context.Context, repo, path string) (*gh.RepositoryContent, []*gh.RepositoryContent, *gh.Response, error)
 	GetTree(ctx context.Context, repo, sha string, recursive bool) (*gh.Tree, *gh.Response, error)
+	ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error)
+	ResolveRef(ctx context.Context, owner, repo, ref string) (string, error)
+	GetRepository(ctx context.Context, repo string) (*gh.Repository, error)
+	EditRepository(ctx context.Context, repo string, settings *gh.Repository) (*gh.Repository, error)
 
 	// Branch operations
 	GetBranch(ctx context.Context, repo, branch string) (*gh.Reference, error)
 	CreateBranch(ctx context.Context, repo, branchName, baseSHA string) error
 
+	// Branch protection and ruleset operations
+	GetBranchProtection(ctx context.Context, repo, branch string) (*gh.Protection, error)
+	UpdateBranchProtection(ctx context.Context, repo, branch string, protection *gh.ProtectionRequest) error
+	GetRulesetByName(ctx context.Context, repo, name string) (*gh.RepositoryRuleset, error)
+	CreateRuleset(ctx context.Context, repo string, ruleset gh.RepositoryRuleset) error
+	UpdateRuleset(ctx context.Context, repo string, ruleset gh.RepositoryRuleset) error
+
 	// File operations
 	GetFileContent(ctx context.Context, repo, path, ref string) (content string, sha string, err error)
 	CreateOrUpdateFile(ctx context.Context, repo, path, branch, message, content string, fileSHA *string) error
+	DeleteFile(ctx context.Context, repo, path, branch, message, fileSHA string) error
+	CommitFiles(ctx context.Context, repo, branch, message string, files map[string]string, deletions []string) error
 
 	// Pull request operations
 	ListPullRequests(ctx context.Context, repo string, opts *gh.PullRequestListOptions) ([]*gh.PullRequest, error)
-	CreatePullRequest(ctx context.Context, repo, title, body, head, base string) (*gh.PullRequest, error)
+	CreatePullRequest(ctx context.Context, repo, title, body, head, base string, draft bool) (*gh.PullRequest, error)
 	FindPullRequestByBranch(ctx context.Context, repo, branchName string) (*gh.PullRequest, error)
+	RequestReviewers(ctx context.Context, repo string, number int, reviewers, teamReviewers []string) error
+	EditPullRequestBody(ctx context.Context, repo string, number int, body string) error
+	EnableAutoMerge(ctx context.Context, pullRequestID, mergeMethod string) error
+
+	// Issue operations
+	GetLabel(ctx context.Context, repo, name string) (*gh.Label, *gh.Response, error)
+	CreateLabel(ctx context.Context, repo, name, color string) (*gh.Label, error)
+	AddLabelsToIssue(ctx context.Context, repo string, number int, labels []string) error
+	AddAssignees(ctx context.Context, repo string, number int, assignees []string) error
+	CreateIssue(ctx context.Context, repo, title, body string, labels []string) (*gh.Issue, error)
+	EditIssue(ctx context.Context, repo string, number int, title, body string) (*gh.Issue, error)
+	ListIssues(ctx context.Context, repo, creator string) ([]*gh.Issue, error)
+	PinIssue(ctx context.Context, issueID string) error
+	ListIssueComments(ctx context.Context, repo string, number int) ([]*gh.IssueComment, error)
+	CreateIssueComment(ctx context.Context, repo string, number int, body string) error
+	EditIssueComment(ctx context.Context, repo string, commentID int64, body string) error
+	DeleteIssueComment(ctx context.Context, repo string, commentID int64) error
+
+	// Code scanning operations
+	UploadSARIF(ctx context.Context, repo, commitSHA, ref string, sarif []byte) error
+
+	// User operations
+	AuthenticatedUser(ctx context.Context) (string, error)
 }
 
 type RepositoriesAdapter interface {
@@ -32,57 +73,181 @@ type RepositoriesAdapter interface {
 	GetContents(ctx context.Context, owner, repo, path string, opts *gh.RepositoryContentGetOptions) (*gh.RepositoryContent, []*gh.RepositoryContent, *gh.Response, error)
 	CreateFile(ctx context.Context, owner, repo, path string, opts *gh.RepositoryContentFileOptions) (*gh.RepositoryContentResponse, *gh.Response, error)
 	UpdateFile(ctx context.Context, owner, repo, path string, opts *gh.RepositoryContentFileOptions) (*gh.RepositoryContentResponse, *gh.Response, error)
+	DeleteFile(ctx context.Context, owner, repo, path string, opts *gh.RepositoryContentFileOptions) (*gh.RepositoryContentResponse, *gh.Response, error)
+	CompareCommits(ctx context.Context, owner, repo, base, head string, opts *gh.ListOptions) (*gh.CommitsComparison, *gh.Response, error)
+	GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *gh.Response, error)
+	Get(ctx context.Context, owner, repo string) (*gh.Repository, *gh.Response, error)
+	Edit(ctx context.Context, owner, repo string, repository *gh.Repository) (*gh.Repository, *gh.Response, error)
+}
+
+type BranchProtectionAdapter interface {
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*gh.Protection, *gh.Response, error)
+	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *gh.ProtectionRequest) (*gh.Protection, *gh.Response, error)
+}
+
+type RulesetsAdapter interface {
+	GetAllRulesets(ctx context.Context, owner, repo string, opts *gh.RepositoryListRulesetsOptions) ([]*gh.RepositoryRuleset, *gh.Response, error)
+	GetRuleset(ctx context.Context, owner, repo string, rulesetID int64, includesParents bool) (*gh.RepositoryRuleset, *gh.Response, error)
+	CreateRuleset(ctx context.Context, owner, repo string, ruleset gh.RepositoryRuleset) (*gh.RepositoryRuleset, *gh.Response, error)
+	UpdateRuleset(ctx context.Context, owner, repo string, rulesetID int64, ruleset gh.RepositoryRuleset) (*gh.RepositoryRuleset, *gh.Response, error)
+	UpdateRulesetNoBypassActor(ctx context.Context, owner, repo string, rulesetID int64, ruleset gh.RepositoryRuleset) (*gh.RepositoryRuleset, *gh.Response, error)
 }
 
 type GitAdapter interface {
 	GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*gh.Tree, *gh.Response, error)
+	CreateTree(ctx context.Context, owner, repo, baseTree string, entries []*gh.TreeEntry) (*gh.Tree, *gh.Response, error)
+	GetCommit(ctx context.Context, owner, repo, sha string) (*gh.Commit, *gh.Response, error)
+	CreateCommit(ctx context.Context, owner, repo string, commit gh.Commit, opts *gh.CreateCommitOptions) (*gh.Commit, *gh.Response, error)
 }
 
 type ReferencesAdapter interface {
 	GetRef(ctx context.Context, owner, repo, ref string) (*gh.Reference, *gh.Response, error)
 	CreateRef(ctx context.Context, owner, repo string, ref gh.CreateRef) (*gh.Reference, *gh.Response, error)
+	UpdateRef(ctx context.Context, owner, repo, ref string, updateRef gh.UpdateRef) (*gh.Reference, *gh.Response, error)
 }
 
 type PullRequestsAdapter interface {
 	List(ctx context.Context, owner, repo string, opts *gh.PullRequestListOptions) ([]*gh.PullRequest, *gh.Response, error)
 	Create(ctx context.Context, owner, repo string, pull *gh.NewPullRequest) (*gh.PullRequest, *gh.Response, error)
+	RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers gh.ReviewersRequest) (*gh.PullRequest, *gh.Response, error)
+	Edit(ctx context.Context, owner, repo string, number int, pull *gh.PullRequest) (*gh.PullRequest, *gh.Response, error)
+}
+
+type IssuesAdapter interface {
+	GetLabel(ctx context.Context, owner, repo, name string) (*gh.Label, *gh.Response, error)
+	CreateLabel(ctx context.Context, owner, repo string, label *gh.Label) (*gh.Label, *gh.Response, error)
+	AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*gh.Label, *gh.Response, error)
+	AddAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*gh.Issue, *gh.Response, error)
+	Create(ctx context.Context, owner, repo string, issue *gh.IssueRequest) (*gh.Issue, *gh.Response, error)
+	Edit(ctx context.Context, owner, repo string, number int, issue *gh.IssueRequest) (*gh.Issue, *gh.Response, error)
+	ListByRepo(ctx context.Context, owner, repo string, opts *gh.IssueListByRepoOptions) ([]*gh.Issue, *gh.Response, error)
+	ListComments(ctx context.Context, owner, repo string, number int, opts *gh.IssueListCommentsOptions) ([]*gh.IssueComment, *gh.Response, error)
+	CreateComment(ctx context.Context, owner, repo string, number int, comment *gh.IssueComment) (*gh.IssueComment, *gh.Response, error)
+	EditComment(ctx context.Context, owner, repo string, commentID int64, comment *gh.IssueComment) (*gh.IssueComment, *gh.Response, error)
+	DeleteComment(ctx context.Context, owner, repo string, commentID int64) (*gh.Response, error)
+}
+
+type UsersAdapter interface {
+	Get(ctx context.Context, user string) (*gh.User, *gh.Response, error)
+}
+
+type CodeScanningAdapter interface {
+	UploadSarif(ctx context.Context, owner, repo string, sarif *gh.SarifAnalysis) (*gh.SarifID, *gh.Response, error)
+}
+
+type GraphQLAdapter interface {
+	Do(ctx context.Context, query string, variables map[string]any, out any) error
 }
 
 type client struct {
 	github       *gh.Client
 	repositories RepositoriesAdapter
+	protection   BranchProtectionAdapter
+	rulesets     RulesetsAdapter
 	git          GitAdapter
 	references   ReferencesAdapter
 	pullRequests PullRequestsAdapter
+	issues       IssuesAdapter
+	codeScanning CodeScanningAdapter
+	users        UsersAdapter
+	graphql      GraphQLAdapter
 	org          string
 }
 
 type authTransport struct {
 	token string
+	next  http.RoundTripper
 }
 
 func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+t.token)
-	return http.DefaultTransport.RoundTrip(req)
+	if t.next == nil {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// loggingTransport logs every API call with the logger of the request context.
+type loggingTransport struct {
+	next http.RoundTripper
 }
 
-func New(token, org string) Client {
-	var httpClient *http.Client
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := logging.FromContext(req.Context())
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		logger.Debug("github request failed",
+			"method", req.Method, logging.Path(req.URL.Path), "duration", time.Since(start), logging.Error(err))
+		return nil, err
+	}
+
+	logger.Debug("github request",
+		"method", req.Method, logging.Path(req.URL.Path), "status", resp.StatusCode,
+		"duration", time.Since(start), logging.RequestID(resp.Header.Get("X-GitHub-Request-Id")))
+	return resp, nil
+}
+
+// Option configures optional behaviour of the client.
+type Option func(*options)
+
+type options struct {
+	metrics *metrics.Metrics
+	baseURL *url.URL
+}
+
+// WithMetrics records every API call and the remaining rate limit in m.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// WithBaseURL sends the API calls to baseURL instead of https://api.github.com/,
+// e.g. to a fake GitHub in tests.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+func New(token, org string, opts ...Option) Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
 	if token != "" {
-		httpClient = &http.Client{
-			Transport: &authTransport{
-				token: token,
-			},
+		transport = &authTransport{token: token, next: transport}
+	}
+	transport = &loggingTransport{next: transport}
+	if o.metrics != nil {
+		transport = &metricsTransport{next: transport, metrics: o.metrics}
+	}
+	transport = &tracingTransport{next: transport}
+	c := gh.NewClient(&http.Client{Transport: transport})
+	if o.baseURL != nil {
+		base := *o.baseURL
+		if !strings.HasSuffix(base.Path, "/") {
+			base.Path += "/"
 		}
+		c.BaseURL, c.UploadURL = &base, &base
 	}
-	c := gh.NewClient(httpClient)
 
-	return &client{
+	return &tracedClient{org: org, next: &client{
 		github:       c,
 		repositories: c.Repositories,
+		protection:   c.Repositories,
+		rulesets:     c.Repositories,
 		git:          c.Git,
 		references:   c.Git,
 		pullRequests: c.PullRequests,
+		issues:       c.Issues,
+		codeScanning: c.CodeScanning,
+		users:        c.Users,
+		graphql:      &graphQL{client: c},
 		org:          org,
-	}
+	}}
 }
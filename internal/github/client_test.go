@@ -1,11 +1,15 @@
 package github
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
 )
 
 func TestNew_WithToken(t *testing.T) {
@@ -38,3 +42,29 @@ func TestAuthTransport_RoundTrip(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestLoggingTransport_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	transport := &loggingTransport{next: http.DefaultTransport}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx := logging.NewContext(context.Background(), logger)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/repos/org/repo", nil)
+	assert.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	out := buf.String()
+	assert.Contains(t, out, `msg="github request"`)
+	assert.Contains(t, out, "path=/repos/org/repo")
+	assert.Contains(t, out, "status=404")
+	assert.Contains(t, out, "request_id=ABCD:1234")
+}
@@ -0,0 +1,29 @@
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+
+	gh "github.com/google/go-github/v80/github"
+)
+
+// UploadSARIF uploads a SARIF log for commitSHA on ref. The API expects it gzipped and base64 encoded.
+func (c *client) UploadSARIF(ctx context.Context, repo, commitSHA, ref string, sarif []byte) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(sarif); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	_, _, err := c.codeScanning.UploadSarif(ctx, c.org, repo, &gh.SarifAnalysis{
+		CommitSHA: gh.Ptr(commitSHA),
+		Ref:       gh.Ptr(ref),
+		Sarif:     gh.Ptr(base64.StdEncoding.EncodeToString(buf.Bytes())),
+	})
+	return err
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+func TestUploadSARIF_Success(t *testing.T) {
+	ctx := context.Background()
+	codeScanning := github.NewMockCodeScanningAdapter(t)
+
+	codeScanning.
+		EXPECT().
+		UploadSarif(mock.Anything, "org-name", "repo-name",
+			mock.MatchedBy(func(analysis *gh.SarifAnalysis) bool {
+				compressed, err := base64.StdEncoding.DecodeString(analysis.GetSarif())
+				if err != nil {
+					return false
+				}
+				zr, err := gzip.NewReader(bytes.NewReader(compressed))
+				if err != nil {
+					return false
+				}
+				sarif, err := io.ReadAll(zr)
+				return err == nil &&
+					string(sarif) == `{"version":"2.1.0"}` &&
+					analysis.GetCommitSHA() == "abc123" &&
+					analysis.GetRef() == "refs/heads/main"
+			}),
+		).
+		Once().
+		Return(&gh.SarifID{ID: gh.Ptr("1")}, &gh.Response{}, nil)
+
+	c := &client{codeScanning: codeScanning, org: "org-name"}
+
+	err := c.UploadSARIF(ctx, "repo-name", "abc123", "refs/heads/main", []byte(`{"version":"2.1.0"}`))
+
+	assert.NoError(t, err)
+}
+
+func TestUploadSARIF_Error(t *testing.T) {
+	ctx := context.Background()
+	codeScanning := github.NewMockCodeScanningAdapter(t)
+
+	codeScanning.
+		EXPECT().
+		UploadSarif(mock.Anything, "org-name", "repo-name", mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("advanced security is not enabled"))
+
+	c := &client{codeScanning: codeScanning, org: "org-name"}
+
+	err := c.UploadSARIF(ctx, "repo-name", "abc123", "refs/heads/main", []byte(`{}`))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "advanced security")
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+
+	gh "github.com/google/go-github/v80/github"
+)
+
+// ChangedFiles lists the paths added, modified, removed or renamed between
+// base and head. Renamed files are listed under their old and new path.
+func (c *client) ChangedFiles(ctx context.Context, repo, base, head string) ([]string, error) {
+	var files []string
+
+	opts := &gh.ListOptions{PerPage: 100}
+
+	for {
+		comparison, resp, err := c.repositories.CompareCommits(ctx, c.org, repo, base, head, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range comparison.Files {
+			files = append(files, f.GetFilename())
+			if f.GetPreviousFilename() != "" {
+				files = append(files, f.GetPreviousFilename())
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return files, nil
+}
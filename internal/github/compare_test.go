@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+func TestChangedFiles_Pagination(t *testing.T) {
+	ctx := context.Background()
+	reposSvc := github.NewMockRepositoriesAdapter(t)
+
+	reposSvc.
+		EXPECT().
+		CompareCommits(mock.Anything, "org-name", "actions", "abc", "def",
+			mock.MatchedBy(func(o *gh.ListOptions) bool { return o.Page == 0 }),
+		).
+		Once().
+		Return(
+			&gh.CommitsComparison{Files: []*gh.CommitFile{
+				{Filename: gh.Ptr("workflows/go.yml")},
+				{Filename: gh.Ptr("workflows/docker.yml"), PreviousFilename: gh.Ptr("workflows/dockerfile.yml")},
+			}},
+			&gh.Response{NextPage: 2},
+			nil,
+		)
+
+	reposSvc.
+		EXPECT().
+		CompareCommits(mock.Anything, "org-name", "actions", "abc", "def",
+			mock.MatchedBy(func(o *gh.ListOptions) bool { return o.Page == 2 }),
+		).
+		Once().
+		Return(
+			&gh.CommitsComparison{Files: []*gh.CommitFile{{Filename: gh.Ptr("README.md")}}},
+			&gh.Response{},
+			nil,
+		)
+
+	c := &client{repositories: reposSvc, org: "org-name"}
+
+	files, err := c.ChangedFiles(ctx, "actions", "abc", "def")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"workflows/go.yml", "workflows/docker.yml", "workflows/dockerfile.yml", "README.md"}, files)
+}
+
+func TestChangedFiles_Error(t *testing.T) {
+	ctx := context.Background()
+	reposSvc := github.NewMockRepositoriesAdapter(t)
+
+	reposSvc.
+		EXPECT().
+		CompareCommits(mock.Anything, "org-name", "actions", "abc", "def", mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("not found"))
+
+	c := &client{repositories: reposSvc, org: "org-name"}
+
+	files, err := c.ChangedFiles(ctx, "actions", "abc", "def")
+
+	assert.EqualError(t, err, "not found")
+	assert.Nil(t, files)
+}
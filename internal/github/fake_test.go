@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/github/githubtest"
+)
+
+// statusCode returns the HTTP status of a failed API call, 0 if err is not one.
+func statusCode(err error) int {
+	var errResp *gh.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response.StatusCode
+	}
+	return 0
+}
+
+func newFake(t *testing.T) (*githubtest.Server, Client) {
+	s := githubtest.NewServer(t, "my-org")
+	s.AddRepo(githubtest.Repo{Name: "api", Topics: []string{"go"}, Files: map[string]string{
+		"go.mod":    "module api\n",
+		"README.md": "# api\n",
+	}})
+	s.AddRepo(githubtest.Repo{Name: "legacy", Archived: true})
+	return s, New("token", "my-org", WithBaseURL(s.BaseURL()))
+}
+
+func TestFake_ListAllRepos(t *testing.T) {
+	_, c := newFake(t)
+
+	repos, err := c.ListAllRepos(context.Background())
+
+	assert.NoError(t, err)
+	if !assert.Len(t, repos, 2) {
+		return
+	}
+	assert.Equal(t, "api", repos[0].GetName())
+	assert.Equal(t, []string{"go"}, repos[0].Topics)
+	assert.True(t, repos[1].GetArchived())
+}
+
+func TestFake_FileRoundTrip(t *testing.T) {
+	s, c := newFake(t)
+	ctx := context.Background()
+
+	content, sha, err := c.GetFileContent(ctx, "api", "go.mod", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "module api\n", content)
+
+	assert.NoError(t, c.CreateOrUpdateFile(ctx, "api", "go.mod", "main", "Bump", "module api\n\ngo 1.25\n", &sha))
+	_, readmeSHA, err := c.GetFileContent(ctx, "api", "README.md", "main")
+	assert.NoError(t, err)
+	assert.NoError(t, c.DeleteFile(ctx, "api", "README.md", "main", "Remove readme", readmeSHA))
+
+	got, _ := s.File("api", "main", "go.mod")
+	assert.Equal(t, "module api\n\ngo 1.25\n", got)
+	_, ok := s.File("api", "main", "README.md")
+	assert.False(t, ok)
+}
+
+func TestFake_Conflict(t *testing.T) {
+	_, c := newFake(t)
+
+	err := c.CreateOrUpdateFile(context.Background(), "api", "go.mod", "main", "Bump", "module api\n", gh.Ptr("stale"))
+
+	assert.Equal(t, http.StatusConflict, statusCode(err))
+}
+
+func TestFake_CommitFilesOnNewBranch(t *testing.T) {
+	s, c := newFake(t)
+	ctx := context.Background()
+
+	base, err := c.GetBranch(ctx, "api", "main")
+	assert.NoError(t, err)
+	assert.NoError(t, c.CreateBranch(ctx, "api", "chore/go", base.GetObject().GetSHA()))
+
+	err = c.CommitFiles(ctx, "api", "chore/go", "Apply policy",
+		map[string]string{".github/workflows/go.yml": "name: go\n"}, []string{"README.md"})
+	assert.NoError(t, err)
+
+	workflow, ok := s.File("api", "chore/go", ".github/workflows/go.yml")
+	assert.True(t, ok)
+	assert.Equal(t, "name: go\n", workflow)
+	_, ok = s.File("api", "chore/go", "README.md")
+	assert.False(t, ok)
+	_, ok = s.File("api", "main", "README.md")
+	assert.True(t, ok, "main is left untouched")
+
+	changed, err := c.ChangedFiles(ctx, "api", "main", "chore/go")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/go.yml", "README.md"}, changed)
+
+	tree, _, err := c.GetTree(ctx, "api", "chore/go", true)
+	assert.NoError(t, err)
+	var paths []string
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" {
+			paths = append(paths, entry.GetPath())
+		}
+	}
+	assert.Equal(t, []string{".github/workflows/go.yml", "go.mod"}, paths)
+}
+
+func TestFake_PullRequest(t *testing.T) {
+	s, c := newFake(t)
+	ctx := context.Background()
+	s.Commit("api", "chore/go", "Apply policy", map[string]string{"ci.yml": "on: push\n"})
+
+	pr, err := c.CreatePullRequest(ctx, "api", "Apply go policy", "body", "chore/go", "main", false)
+	assert.NoError(t, err)
+	assert.NoError(t, c.AddLabelsToIssue(ctx, "api", pr.GetNumber(), []string{"policy"}))
+	assert.NoError(t, c.RequestReviewers(ctx, "api", pr.GetNumber(), []string{"alice"}, []string{"platform"}))
+	assert.NoError(t, c.EnableAutoMerge(ctx, pr.GetNodeID(), "squash"))
+
+	found, err := c.FindPullRequestByBranch(ctx, "api", "chore/go")
+	assert.NoError(t, err)
+	assert.Equal(t, pr.GetNumber(), found.GetNumber())
+
+	_, err = c.CreatePullRequest(ctx, "api", "Apply go policy", "body", "chore/go", "main", false)
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode(err), "a second pull request for the branch")
+
+	prs := s.PullRequests("api")
+	if !assert.Len(t, prs, 1) {
+		return
+	}
+	assert.Equal(t, []string{"policy"}, prs[0].Labels)
+	assert.Equal(t, []string{"alice"}, prs[0].Reviewers)
+	assert.Equal(t, []string{"platform"}, prs[0].TeamReviewers)
+	assert.Equal(t, "squash", prs[0].AutoMerge)
+}
+
+func TestFake_Issues(t *testing.T) {
+	s, c := newFake(t)
+	ctx := context.Background()
+
+	issue, err := c.CreateIssue(ctx, "api", "Policy compliance", "<!-- marker -->", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, c.PinIssue(ctx, issue.GetNodeID()))
+	assert.NoError(t, c.CreateIssueComment(ctx, "api", issue.GetNumber(), "part 2"))
+
+	login, err := c.AuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, githubtest.Login, login)
+
+	found, err := c.ListIssues(ctx, "api", login)
+	assert.NoError(t, err)
+	if !assert.Len(t, found, 1) {
+		return
+	}
+	others, err := c.ListIssues(ctx, "api", "someone-else")
+	assert.NoError(t, err)
+	assert.Empty(t, others)
+
+	comments, err := c.ListIssueComments(ctx, "api", issue.GetNumber())
+	assert.NoError(t, err)
+	if !assert.Len(t, comments, 1) {
+		return
+	}
+	assert.NoError(t, c.DeleteIssueComment(ctx, "api", comments[0].GetID()))
+
+	issues := s.Issues("api")
+	if !assert.Len(t, issues, 1) {
+		return
+	}
+	assert.True(t, issues[0].Pinned)
+	assert.Empty(t, issues[0].Comments)
+}
+
+func TestFake_Faults(t *testing.T) {
+	tests := []struct {
+		name   string
+		fault  githubtest.Fault
+		status int
+	}{
+		{name: "not found", fault: githubtest.NotFound("GET", "/repos/my-org/api/git/ref/heads/*"), status: http.StatusNotFound},
+		{name: "conflict", fault: githubtest.Conflict("GET", "/repos/my-org/api/git/ref/heads/*"), status: http.StatusConflict},
+		{name: "unprocessable", fault: githubtest.Unprocessable("", "/repos/my-org/*/git/ref/heads/main"), status: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newFake(t)
+			tt.fault.Times = 1
+			s.Inject(tt.fault)
+
+			_, err := c.GetBranch(context.Background(), "api", "main")
+			assert.Equal(t, tt.status, statusCode(err))
+
+			_, err = c.GetBranch(context.Background(), "api", "main")
+			assert.NoError(t, err, "the fault only fails once")
+		})
+	}
+}
+
+func TestFake_RateLimited(t *testing.T) {
+	s, c := newFake(t)
+	s.Inject(githubtest.RateLimited("", "/orgs/my-org/repos"))
+
+	_, err := c.ListAllRepos(context.Background())
+
+	var rateErr *gh.RateLimitError
+	assert.True(t, errors.As(err, &rateErr))
+}
+
+func TestFake_UnknownBranch(t *testing.T) {
+	_, c := newFake(t)
+
+	_, err := c.GetBranch(context.Background(), "api", "missing")
+
+	assert.Equal(t, http.StatusNotFound, statusCode(err))
+}
@@ -36,3 +36,13 @@ func (c *client) CreateOrUpdateFile(ctx context.Context, repo, path, branch, mes
 	_, _, err := c.repositories.UpdateFile(ctx, c.org, repo, path, opts)
 	return err
 }
+
+func (c *client) DeleteFile(ctx context.Context, repo, path, branch, message, fileSHA string) error {
+	opts := &gh.RepositoryContentFileOptions{
+		Message: gh.Ptr(message),
+		Branch:  gh.Ptr(branch),
+		SHA:     gh.Ptr(fileSHA),
+	}
+	_, _, err := c.repositories.DeleteFile(ctx, c.org, repo, path, opts)
+	return err
+}
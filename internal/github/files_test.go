@@ -153,3 +153,44 @@ func TestCreateOrUpdateFile_UpdateError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "conflict")
 }
+
+func TestDeleteFile_Success(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := github.NewMockRepositoriesAdapter(t)
+
+	repoSvc.
+		EXPECT().
+		DeleteFile(mock.Anything, "org-name", "repo-name", ".github/workflows/old.yml",
+			mock.MatchedBy(func(opts *gh.RepositoryContentFileOptions) bool {
+				return opts.GetMessage() == "Remove old" &&
+					opts.GetBranch() == "chore/old" &&
+					opts.GetSHA() == "abc123"
+			}),
+		).
+		Once().
+		Return(&gh.RepositoryContentResponse{}, &gh.Response{}, nil)
+
+	c := &client{repositories: repoSvc, org: "org-name"}
+
+	err := c.DeleteFile(ctx, "repo-name", ".github/workflows/old.yml", "chore/old", "Remove old", "abc123")
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteFile_Error(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := github.NewMockRepositoriesAdapter(t)
+
+	repoSvc.
+		EXPECT().
+		DeleteFile(mock.Anything, "org-name", "repo-name", ".github/workflows/old.yml", mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("sha mismatch"))
+
+	c := &client{repositories: repoSvc, org: "org-name"}
+
+	err := c.DeleteFile(ctx, "repo-name", ".github/workflows/old.yml", "chore/old", "Remove old", "abc123")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sha mismatch")
+}
@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	gh "github.com/google/go-github/v80/github"
 )
@@ -9,3 +11,63 @@ import (
 func (c *client) GetTree(ctx context.Context, repo, sha string, recursive bool) (*gh.Tree, *gh.Response, error) {
 	return c.git.GetTree(ctx, c.org, repo, sha, recursive)
 }
+
+// CommitFiles writes files (path to content) and removes deletions on branch
+// in a single commit, which the contents API cannot do.
+func (c *client) CommitFiles(ctx context.Context, repo, branch, message string, files map[string]string, deletions []string) error {
+	ref, _, err := c.references.GetRef(ctx, c.org, repo, "refs/heads/"+branch)
+	if err != nil {
+		return fmt.Errorf("getting branch %s: %w", branch, err)
+	}
+	parentSHA := ref.GetObject().GetSHA()
+
+	parent, _, err := c.git.GetCommit(ctx, c.org, repo, parentSHA)
+	if err != nil {
+		return fmt.Errorf("getting commit %s: %w", parentSHA, err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	entries := make([]*gh.TreeEntry, 0, len(files)+len(deletions))
+	for _, path := range paths {
+		entries = append(entries, &gh.TreeEntry{
+			Path:    gh.Ptr(path),
+			Mode:    gh.Ptr("100644"),
+			Type:    gh.Ptr("blob"),
+			Content: gh.Ptr(files[path]),
+		})
+	}
+	for _, path := range deletions {
+		// An entry without SHA nor content removes the path from the tree
+		entries = append(entries, &gh.TreeEntry{
+			Path: gh.Ptr(path),
+			Mode: gh.Ptr("100644"),
+			Type: gh.Ptr("blob"),
+		})
+	}
+
+	tree, _, err := c.git.CreateTree(ctx, c.org, repo, parent.GetTree().GetSHA(), entries)
+	if err != nil {
+		return fmt.Errorf("creating tree: %w", err)
+	}
+
+	commit := gh.Commit{
+		Message: gh.Ptr(message),
+		Tree:    tree,
+		Parents: []*gh.Commit{{SHA: gh.Ptr(parentSHA)}},
+	}
+	created, _, err := c.git.CreateCommit(ctx, c.org, repo, commit, nil)
+	if err != nil {
+		return fmt.Errorf("creating commit: %w", err)
+	}
+
+	_, _, err = c.references.UpdateRef(ctx, c.org, repo, "refs/heads/"+branch, gh.UpdateRef{SHA: created.GetSHA()})
+	if err != nil {
+		return fmt.Errorf("updating branch %s: %w", branch, err)
+	}
+	return nil
+}
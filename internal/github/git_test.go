@@ -118,3 +118,112 @@ func TestGetTree_EmptyRepo(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Empty(t, result.Entries)
 }
+
+func TestCommitFiles_Success(t *testing.T) {
+	ctx := context.Background()
+	gitSvc := github.NewMockGitAdapter(t)
+	refSvc := github.NewMockReferencesAdapter(t)
+
+	refSvc.
+		EXPECT().
+		GetRef(mock.Anything, "org-name", "repo-name", "refs/heads/chore/docker-build").
+		Once().
+		Return(&gh.Reference{Object: &gh.GitObject{SHA: gh.Ptr("parent-sha")}}, &gh.Response{}, nil)
+
+	gitSvc.
+		EXPECT().
+		GetCommit(mock.Anything, "org-name", "repo-name", "parent-sha").
+		Once().
+		Return(&gh.Commit{SHA: gh.Ptr("parent-sha"), Tree: &gh.Tree{SHA: gh.Ptr("base-tree")}}, &gh.Response{}, nil)
+
+	gitSvc.
+		EXPECT().
+		CreateTree(mock.Anything, "org-name", "repo-name", "base-tree",
+			mock.MatchedBy(func(entries []*gh.TreeEntry) bool {
+				return len(entries) == 2 &&
+					entries[0].GetPath() == ".github/workflows/docker-build.yml" &&
+					entries[0].GetContent() == "new content" &&
+					entries[1].GetPath() == ".github/workflows/dockerfile.yml" &&
+					entries[1].SHA == nil && entries[1].Content == nil
+			}),
+		).
+		Once().
+		Return(&gh.Tree{SHA: gh.Ptr("new-tree")}, &gh.Response{}, nil)
+
+	gitSvc.
+		EXPECT().
+		CreateCommit(mock.Anything, "org-name", "repo-name",
+			mock.MatchedBy(func(c gh.Commit) bool {
+				return c.GetMessage() == "Migrate docker-build" &&
+					c.GetTree().GetSHA() == "new-tree" &&
+					len(c.Parents) == 1 && c.Parents[0].GetSHA() == "parent-sha"
+			}),
+			(*gh.CreateCommitOptions)(nil),
+		).
+		Once().
+		Return(&gh.Commit{SHA: gh.Ptr("new-commit")}, &gh.Response{}, nil)
+
+	refSvc.
+		EXPECT().
+		UpdateRef(mock.Anything, "org-name", "repo-name", "refs/heads/chore/docker-build", gh.UpdateRef{SHA: "new-commit"}).
+		Once().
+		Return(&gh.Reference{}, &gh.Response{}, nil)
+
+	c := &client{git: gitSvc, references: refSvc, org: "org-name"}
+
+	err := c.CommitFiles(ctx, "repo-name", "chore/docker-build", "Migrate docker-build",
+		map[string]string{".github/workflows/docker-build.yml": "new content"},
+		[]string{".github/workflows/dockerfile.yml"},
+	)
+
+	assert.NoError(t, err)
+}
+
+func TestCommitFiles_CreateTreeError(t *testing.T) {
+	ctx := context.Background()
+	gitSvc := github.NewMockGitAdapter(t)
+	refSvc := github.NewMockReferencesAdapter(t)
+
+	refSvc.
+		EXPECT().
+		GetRef(mock.Anything, "org-name", "repo-name", "refs/heads/chore/docker-build").
+		Once().
+		Return(&gh.Reference{Object: &gh.GitObject{SHA: gh.Ptr("parent-sha")}}, &gh.Response{}, nil)
+
+	gitSvc.
+		EXPECT().
+		GetCommit(mock.Anything, "org-name", "repo-name", "parent-sha").
+		Once().
+		Return(&gh.Commit{Tree: &gh.Tree{SHA: gh.Ptr("base-tree")}}, &gh.Response{}, nil)
+
+	gitSvc.
+		EXPECT().
+		CreateTree(mock.Anything, "org-name", "repo-name", "base-tree", mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("tree too large"))
+
+	c := &client{git: gitSvc, references: refSvc, org: "org-name"}
+
+	err := c.CommitFiles(ctx, "repo-name", "chore/docker-build", "Migrate", map[string]string{"a.yml": "a"}, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "creating tree")
+}
+
+func TestCommitFiles_BranchNotFound(t *testing.T) {
+	ctx := context.Background()
+	refSvc := github.NewMockReferencesAdapter(t)
+
+	refSvc.
+		EXPECT().
+		GetRef(mock.Anything, "org-name", "repo-name", "refs/heads/missing").
+		Once().
+		Return(nil, nil, errors.New("not found"))
+
+	c := &client{references: refSvc, org: "org-name"}
+
+	err := c.CommitFiles(ctx, "repo-name", "missing", "Migrate", nil, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "getting branch missing")
+}
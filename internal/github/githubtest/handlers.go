@@ -0,0 +1,895 @@
+package githubtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	gh "github.com/google/go-github/v80/github"
+)
+
+func (s *Server) routes() {
+	s.handle("GET /orgs/{org}/repos", s.listRepos)
+
+	s.handle("GET /repos/{owner}/{repo}/contents/{path...}", s.getContents)
+	s.handle("PUT /repos/{owner}/{repo}/contents/{path...}", s.putContents)
+	s.handle("DELETE /repos/{owner}/{repo}/contents/{path...}", s.deleteContents)
+	s.handle("GET /repos/{owner}/{repo}/compare/{basehead...}", s.compare)
+
+	s.handle("GET /repos/{owner}/{repo}/git/ref/{ref...}", s.getRef)
+	s.handle("POST /repos/{owner}/{repo}/git/refs", s.createRef)
+	s.handle("PATCH /repos/{owner}/{repo}/git/refs/{ref...}", s.updateRef)
+	s.handle("GET /repos/{owner}/{repo}/git/commits/{sha}", s.getCommit)
+	s.handle("POST /repos/{owner}/{repo}/git/commits", s.createCommit)
+	s.handle("GET /repos/{owner}/{repo}/git/trees/{sha...}", s.getTree)
+	s.handle("POST /repos/{owner}/{repo}/git/trees", s.createTree)
+
+	s.handle("GET /repos/{owner}/{repo}/pulls", s.listPulls)
+	s.handle("POST /repos/{owner}/{repo}/pulls", s.createPull)
+	s.handle("PATCH /repos/{owner}/{repo}/pulls/{number}", s.editPull)
+	s.handle("POST /repos/{owner}/{repo}/pulls/{number}/requested_reviewers", s.requestReviewers)
+
+	s.handle("GET /repos/{owner}/{repo}/labels/{name}", s.getLabel)
+	s.handle("POST /repos/{owner}/{repo}/labels", s.createLabel)
+	s.handle("GET /repos/{owner}/{repo}/issues", s.listIssues)
+	s.handle("POST /repos/{owner}/{repo}/issues", s.createIssue)
+	s.handle("PATCH /repos/{owner}/{repo}/issues/{number}", s.editIssue)
+	s.handle("POST /repos/{owner}/{repo}/issues/{number}/labels", s.addLabels)
+	s.handle("POST /repos/{owner}/{repo}/issues/{number}/assignees", s.addAssignees)
+	s.handle("GET /repos/{owner}/{repo}/issues/{number}/comments", s.listComments)
+	s.handle("POST /repos/{owner}/{repo}/issues/{number}/comments", s.createComment)
+	s.handle("PATCH /repos/{owner}/{repo}/issues/comments/{id}", s.editComment)
+	s.handle("DELETE /repos/{owner}/{repo}/issues/comments/{id}", s.deleteComment)
+
+	s.handle("POST /repos/{owner}/{repo}/code-scanning/sarifs", s.uploadSARIF)
+	s.handle("POST /graphql", s.graphql)
+	s.handle("GET /user", s.getUser)
+
+	s.handle("GET /raw/{owner}/{repo}/{ref}/{path...}", s.raw)
+}
+
+func (s *Server) htmlURL(repo *repository, kind string, number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/%s/%d", s.org, repo.Name, kind, number)
+}
+
+func (s *Server) listRepos(w http.ResponseWriter, r *http.Request, _ *repository) {
+	if r.PathValue("org") != s.org {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var repos []*gh.Repository
+	for _, name := range slices.Sorted(maps.Keys(s.repos)) {
+		repo := s.repos[name]
+		repos = append(repos, &gh.Repository{
+			Name:          gh.Ptr(repo.Name),
+			FullName:      gh.Ptr(s.org + "/" + repo.Name),
+			Owner:         &gh.User{Login: gh.Ptr(s.org)},
+			Private:       gh.Ptr(repo.Private),
+			Archived:      gh.Ptr(repo.Archived),
+			DefaultBranch: gh.Ptr(repo.DefaultBranch),
+			Topics:        repo.Topics,
+		})
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, repos))
+}
+
+func fileContent(path, content string) *gh.RepositoryContent {
+	return &gh.RepositoryContent{
+		Type:     gh.Ptr("file"),
+		Encoding: gh.Ptr("base64"),
+		Name:     gh.Ptr(path[strings.LastIndex(path, "/")+1:]),
+		Path:     gh.Ptr(path),
+		SHA:      gh.Ptr(blobSHA(content)),
+		Size:     gh.Ptr(len(content)),
+		Content:  gh.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+	}
+}
+
+func (s *Server) getContents(w http.ResponseWriter, r *http.Request, repo *repository) {
+	files, ok := repo.files(r.URL.Query().Get("ref"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "No commit found for the ref "+r.URL.Query().Get("ref"))
+		return
+	}
+
+	path := strings.Trim(r.PathValue("path"), "/")
+	if content, ok := files[path]; ok {
+		writeJSON(w, http.StatusOK, fileContent(path, content))
+		return
+	}
+
+	// A directory lists its files and subdirectories
+	prefix := path + "/"
+	if path == "" {
+		prefix = ""
+	}
+	entries := make(map[string]*gh.RepositoryContent)
+	for file := range files {
+		rest, ok := strings.CutPrefix(file, prefix)
+		if !ok {
+			continue
+		}
+		name, _, isDir := strings.Cut(rest, "/")
+		if isDir {
+			entries[name] = &gh.RepositoryContent{Type: gh.Ptr("dir"), Name: gh.Ptr(name), Path: gh.Ptr(prefix + name)}
+		} else {
+			entries[name] = &gh.RepositoryContent{Type: gh.Ptr("file"), Name: gh.Ptr(name), Path: gh.Ptr(file), SHA: gh.Ptr(blobSHA(files[file]))}
+		}
+	}
+	if len(entries) == 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var dir []*gh.RepositoryContent
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		dir = append(dir, entries[name])
+	}
+	writeJSON(w, http.StatusOK, dir)
+}
+
+// branchFiles returns the branch named in a contents request, the default one
+// when empty, and its files.
+func branchFiles(w http.ResponseWriter, repo *repository, branch string) (string, map[string]string, bool) {
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+	if _, ok := repo.branches[branch]; !ok {
+		writeError(w, http.StatusNotFound, "Branch not found")
+		return "", nil, false
+	}
+	files, _ := repo.files(branch)
+	return branch, files, true
+}
+
+func (s *Server) putContents(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var opts gh.RepositoryContentFileOptions
+	if !decode(w, r, &opts) {
+		return
+	}
+	branch, files, ok := branchFiles(w, repo, opts.GetBranch())
+	if !ok {
+		return
+	}
+
+	path := r.PathValue("path")
+	current, exists := files[path]
+	switch {
+	case exists && opts.SHA == nil:
+		writeError(w, http.StatusUnprocessableEntity, `Invalid request. "sha" wasn't supplied.`)
+		return
+	case exists && opts.GetSHA() != blobSHA(current):
+		writeError(w, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, opts.GetSHA()))
+		return
+	}
+
+	files = maps.Clone(files)
+	files[path] = string(opts.Content)
+	sha := repo.commit(repo.branches[branch], files, opts.GetMessage())
+	repo.branches[branch] = sha
+
+	status := http.StatusCreated
+	if exists {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, &gh.RepositoryContentResponse{
+		Content: fileContent(path, files[path]),
+		Commit:  gh.Commit{SHA: gh.Ptr(sha), Message: opts.Message},
+	})
+}
+
+func (s *Server) deleteContents(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var opts gh.RepositoryContentFileOptions
+	if !decode(w, r, &opts) {
+		return
+	}
+	branch, files, ok := branchFiles(w, repo, opts.GetBranch())
+	if !ok {
+		return
+	}
+
+	path := r.PathValue("path")
+	current, exists := files[path]
+	switch {
+	case !exists:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	case opts.GetSHA() != blobSHA(current):
+		writeError(w, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, opts.GetSHA()))
+		return
+	}
+
+	files = maps.Clone(files)
+	delete(files, path)
+	sha := repo.commit(repo.branches[branch], files, opts.GetMessage())
+	repo.branches[branch] = sha
+
+	writeJSON(w, http.StatusOK, &gh.RepositoryContentResponse{Commit: gh.Commit{SHA: gh.Ptr(sha), Message: opts.Message}})
+}
+
+func (s *Server) compare(w http.ResponseWriter, r *http.Request, repo *repository) {
+	base, head, ok := strings.Cut(r.PathValue("basehead"), "...")
+	baseFiles, baseOK := repo.files(base)
+	headFiles, headOK := repo.files(head)
+	if !ok || !baseOK || !headOK {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var files []*gh.CommitFile
+	for _, path := range slices.Sorted(maps.Keys(headFiles)) {
+		before, existed := baseFiles[path]
+		switch {
+		case !existed:
+			files = append(files, &gh.CommitFile{Filename: gh.Ptr(path), Status: gh.Ptr("added")})
+		case before != headFiles[path]:
+			files = append(files, &gh.CommitFile{Filename: gh.Ptr(path), Status: gh.Ptr("modified")})
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(baseFiles)) {
+		if _, kept := headFiles[path]; !kept {
+			files = append(files, &gh.CommitFile{Filename: gh.Ptr(path), Status: gh.Ptr("removed")})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, &gh.CommitsComparison{
+		Status: gh.Ptr("ahead"),
+		Files:  paginate(w, r, files),
+	})
+}
+
+func (s *Server) reference(branch, sha string) *gh.Reference {
+	return &gh.Reference{
+		Ref:    gh.Ptr("refs/heads/" + branch),
+		Object: &gh.GitObject{Type: gh.Ptr("commit"), SHA: gh.Ptr(sha)},
+	}
+}
+
+func (s *Server) getRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	branch, ok := strings.CutPrefix(r.PathValue("ref"), "heads/")
+	sha, exists := repo.branches[branch]
+	if !ok || !exists {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.reference(branch, sha))
+}
+
+func (s *Server) createRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var ref gh.CreateRef
+	if !decode(w, r, &ref) {
+		return
+	}
+
+	branch, ok := strings.CutPrefix(ref.Ref, "refs/heads/")
+	switch {
+	case !ok:
+		writeError(w, http.StatusUnprocessableEntity, "Reference name is not valid")
+		return
+	case repo.branches[branch] != "":
+		writeError(w, http.StatusUnprocessableEntity, "Reference already exists")
+		return
+	case repo.commits[ref.SHA] == nil:
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+
+	repo.branches[branch] = ref.SHA
+	writeJSON(w, http.StatusCreated, s.reference(branch, ref.SHA))
+}
+
+func (s *Server) updateRef(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var update gh.UpdateRef
+	if !decode(w, r, &update) {
+		return
+	}
+
+	branch, _ := strings.CutPrefix(r.PathValue("ref"), "heads/")
+	current, exists := repo.branches[branch]
+	switch {
+	case !exists:
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	case repo.commits[update.SHA] == nil:
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	case !update.GetForce() && !repo.isAncestor(current, update.SHA):
+		writeError(w, http.StatusUnprocessableEntity, "Update is not a fast forward")
+		return
+	}
+
+	repo.branches[branch] = update.SHA
+	writeJSON(w, http.StatusOK, s.reference(branch, update.SHA))
+}
+
+func gitCommit(c *commit) *gh.Commit {
+	commit := &gh.Commit{
+		SHA:     gh.Ptr(c.sha),
+		Message: gh.Ptr(c.message),
+		Tree:    &gh.Tree{SHA: gh.Ptr(c.tree)},
+	}
+	for _, parent := range c.parents {
+		commit.Parents = append(commit.Parents, &gh.Commit{SHA: gh.Ptr(parent)})
+	}
+	return commit
+}
+
+func (s *Server) getCommit(w http.ResponseWriter, r *http.Request, repo *repository) {
+	c, ok := repo.commits[r.PathValue("sha")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitCommit(c))
+}
+
+func (s *Server) createCommit(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var req struct {
+		Message string   `json:"message"`
+		Tree    string   `json:"tree"`
+		Parents []string `json:"parents"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if _, ok := repo.trees[req.Tree]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Tree SHA does not exist")
+		return
+	}
+	for _, parent := range req.Parents {
+		if _, ok := repo.commits[parent]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Parent SHA does not exist or is not a commit object")
+			return
+		}
+	}
+
+	parent := ""
+	if len(req.Parents) > 0 {
+		parent = req.Parents[0]
+	}
+	sha := repo.commit(parent, repo.trees[req.Tree], req.Message)
+	writeJSON(w, http.StatusCreated, gitCommit(repo.commits[sha]))
+}
+
+func (s *Server) getTree(w http.ResponseWriter, r *http.Request, repo *repository) {
+	sha := r.PathValue("sha")
+	files, ok := repo.trees[sha]
+	if !ok {
+		c, found := repo.resolve(sha)
+		if !found {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		sha, files = c.tree, repo.trees[c.tree]
+	}
+
+	recursive := r.URL.Query().Get("recursive") != ""
+	dirs := make(map[string]bool)
+	var entries []*gh.TreeEntry
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		parts := strings.Split(path, "/")
+		for i := 1; i < len(parts); i++ {
+			dir := strings.Join(parts[:i], "/")
+			if !dirs[dir] && (recursive || i == 1) {
+				dirs[dir] = true
+				entries = append(entries, &gh.TreeEntry{Path: gh.Ptr(dir), Mode: gh.Ptr("040000"), Type: gh.Ptr("tree")})
+			}
+		}
+		if recursive || len(parts) == 1 {
+			entries = append(entries, &gh.TreeEntry{
+				Path: gh.Ptr(path),
+				Mode: gh.Ptr("100644"),
+				Type: gh.Ptr("blob"),
+				SHA:  gh.Ptr(blobSHA(files[path])),
+				Size: gh.Ptr(len(files[path])),
+			})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, &gh.Tree{SHA: gh.Ptr(sha), Entries: entries, Truncated: gh.Ptr(false)})
+}
+
+func (s *Server) createTree(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var req struct {
+		BaseTree string                       `json:"base_tree"`
+		Tree     []map[string]json.RawMessage `json:"tree"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	files := make(map[string]string)
+	if req.BaseTree != "" {
+		base, ok := repo.trees[req.BaseTree]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "base_tree is not a valid tree oid")
+			return
+		}
+		files = maps.Clone(base)
+	}
+
+	for _, entry := range req.Tree {
+		var path, content string
+		var sha *string
+		_ = json.Unmarshal(entry["path"], &path)
+		if raw, ok := entry["content"]; ok {
+			_ = json.Unmarshal(raw, &content)
+			files[path] = content
+			continue
+		}
+		_ = json.Unmarshal(entry["sha"], &sha)
+		if sha == nil {
+			// A null SHA removes the path
+			delete(files, path)
+			continue
+		}
+		blob, ok := repo.blobs[*sha]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "tree.sha "+*sha+" is not a valid blob")
+			return
+		}
+		files[path] = blob
+	}
+
+	sha := repo.storeTree(files)
+	writeJSON(w, http.StatusCreated, &gh.Tree{SHA: gh.Ptr(sha)})
+}
+
+func (s *Server) pullRequest(repo *repository, pr *PullRequest) *gh.PullRequest {
+	head, _ := repo.branches[pr.Head]
+	return &gh.PullRequest{
+		Number:  gh.Ptr(pr.Number),
+		NodeID:  gh.Ptr(pr.NodeID),
+		Title:   gh.Ptr(pr.Title),
+		Body:    gh.Ptr(pr.Body),
+		State:   gh.Ptr(pr.State),
+		Draft:   gh.Ptr(pr.Draft),
+		HTMLURL: gh.Ptr(s.htmlURL(repo, "pull", pr.Number)),
+		Head:    &gh.PullRequestBranch{Ref: gh.Ptr(pr.Head), Label: gh.Ptr(s.org + ":" + pr.Head), SHA: gh.Ptr(head)},
+		Base:    &gh.PullRequestBranch{Ref: gh.Ptr(pr.Base), Label: gh.Ptr(s.org + ":" + pr.Base)},
+	}
+}
+
+func (s *Server) listPulls(w http.ResponseWriter, r *http.Request, repo *repository) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	if state == "" {
+		state = "open"
+	}
+
+	var prs []*gh.PullRequest
+	for _, pr := range repo.pulls {
+		if state != "all" && pr.State != state {
+			continue
+		}
+		if head := query.Get("head"); head != "" && head != s.org+":"+pr.Head {
+			continue
+		}
+		if base := query.Get("base"); base != "" && base != pr.Base {
+			continue
+		}
+		prs = append(prs, s.pullRequest(repo, pr))
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, prs))
+}
+
+func (s *Server) createPull(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var req gh.NewPullRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	head, base := req.GetHead(), req.GetBase()
+	switch {
+	case repo.branches[head] == "" || repo.branches[base] == "":
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	case repo.branches[head] == repo.branches[base]:
+		writeError(w, http.StatusUnprocessableEntity, "No commits between "+base+" and "+head)
+		return
+	}
+	for _, pr := range repo.pulls {
+		if pr.State == "open" && pr.Head == head {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("A pull request already exists for %s:%s.", s.org, head))
+			return
+		}
+	}
+
+	number := repo.nextNumber()
+	pr := &PullRequest{
+		Number: number,
+		NodeID: fmt.Sprintf("PR_%s_%d", repo.Name, number),
+		Title:  req.GetTitle(),
+		Body:   req.GetBody(),
+		Head:   head,
+		Base:   base,
+		Draft:  req.GetDraft(),
+		State:  "open",
+	}
+	repo.pulls = append(repo.pulls, pr)
+	writeJSON(w, http.StatusCreated, s.pullRequest(repo, pr))
+}
+
+// number parses the number of the path and returns the pull request or issue it names.
+func number(w http.ResponseWriter, r *http.Request, repo *repository) (*PullRequest, *Issue, bool) {
+	n, err := strconv.Atoi(r.PathValue("number"))
+	if err == nil {
+		if pr := repo.pull(n); pr != nil {
+			return pr, nil, true
+		}
+		if issue := repo.issue(n); issue != nil {
+			return nil, issue, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, nil, false
+}
+
+func (s *Server) editPull(w http.ResponseWriter, r *http.Request, repo *repository) {
+	pr, _, ok := number(w, r, repo)
+	if !ok || pr == nil {
+		if ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+		}
+		return
+	}
+	var req gh.PullRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Title != nil {
+		pr.Title = req.GetTitle()
+	}
+	if req.Body != nil {
+		pr.Body = req.GetBody()
+	}
+	writeJSON(w, http.StatusOK, s.pullRequest(repo, pr))
+}
+
+func (s *Server) requestReviewers(w http.ResponseWriter, r *http.Request, repo *repository) {
+	pr, _, ok := number(w, r, repo)
+	if !ok || pr == nil {
+		if ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+		}
+		return
+	}
+	var req gh.ReviewersRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	pr.Reviewers = append(pr.Reviewers, req.Reviewers...)
+	pr.TeamReviewers = append(pr.TeamReviewers, req.TeamReviewers...)
+	writeJSON(w, http.StatusCreated, s.pullRequest(repo, pr))
+}
+
+func (s *Server) getLabel(w http.ResponseWriter, r *http.Request, repo *repository) {
+	name := r.PathValue("name")
+	if !slices.Contains(repo.labels, name) {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, &gh.Label{Name: gh.Ptr(name)})
+}
+
+func (s *Server) createLabel(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var label gh.Label
+	if !decode(w, r, &label) {
+		return
+	}
+	if slices.Contains(repo.labels, label.GetName()) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+	repo.labels = append(repo.labels, label.GetName())
+	writeJSON(w, http.StatusCreated, &label)
+}
+
+func (s *Server) issue(repo *repository, issue *Issue) *gh.Issue {
+	return &gh.Issue{
+		Number:  gh.Ptr(issue.Number),
+		NodeID:  gh.Ptr(issue.NodeID),
+		Title:   gh.Ptr(issue.Title),
+		Body:    gh.Ptr(issue.Body),
+		State:   gh.Ptr(issue.State),
+		User:    &gh.User{Login: gh.Ptr(issue.Author)},
+		HTMLURL: gh.Ptr(s.htmlURL(repo, "issues", issue.Number)),
+	}
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var req gh.IssueRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	number := repo.nextNumber()
+	issue := &Issue{
+		Number: number,
+		NodeID: fmt.Sprintf("I_%s_%d", repo.Name, number),
+		Title:  req.GetTitle(),
+		Body:   req.GetBody(),
+		State:  "open",
+		Author: Login,
+		Labels: req.GetLabels(),
+	}
+	repo.issues = append(repo.issues, issue)
+	writeJSON(w, http.StatusCreated, s.issue(repo, issue))
+}
+
+func (s *Server) editIssue(w http.ResponseWriter, r *http.Request, repo *repository) {
+	_, issue, ok := number(w, r, repo)
+	if !ok || issue == nil {
+		if ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+		}
+		return
+	}
+	var req gh.IssueRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Title != nil {
+		issue.Title = req.GetTitle()
+	}
+	if req.Body != nil {
+		issue.Body = req.GetBody()
+	}
+	if req.State != nil {
+		issue.State = req.GetState()
+	}
+	writeJSON(w, http.StatusOK, s.issue(repo, issue))
+}
+
+func (s *Server) addLabels(w http.ResponseWriter, r *http.Request, repo *repository) {
+	pr, issue, ok := number(w, r, repo)
+	if !ok {
+		return
+	}
+	var labels []string
+	if !decode(w, r, &labels) {
+		return
+	}
+
+	var all *[]string
+	if pr != nil {
+		all = &pr.Labels
+	} else {
+		all = &issue.Labels
+	}
+	var out []*gh.Label
+	for _, label := range labels {
+		if !slices.Contains(*all, label) {
+			*all = append(*all, label)
+		}
+		if !slices.Contains(repo.labels, label) {
+			repo.labels = append(repo.labels, label)
+		}
+	}
+	for _, label := range *all {
+		out = append(out, &gh.Label{Name: gh.Ptr(label)})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) addAssignees(w http.ResponseWriter, r *http.Request, repo *repository) {
+	pr, issue, ok := number(w, r, repo)
+	if !ok {
+		return
+	}
+	var req struct {
+		Assignees []string `json:"assignees"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if pr != nil {
+		pr.Assignees = append(pr.Assignees, req.Assignees...)
+		writeJSON(w, http.StatusCreated, &gh.Issue{Number: gh.Ptr(pr.Number)})
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.issue(repo, issue))
+}
+
+func comment(c Comment) *gh.IssueComment {
+	return &gh.IssueComment{ID: gh.Ptr(c.ID), Body: gh.Ptr(c.Body)}
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request, repo *repository) {
+	_, issue, ok := number(w, r, repo)
+	if !ok {
+		return
+	}
+
+	var comments []*gh.IssueComment
+	if issue != nil {
+		for _, c := range issue.Comments {
+			comments = append(comments, comment(c))
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, comments))
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request, repo *repository) {
+	_, issue, ok := number(w, r, repo)
+	if !ok || issue == nil {
+		if ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+		}
+		return
+	}
+	var req gh.IssueComment
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.commentID++
+	c := Comment{ID: s.commentID, Body: req.GetBody()}
+	issue.Comments = append(issue.Comments, c)
+	writeJSON(w, http.StatusCreated, comment(c))
+}
+
+// findComment returns the issue holding the comment of the path and its index.
+func findComment(w http.ResponseWriter, r *http.Request, repo *repository) (*Issue, int, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err == nil {
+		for _, issue := range repo.issues {
+			for i, c := range issue.Comments {
+				if c.ID == id {
+					return issue, i, true
+				}
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, 0, false
+}
+
+func (s *Server) editComment(w http.ResponseWriter, r *http.Request, repo *repository) {
+	issue, i, ok := findComment(w, r, repo)
+	if !ok {
+		return
+	}
+	var req gh.IssueComment
+	if !decode(w, r, &req) {
+		return
+	}
+
+	issue.Comments[i].Body = req.GetBody()
+	writeJSON(w, http.StatusOK, comment(issue.Comments[i]))
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request, repo *repository) {
+	issue, i, ok := findComment(w, r, repo)
+	if !ok {
+		return
+	}
+	issue.Comments = slices.Delete(issue.Comments, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listIssues lists the issues and pull requests of repo, like GitHub does,
+// filtered by the state and creator parameters.
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request, repo *repository) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	if state == "" {
+		state = "open"
+	}
+	creator := query.Get("creator")
+	matches := func(itemState, author string) bool {
+		return (state == "all" || itemState == state) && (creator == "" || author == creator)
+	}
+
+	var issues []*gh.Issue
+	for _, issue := range repo.issues {
+		if matches(issue.State, issue.Author) {
+			issues = append(issues, s.issue(repo, issue))
+		}
+	}
+	for _, pr := range repo.pulls {
+		if matches(pr.State, Login) {
+			issues = append(issues, &gh.Issue{
+				Number:           gh.Ptr(pr.Number),
+				Title:            gh.Ptr(pr.Title),
+				Body:             gh.Ptr(pr.Body),
+				State:            gh.Ptr(pr.State),
+				User:             &gh.User{Login: gh.Ptr(Login)},
+				PullRequestLinks: &gh.PullRequestLinks{HTMLURL: gh.Ptr(s.htmlURL(repo, "pull", pr.Number))},
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, issues))
+}
+
+func (s *Server) getUser(w http.ResponseWriter, _ *http.Request, _ *repository) {
+	writeJSON(w, http.StatusOK, &gh.User{Login: gh.Ptr(Login)})
+}
+
+func (s *Server) uploadSARIF(w http.ResponseWriter, r *http.Request, repo *repository) {
+	var req gh.SarifAnalysis
+	if !decode(w, r, &req) {
+		return
+	}
+	if _, err := base64.StdEncoding.DecodeString(req.GetSarif()); err != nil || req.GetCommitSHA() == "" || req.GetRef() == "" {
+		writeError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	repo.sarifs++
+	id := fmt.Sprintf("sarif-%d", repo.sarifs)
+	writeJSON(w, http.StatusAccepted, &gh.SarifID{
+		ID:  gh.Ptr(id),
+		URL: gh.Ptr(fmt.Sprintf("%s/repos/%s/%s/code-scanning/sarifs/%s", s.URL, s.org, repo.Name, id)),
+	})
+}
+
+// graphql supports the enablePullRequestAutoMerge and pinIssue mutations.
+func (s *Server) graphql(w http.ResponseWriter, r *http.Request, _ *repository) {
+	var req struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	fail := func(message string) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"data":   nil,
+			"errors": []map[string]string{{"type": "NOT_FOUND", "message": message}},
+		})
+	}
+
+	switch {
+	case strings.Contains(req.Query, "enablePullRequestAutoMerge"):
+		id, _ := req.Variables["pullRequestId"].(string)
+		method, _ := req.Variables["mergeMethod"].(string)
+		for _, repo := range s.repos {
+			for _, pr := range repo.pulls {
+				if pr.NodeID == id {
+					pr.AutoMerge = strings.ToLower(method)
+					writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"enablePullRequestAutoMerge": map[string]any{}}})
+					return
+				}
+			}
+		}
+		fail(fmt.Sprintf("Could not resolve to a node with the global id of '%s'", id))
+	case strings.Contains(req.Query, "pinIssue"):
+		id, _ := req.Variables["issueId"].(string)
+		for _, repo := range s.repos {
+			for _, issue := range repo.issues {
+				if issue.NodeID == id {
+					issue.Pinned = true
+					writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"pinIssue": map[string]any{}}})
+					return
+				}
+			}
+		}
+		fail(fmt.Sprintf("Could not resolve to a node with the global id of '%s'", id))
+	default:
+		fail("githubtest: unsupported query")
+	}
+}
+
+func (s *Server) raw(w http.ResponseWriter, r *http.Request, repo *repository) {
+	files, ok := repo.files(r.PathValue("ref"))
+	content, found := files[r.PathValue("path")]
+	if !ok || !found {
+		http.Error(w, "404: Not Found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(content))
+}
@@ -0,0 +1,190 @@
+package githubtest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Repo describes a repository added to the server.
+type Repo struct {
+	Name          string
+	DefaultBranch string // "main" when empty
+	Private       bool
+	Archived      bool
+	Topics        []string
+	Files         map[string]string // Content of the default branch, by path
+}
+
+// PullRequest is a pull request opened on the server.
+type PullRequest struct {
+	Number        int
+	NodeID        string
+	Title         string
+	Body          string
+	Head          string
+	Base          string
+	Draft         bool
+	State         string // "open" or "closed"
+	Labels        []string
+	Assignees     []string
+	Reviewers     []string
+	TeamReviewers []string
+	AutoMerge     string // Merge method of auto-merge, empty when disabled
+}
+
+// Issue is an issue opened on the server.
+type Issue struct {
+	Number   int
+	NodeID   string
+	Title    string
+	Body     string
+	State    string // "open" or "closed"
+	Author   string // Login of the user who opened it
+	Labels   []string
+	Pinned   bool
+	Comments []Comment
+}
+
+// Comment is a comment of an issue.
+type Comment struct {
+	ID   int64
+	Body string
+}
+
+type commit struct {
+	sha     string
+	tree    string
+	message string
+	parents []string
+}
+
+// repository is the state of a repository: its git objects, refs, pull
+// requests, issues and labels.
+type repository struct {
+	Repo
+	branches map[string]string            // Branch name to commit SHA
+	commits  map[string]*commit           // Commits by SHA
+	trees    map[string]map[string]string // Trees by SHA, as path to content
+	blobs    map[string]string            // Blob contents by SHA
+	pulls    []*PullRequest
+	issues   []*Issue
+	labels   []string
+	sarifs   int
+	number   int // Last issue or pull request number
+}
+
+func newRepository(r Repo) *repository {
+	if r.DefaultBranch == "" {
+		r.DefaultBranch = "main"
+	}
+	repo := &repository{
+		Repo:     r,
+		branches: make(map[string]string),
+		commits:  make(map[string]*commit),
+		trees:    make(map[string]map[string]string),
+		blobs:    make(map[string]string),
+	}
+	repo.branches[r.DefaultBranch] = repo.commit("", r.Files, "Initial commit")
+	repo.Files = nil
+	return repo
+}
+
+func hash(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// blobSHA identifies content like git does.
+func blobSHA(content string) string {
+	sum := sha1.Sum(fmt.Appendf(nil, "blob %d\x00%s", len(content), content))
+	return hex.EncodeToString(sum[:])
+}
+
+// storeTree stores files as a tree and returns its SHA.
+func (r *repository) storeTree(files map[string]string) string {
+	parts := []string{"tree"}
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		sha := blobSHA(files[path])
+		r.blobs[sha] = files[path]
+		parts = append(parts, path, sha)
+	}
+	sha := hash(parts...)
+	r.trees[sha] = maps.Clone(files)
+	return sha
+}
+
+// commit stores a commit of files on top of parent, which may be empty, and returns its SHA.
+func (r *repository) commit(parent string, files map[string]string, message string) string {
+	tree := r.storeTree(files)
+	var parents []string
+	if parent != "" {
+		parents = []string{parent}
+	}
+	sha := hash("commit", tree, parent, message, fmt.Sprint(len(r.commits)))
+	r.commits[sha] = &commit{sha: sha, tree: tree, message: message, parents: parents}
+	return sha
+}
+
+// resolve returns the commit a branch, a commit SHA, HEAD or an empty ref points to.
+func (r *repository) resolve(ref string) (*commit, bool) {
+	ref = strings.TrimPrefix(ref, "refs/heads/")
+	if ref == "" || ref == "HEAD" {
+		ref = r.DefaultBranch
+	}
+	if sha, ok := r.branches[ref]; ok {
+		ref = sha
+	}
+	c, ok := r.commits[ref]
+	return c, ok
+}
+
+// files returns the files of the tree of a ref.
+func (r *repository) files(ref string) (map[string]string, bool) {
+	c, ok := r.resolve(ref)
+	if !ok {
+		return nil, false
+	}
+	return r.trees[c.tree], true
+}
+
+// isAncestor reports whether ancestor is sha or one of its parents.
+func (r *repository) isAncestor(ancestor, sha string) bool {
+	for sha != "" {
+		if sha == ancestor {
+			return true
+		}
+		c, ok := r.commits[sha]
+		if !ok || len(c.parents) == 0 {
+			return false
+		}
+		sha = c.parents[0]
+	}
+	return false
+}
+
+func (r *repository) nextNumber() int {
+	r.number++
+	return r.number
+}
+
+func (r *repository) pull(number int) *PullRequest {
+	for _, pr := range r.pulls {
+		if pr.Number == number {
+			return pr
+		}
+	}
+	return nil
+}
+
+func (r *repository) issue(number int) *Issue {
+	for _, issue := range r.issues {
+		if issue.Number == number {
+			return issue
+		}
+	}
+	return nil
+}
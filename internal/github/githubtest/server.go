@@ -0,0 +1,325 @@
+// Package githubtest serves an in-memory GitHub API for end-to-end tests of the
+// client: repositories of one organization with their commits, trees,
+// contents and branches, pull requests, labels, issues and code scanning
+// uploads. Faults can be injected to exercise the error paths.
+package githubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Login is the user every request is authenticated as, who opens the issues
+// and pull requests.
+const Login = "policy-bot"
+
+// Server is a fake GitHub API. Point the client at it with github.WithBaseURL(s.BaseURL()).
+type Server struct {
+	*httptest.Server
+
+	org string
+	mux *http.ServeMux
+
+	mu        sync.Mutex
+	repos     map[string]*repository
+	faults    []*Fault
+	requests  []string
+	commentID int64
+}
+
+// NewServer starts a server for the organization org, closed at the end of the test.
+func NewServer(t testing.TB, org string) *Server {
+	s := Start(org)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Start starts a server for the organization org outside of a test. The caller must Close it.
+func Start(org string) *Server {
+	s := &Server{org: org, mux: http.NewServeMux(), repos: make(map[string]*repository)}
+	s.routes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// BaseURL is the URL of the REST and GraphQL APIs.
+func (s *Server) BaseURL() *url.URL {
+	u, _ := url.Parse(s.URL + "/")
+	return u
+}
+
+// RawURL is the URL of the content of path at ref in repo, like on raw.githubusercontent.com.
+func (s *Server) RawURL(repo, ref, path string) string {
+	return fmt.Sprintf("%s/raw/%s/%s/%s/%s", s.URL, s.org, repo, ref, path)
+}
+
+// AddRepo adds a repository whose default branch holds r.Files in a single commit.
+func (s *Server) AddRepo(r Repo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.repos[r.Name] = newRepository(r)
+}
+
+// Commit commits files on branch of repo, creating the branch from the default
+// one if needed, and returns the SHA of the commit. A file with empty content
+// is removed.
+func (s *Server) Commit(repo, branch, message string, files map[string]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.mustRepo(repo)
+	parent, ok := r.branches[branch]
+	if !ok {
+		parent = r.branches[r.DefaultBranch]
+	}
+	tree := maps.Clone(r.trees[r.commits[parent].tree])
+	for path, content := range files {
+		if content == "" {
+			delete(tree, path)
+			continue
+		}
+		tree[path] = content
+	}
+	sha := r.commit(parent, tree, message)
+	r.branches[branch] = sha
+	return sha
+}
+
+// File returns the content of path at ref, a branch or a commit SHA, of repo.
+func (s *Server) File(repo, ref, path string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, ok := s.mustRepo(repo).files(ref)
+	if !ok {
+		return "", false
+	}
+	content, ok := files[path]
+	return content, ok
+}
+
+// Branches returns the branches of repo, sorted.
+func (s *Server) Branches(repo string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(maps.Keys(s.mustRepo(repo).branches))
+}
+
+// PullRequests returns the pull requests of repo, in the order they were opened.
+func (s *Server) PullRequests(repo string) []PullRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var prs []PullRequest
+	for _, pr := range s.mustRepo(repo).pulls {
+		prs = append(prs, *pr)
+	}
+	return prs
+}
+
+// ClosePullRequest closes a pull request of repo, as if it was merged or declined.
+func (s *Server) ClosePullRequest(repo string, number int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mustRepo(repo).pull(number).State = "closed"
+}
+
+// Issues returns the issues of repo, in the order they were opened.
+func (s *Server) Issues(repo string) []Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var issues []Issue
+	for _, issue := range s.mustRepo(repo).issues {
+		copied := *issue
+		copied.Comments = slices.Clone(issue.Comments)
+		issues = append(issues, copied)
+	}
+	return issues
+}
+
+// Labels returns the labels of repo, in the order they were created.
+func (s *Server) Labels(repo string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.mustRepo(repo).labels)
+}
+
+// SARIFUploads returns the number of SARIF logs uploaded to repo.
+func (s *Server) SARIFUploads(repo string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mustRepo(repo).sarifs
+}
+
+// Requests returns every request received, as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *Server) mustRepo(name string) *repository {
+	r, ok := s.repos[name]
+	if !ok {
+		panic(fmt.Sprintf("githubtest: unknown repository %q", name))
+	}
+	return r
+}
+
+// Fault makes requests fail instead of reaching the model.
+type Fault struct {
+	Method string // Method of the failing requests, any when empty
+	Path   string // path.Match pattern of the failing requests, e.g. "/repos/org/*/pulls"
+	Status int
+	Times  int // Number of requests to fail, all of them when 0
+
+	rateLimited bool
+	hits        int
+}
+
+// NotFound fails the requests matching method and pattern with 404 Not Found.
+func NotFound(method, pattern string) Fault {
+	return Fault{Method: method, Path: pattern, Status: http.StatusNotFound}
+}
+
+// Conflict fails the requests matching method and pattern with 409 Conflict.
+func Conflict(method, pattern string) Fault {
+	return Fault{Method: method, Path: pattern, Status: http.StatusConflict}
+}
+
+// Unprocessable fails the requests matching method and pattern with 422 Unprocessable Entity.
+func Unprocessable(method, pattern string) Fault {
+	return Fault{Method: method, Path: pattern, Status: http.StatusUnprocessableEntity}
+}
+
+// RateLimited fails the requests matching method and pattern as GitHub does
+// when the primary rate limit is exhausted.
+func RateLimited(method, pattern string) Fault {
+	return Fault{Method: method, Path: pattern, Status: http.StatusForbidden, rateLimited: true}
+}
+
+// Inject adds a fault, checked before the model answers every request.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Times > 0 && f.hits >= f.Times {
+		return false
+	}
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	matched, _ := path.Match(f.Path, r.URL.Path)
+	return matched
+}
+
+func (f *Fault) write(w http.ResponseWriter) {
+	if f.rateLimited {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		writeError(w, f.Status, "API rate limit exceeded for installation.")
+		return
+	}
+	writeError(w, f.Status, http.StatusText(f.Status))
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	var fault *Fault
+	for _, f := range s.faults {
+		if f.matches(r) {
+			f.hits++
+			fault = f
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if fault != nil {
+		fault.write(w)
+		return
+	}
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", "4999")
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle routes pattern to h, called with the model locked and the repository
+// of the path, if the pattern has one.
+func (s *Server) handle(pattern string, h func(w http.ResponseWriter, r *http.Request, repo *repository)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var repo *repository
+		if name := r.PathValue("repo"); name != "" {
+			repo = s.repos[name]
+			if r.PathValue("owner") != s.org || repo == nil {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+		}
+		h(w, r, repo)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+// paginate returns the page of items requested by the page and per_page
+// parameters, and links the next one like GitHub does.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) []T {
+	query := r.URL.Query()
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 30
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	if end < len(items) {
+		next := *r.URL
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+	}
+	return items[start:end]
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGraphQLTestClient(t *testing.T, handler http.HandlerFunc) *graphQL {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := gh.NewClient(nil)
+	c.BaseURL, _ = url.Parse(server.URL + "/")
+	return &graphQL{client: c}
+}
+
+func TestGraphQLDo_Success(t *testing.T) {
+	g := newGraphQLTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/graphql", r.URL.Path)
+
+		var req graphQLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "query { viewer { login } }", req.Query)
+		assert.Equal(t, "value", req.Variables["key"])
+
+		w.Write([]byte(`{"data": {"viewer": {"login": "tracker-tv-bot"}}}`))
+	})
+
+	var out struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	err := g.Do(context.Background(), "query { viewer { login } }", map[string]any{"key": "value"}, &out)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tracker-tv-bot", out.Viewer.Login)
+}
+
+func TestGraphQLDo_Errors(t *testing.T) {
+	g := newGraphQLTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [{"type": "UNPROCESSABLE", "message": "first"}, {"message": "second"}]}`))
+	})
+
+	err := g.Do(context.Background(), "mutation {}", nil, nil)
+
+	var gqlErrs GraphQLErrors
+	assert.ErrorAs(t, err, &gqlErrs)
+	assert.Len(t, gqlErrs, 2)
+	assert.Equal(t, "UNPROCESSABLE", gqlErrs[0].Type)
+	assert.EqualError(t, err, "graphql: first; second")
+}
+
+func TestGraphQLDo_HTTPError(t *testing.T) {
+	g := newGraphQLTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Bad credentials"}`))
+	})
+
+	err := g.Do(context.Background(), "query {}", nil, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Bad credentials")
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+
+	gh "github.com/google/go-github/v80/github"
+)
+
+func (c *client) GetLabel(ctx context.Context, repo, name string) (*gh.Label, *gh.Response, error) {
+	return c.issues.GetLabel(ctx, c.org, repo, name)
+}
+
+func (c *client) CreateLabel(ctx context.Context, repo, name, color string) (*gh.Label, error) {
+	label := &gh.Label{
+		Name:  gh.Ptr(name),
+		Color: gh.Ptr(color),
+	}
+	created, _, err := c.issues.CreateLabel(ctx, c.org, repo, label)
+	return created, err
+}
+
+func (c *client) AddLabelsToIssue(ctx context.Context, repo string, number int, labels []string) error {
+	_, _, err := c.issues.AddLabelsToIssue(ctx, c.org, repo, number, labels)
+	return err
+}
+
+func (c *client) AddAssignees(ctx context.Context, repo string, number int, assignees []string) error {
+	_, _, err := c.issues.AddAssignees(ctx, c.org, repo, number, assignees)
+	return err
+}
+
+func (c *client) CreateIssue(ctx context.Context, repo, title, body string, labels []string) (*gh.Issue, error) {
+	issue := &gh.IssueRequest{
+		Title: gh.Ptr(title),
+		Body:  gh.Ptr(body),
+	}
+	if len(labels) > 0 {
+		issue.Labels = &labels
+	}
+	created, _, err := c.issues.Create(ctx, c.org, repo, issue)
+	return created, err
+}
+
+func (c *client) EditIssue(ctx context.Context, repo string, number int, title, body string) (*gh.Issue, error) {
+	issue := &gh.IssueRequest{
+		Title: gh.Ptr(title),
+		Body:  gh.Ptr(body),
+	}
+	edited, _, err := c.issues.Edit(ctx, c.org, repo, number, issue)
+	return edited, err
+}
+
+// ListIssues returns the open issues of repo opened by creator, a login.
+// Pull requests, which GitHub lists as issues, are left out.
+func (c *client) ListIssues(ctx context.Context, repo, creator string) ([]*gh.Issue, error) {
+	var allIssues []*gh.Issue
+
+	opts := &gh.IssueListByRepoOptions{State: "open", Creator: creator, ListOptions: gh.ListOptions{PerPage: 100}}
+
+	for {
+		issues, resp, err := c.issues.ListByRepo(ctx, c.org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range issues {
+			if !issue.IsPullRequest() {
+				allIssues = append(allIssues, issue)
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return allIssues, nil
+}
+
+// ListIssueComments returns the comments of an issue, oldest first.
+func (c *client) ListIssueComments(ctx context.Context, repo string, number int) ([]*gh.IssueComment, error) {
+	var allComments []*gh.IssueComment
+
+	opts := &gh.IssueListCommentsOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+
+	for {
+		comments, resp, err := c.issues.ListComments(ctx, c.org, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		allComments = append(allComments, comments...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allComments, nil
+}
+
+func (c *client) CreateIssueComment(ctx context.Context, repo string, number int, body string) error {
+	_, _, err := c.issues.CreateComment(ctx, c.org, repo, number, &gh.IssueComment{Body: gh.Ptr(body)})
+	return err
+}
+
+func (c *client) EditIssueComment(ctx context.Context, repo string, commentID int64, body string) error {
+	_, _, err := c.issues.EditComment(ctx, c.org, repo, commentID, &gh.IssueComment{Body: gh.Ptr(body)})
+	return err
+}
+
+func (c *client) DeleteIssueComment(ctx context.Context, repo string, commentID int64) error {
+	_, err := c.issues.DeleteComment(ctx, c.org, repo, commentID)
+	return err
+}
+
+const pinIssueMutation = `mutation($issueId: ID!) {
+  pinIssue(input: {issueId: $issueId}) {
+    clientMutationId
+  }
+}`
+
+// PinIssue pins an issue, identified by its node ID, to its repository.
+func (c *client) PinIssue(ctx context.Context, issueID string) error {
+	return c.graphql.Do(ctx, pinIssueMutation, map[string]any{"issueId": issueID}, nil)
+}
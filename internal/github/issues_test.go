@@ -0,0 +1,332 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+func TestGetLabel_Success(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		GetLabel(mock.Anything, "org-name", "repo-name", "policy-bot").
+		Once().
+		Return(&gh.Label{Name: gh.Ptr("policy-bot")}, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	label, resp, err := c.GetLabel(ctx, "repo-name", "policy-bot")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "policy-bot", label.GetName())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGetLabel_NotFound(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		GetLabel(mock.Anything, "org-name", "repo-name", "policy-bot").
+		Once().
+		Return(nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	label, resp, err := c.GetLabel(ctx, "repo-name", "policy-bot")
+
+	assert.Error(t, err)
+	assert.Nil(t, label)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCreateLabel_Success(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		CreateLabel(mock.Anything, "org-name", "repo-name",
+			mock.MatchedBy(func(l *gh.Label) bool {
+				return l.GetName() == "policy-bot" && l.GetColor() == "ededed"
+			}),
+		).
+		Once().
+		Return(&gh.Label{Name: gh.Ptr("policy-bot")}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	label, err := c.CreateLabel(ctx, "repo-name", "policy-bot", "ededed")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "policy-bot", label.GetName())
+}
+
+func TestCreateLabel_Error(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		CreateLabel(mock.Anything, "org-name", "repo-name", mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("validation failed"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	label, err := c.CreateLabel(ctx, "repo-name", "policy-bot", "ededed")
+
+	assert.Error(t, err)
+	assert.Nil(t, label)
+}
+
+func TestAddLabelsToIssue_Success(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		AddLabelsToIssue(mock.Anything, "org-name", "repo-name", 42, []string{"policy-bot", "ci"}).
+		Once().
+		Return([]*gh.Label{{Name: gh.Ptr("policy-bot")}, {Name: gh.Ptr("ci")}}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	err := c.AddLabelsToIssue(ctx, "repo-name", 42, []string{"policy-bot", "ci"})
+
+	assert.NoError(t, err)
+}
+
+func TestAddLabelsToIssue_Error(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		AddLabelsToIssue(mock.Anything, "org-name", "repo-name", 42, mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("API error"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	err := c.AddLabelsToIssue(ctx, "repo-name", 42, []string{"policy-bot"})
+
+	assert.Error(t, err)
+}
+
+func TestAddAssignees_Success(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		AddAssignees(mock.Anything, "org-name", "repo-name", 42, []string{"alice"}).
+		Once().
+		Return(&gh.Issue{Number: gh.Ptr(42)}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	err := c.AddAssignees(ctx, "repo-name", 42, []string{"alice"})
+
+	assert.NoError(t, err)
+}
+
+func TestAddAssignees_Error(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		AddAssignees(mock.Anything, "org-name", "repo-name", 42, mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("API error"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	err := c.AddAssignees(ctx, "repo-name", 42, []string{"alice"})
+
+	assert.Error(t, err)
+}
+
+func TestCreateIssue_Success(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		Create(mock.Anything, "org-name", "repo-name",
+			mock.MatchedBy(func(i *gh.IssueRequest) bool {
+				return i.GetTitle() == "Policy compliance" && i.GetBody() == "body" && i.GetLabels()[0] == "policy-bot"
+			}),
+		).
+		Once().
+		Return(&gh.Issue{Number: gh.Ptr(7)}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	issue, err := c.CreateIssue(ctx, "repo-name", "Policy compliance", "body", []string{"policy-bot"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, issue.GetNumber())
+}
+
+func TestCreateIssue_Error(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		Create(mock.Anything, "org-name", "repo-name", mock.MatchedBy(func(i *gh.IssueRequest) bool {
+			return i.Labels == nil
+		})).
+		Once().
+		Return(nil, nil, errors.New("API error"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	issue, err := c.CreateIssue(ctx, "repo-name", "Policy compliance", "body", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, issue)
+}
+
+func TestEditIssue_Success(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		Edit(mock.Anything, "org-name", "repo-name", 7,
+			mock.MatchedBy(func(i *gh.IssueRequest) bool {
+				return i.GetTitle() == "Policy compliance" && i.GetBody() == "new body"
+			}),
+		).
+		Once().
+		Return(&gh.Issue{Number: gh.Ptr(7)}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	issue, err := c.EditIssue(ctx, "repo-name", 7, "Policy compliance", "new body")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, issue.GetNumber())
+}
+
+func TestListIssues_PaginatesWithoutPullRequests(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+	byBot := func(o *gh.IssueListByRepoOptions) bool { return o.State == "open" && o.Creator == "policy-bot" }
+
+	issuesSvc.
+		EXPECT().
+		ListByRepo(mock.Anything, "org-name", "repo-name", mock.MatchedBy(func(o *gh.IssueListByRepoOptions) bool { return byBot(o) && o.ListOptions.Page == 0 })).
+		Once().
+		Return([]*gh.Issue{{Number: gh.Ptr(1)}, {Number: gh.Ptr(2), PullRequestLinks: &gh.PullRequestLinks{}}}, &gh.Response{NextPage: 2}, nil)
+	issuesSvc.
+		EXPECT().
+		ListByRepo(mock.Anything, "org-name", "repo-name", mock.MatchedBy(func(o *gh.IssueListByRepoOptions) bool { return byBot(o) && o.ListOptions.Page == 2 })).
+		Once().
+		Return([]*gh.Issue{{Number: gh.Ptr(3)}}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	issues, err := c.ListIssues(ctx, "repo-name", "policy-bot")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*gh.Issue{{Number: gh.Ptr(1)}, {Number: gh.Ptr(3)}}, issues)
+}
+
+func TestListIssues_Error(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		ListByRepo(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("API error"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	issues, err := c.ListIssues(ctx, "repo-name", "policy-bot")
+
+	assert.Error(t, err)
+	assert.Nil(t, issues)
+}
+
+func TestListIssueComments_Paginates(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		ListComments(mock.Anything, "org-name", "repo-name", 7, mock.MatchedBy(func(o *gh.IssueListCommentsOptions) bool { return o.Page == 0 })).
+		Once().
+		Return([]*gh.IssueComment{{ID: gh.Ptr(int64(1))}}, &gh.Response{NextPage: 2}, nil)
+	issuesSvc.
+		EXPECT().
+		ListComments(mock.Anything, "org-name", "repo-name", 7, mock.MatchedBy(func(o *gh.IssueListCommentsOptions) bool { return o.Page == 2 })).
+		Once().
+		Return([]*gh.IssueComment{{ID: gh.Ptr(int64(2))}}, &gh.Response{}, nil)
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	comments, err := c.ListIssueComments(ctx, "repo-name", 7)
+
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+}
+
+func TestIssueComments_CreateEditDelete(t *testing.T) {
+	ctx := context.Background()
+	issuesSvc := github.NewMockIssuesAdapter(t)
+
+	issuesSvc.
+		EXPECT().
+		CreateComment(mock.Anything, "org-name", "repo-name", 7, &gh.IssueComment{Body: gh.Ptr("part 2")}).
+		Once().
+		Return(&gh.IssueComment{}, &gh.Response{}, nil)
+	issuesSvc.
+		EXPECT().
+		EditComment(mock.Anything, "org-name", "repo-name", int64(11), &gh.IssueComment{Body: gh.Ptr("part 3")}).
+		Once().
+		Return(&gh.IssueComment{}, &gh.Response{}, nil)
+	issuesSvc.
+		EXPECT().
+		DeleteComment(mock.Anything, "org-name", "repo-name", int64(12)).
+		Once().
+		Return(nil, errors.New("API error"))
+
+	c := &client{issues: issuesSvc, org: "org-name"}
+
+	assert.NoError(t, c.CreateIssueComment(ctx, "repo-name", 7, "part 2"))
+	assert.NoError(t, c.EditIssueComment(ctx, "repo-name", 11, "part 3"))
+	assert.Error(t, c.DeleteIssueComment(ctx, "repo-name", 12))
+}
+
+func TestPinIssue(t *testing.T) {
+	ctx := context.Background()
+	gql := github.NewMockGraphQLAdapter(t)
+
+	gql.
+		EXPECT().
+		Do(mock.Anything, pinIssueMutation, map[string]any{"issueId": "I_kwDO123"}, nil).
+		Once().
+		Return(nil)
+
+	c := &client{graphql: gql, org: "org-name"}
+
+	err := c.PinIssue(ctx, "I_kwDO123")
+
+	assert.NoError(t, err)
+}
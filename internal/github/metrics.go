@@ -0,0 +1,80 @@
+package github
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
+)
+
+// metricsTransport counts API calls and tracks the remaining rate limit.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *metrics.Metrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.metrics.APIRequest(endpointFor(req.URL.Path), req.Method, 0)
+		return nil, err
+	}
+
+	t.metrics.APIRequest(endpointFor(req.URL.Path), req.Method, resp.StatusCode)
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		t.metrics.RateLimitRemaining(remaining)
+	}
+	return resp, nil
+}
+
+// placeholderAfter names the segment following a fixed one, e.g. the label
+// name in /repos/{owner}/{repo}/labels/{name}.
+var placeholderAfter = map[string]string{
+	"repos":    "{owner}",
+	"orgs":     "{org}",
+	"branches": "{branch}",
+	"labels":   "{name}",
+	"trees":    "{sha}",
+	"commits":  "{sha}",
+}
+
+// restPlaceholder names every remaining segment after a fixed one, for paths
+// and refs that contain slashes themselves.
+var restPlaceholder = map[string]string{
+	"contents": "{path}",
+	"ref":      "{ref}",
+	"refs":     "{ref}",
+}
+
+// endpointFor turns an API path into the template of its endpoint, keeping
+// the cardinality of metric labels independent of repository names.
+func endpointFor(path string) string {
+	path = strings.TrimPrefix(path, "/api/v3")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	out := make([]string, 0, len(segments))
+	for i := 0; i < len(segments); i++ {
+		seg := segments[i]
+		out = append(out, seg)
+
+		if placeholder, ok := restPlaceholder[seg]; ok && i+1 < len(segments) {
+			out = append(out, placeholder)
+			break
+		}
+		if placeholder, ok := placeholderAfter[seg]; ok && i+1 < len(segments) {
+			out = append(out, placeholder)
+			i++
+			if seg == "repos" && i+1 < len(segments) {
+				out = append(out, "{repo}")
+				i++
+			}
+			continue
+		}
+		if _, err := strconv.Atoi(seg); err == nil {
+			out[len(out)-1] = "{number}"
+		}
+	}
+
+	return "/" + strings.Join(out, "/")
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
+)
+
+func TestEndpointFor(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/orgs/tracker-tv/repos", "/orgs/{org}/repos"},
+		{"/repos/tracker-tv/api/pulls", "/repos/{owner}/{repo}/pulls"},
+		{"/repos/tracker-tv/api/pulls/42/requested_reviewers", "/repos/{owner}/{repo}/pulls/{number}/requested_reviewers"},
+		{"/repos/tracker-tv/api/contents/.github/workflows/ci.yml", "/repos/{owner}/{repo}/contents/{path}"},
+		{"/repos/tracker-tv/api/git/ref/heads/chore/policy-bot", "/repos/{owner}/{repo}/git/ref/{ref}"},
+		{"/repos/tracker-tv/api/git/trees/abc123", "/repos/{owner}/{repo}/git/trees/{sha}"},
+		{"/repos/tracker-tv/api/labels/policy-bot", "/repos/{owner}/{repo}/labels/{name}"},
+		{"/api/v3/repos/tracker-tv/api/code-scanning/sarifs", "/repos/{owner}/{repo}/code-scanning/sarifs"},
+		{"/graphql", "/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointFor(tt.path))
+		})
+	}
+}
+
+func TestMetricsTransport_RoundTrip(t *testing.T) {
+	m := metrics.New()
+	transport := &metricsTransport{next: http.DefaultTransport, metrics: m}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/repos/org/repo/pulls", nil)
+	assert.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	assert.Contains(t, string(body), `policybot_github_api_requests_total{endpoint="/repos/{owner}/{repo}/pulls",method="GET",status="200"} 1`)
+	assert.Contains(t, string(body), "policybot_github_rate_limit_remaining 4999")
+}
@@ -38,38 +38,38 @@ func (_m *MockClient) EXPECT() *MockClient_Expecter {
 	return &MockClient_Expecter{mock: &_m.Mock}
 }
 
-// CreateBranch provides a mock function for the type MockClient
-func (_mock *MockClient) CreateBranch(ctx context.Context, repo string, branchName string, baseSHA string) error {
-	ret := _mock.Called(ctx, repo, branchName, baseSHA)
+// AddAssignees provides a mock function for the type MockClient
+func (_mock *MockClient) AddAssignees(ctx context.Context, repo string, number int, assignees []string) error {
+	ret := _mock.Called(ctx, repo, number, assignees)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateBranch")
+		panic("no return value specified for AddAssignees")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, repo, branchName, baseSHA)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, []string) error); ok {
+		r0 = returnFunc(ctx, repo, number, assignees)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockClient_CreateBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBranch'
-type MockClient_CreateBranch_Call struct {
+// MockClient_AddAssignees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAssignees'
+type MockClient_AddAssignees_Call struct {
 	*mock.Call
 }
 
-// CreateBranch is a helper method to define mock.On call
+// AddAssignees is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - branchName string
-//   - baseSHA string
-func (_e *MockClient_Expecter) CreateBranch(ctx interface{}, repo interface{}, branchName interface{}, baseSHA interface{}) *MockClient_CreateBranch_Call {
-	return &MockClient_CreateBranch_Call{Call: _e.mock.On("CreateBranch", ctx, repo, branchName, baseSHA)}
+//   - number int
+//   - assignees []string
+func (_e *MockClient_Expecter) AddAssignees(ctx interface{}, repo interface{}, number interface{}, assignees interface{}) *MockClient_AddAssignees_Call {
+	return &MockClient_AddAssignees_Call{Call: _e.mock.On("AddAssignees", ctx, repo, number, assignees)}
 }
 
-func (_c *MockClient_CreateBranch_Call) Run(run func(ctx context.Context, repo string, branchName string, baseSHA string)) *MockClient_CreateBranch_Call {
+func (_c *MockClient_AddAssignees_Call) Run(run func(ctx context.Context, repo string, number int, assignees []string)) *MockClient_AddAssignees_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -79,13 +79,13 @@ func (_c *MockClient_CreateBranch_Call) Run(run func(ctx context.Context, repo s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int)
 		}
-		var arg3 string
+		var arg3 []string
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].([]string)
 		}
 		run(
 			arg0,
@@ -97,51 +97,48 @@ func (_c *MockClient_CreateBranch_Call) Run(run func(ctx context.Context, repo s
 	return _c
 }
 
-func (_c *MockClient_CreateBranch_Call) Return(err error) *MockClient_CreateBranch_Call {
+func (_c *MockClient_AddAssignees_Call) Return(err error) *MockClient_AddAssignees_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockClient_CreateBranch_Call) RunAndReturn(run func(ctx context.Context, repo string, branchName string, baseSHA string) error) *MockClient_CreateBranch_Call {
+func (_c *MockClient_AddAssignees_Call) RunAndReturn(run func(ctx context.Context, repo string, number int, assignees []string) error) *MockClient_AddAssignees_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrUpdateFile provides a mock function for the type MockClient
-func (_mock *MockClient) CreateOrUpdateFile(ctx context.Context, repo string, path string, branch string, message string, content string, fileSHA *string) error {
-	ret := _mock.Called(ctx, repo, path, branch, message, content, fileSHA)
+// AddLabelsToIssue provides a mock function for the type MockClient
+func (_mock *MockClient) AddLabelsToIssue(ctx context.Context, repo string, number int, labels []string) error {
+	ret := _mock.Called(ctx, repo, number, labels)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrUpdateFile")
+		panic("no return value specified for AddLabelsToIssue")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, *string) error); ok {
-		r0 = returnFunc(ctx, repo, path, branch, message, content, fileSHA)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, []string) error); ok {
+		r0 = returnFunc(ctx, repo, number, labels)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockClient_CreateOrUpdateFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrUpdateFile'
-type MockClient_CreateOrUpdateFile_Call struct {
+// MockClient_AddLabelsToIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLabelsToIssue'
+type MockClient_AddLabelsToIssue_Call struct {
 	*mock.Call
 }
 
-// CreateOrUpdateFile is a helper method to define mock.On call
+// AddLabelsToIssue is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - path string
-//   - branch string
-//   - message string
-//   - content string
-//   - fileSHA *string
-func (_e *MockClient_Expecter) CreateOrUpdateFile(ctx interface{}, repo interface{}, path interface{}, branch interface{}, message interface{}, content interface{}, fileSHA interface{}) *MockClient_CreateOrUpdateFile_Call {
-	return &MockClient_CreateOrUpdateFile_Call{Call: _e.mock.On("CreateOrUpdateFile", ctx, repo, path, branch, message, content, fileSHA)}
+//   - number int
+//   - labels []string
+func (_e *MockClient_Expecter) AddLabelsToIssue(ctx interface{}, repo interface{}, number interface{}, labels interface{}) *MockClient_AddLabelsToIssue_Call {
+	return &MockClient_AddLabelsToIssue_Call{Call: _e.mock.On("AddLabelsToIssue", ctx, repo, number, labels)}
 }
 
-func (_c *MockClient_CreateOrUpdateFile_Call) Run(run func(ctx context.Context, repo string, path string, branch string, message string, content string, fileSHA *string)) *MockClient_CreateOrUpdateFile_Call {
+func (_c *MockClient_AddLabelsToIssue_Call) Run(run func(ctx context.Context, repo string, number int, labels []string)) *MockClient_AddLabelsToIssue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -151,183 +148,137 @@ func (_c *MockClient_CreateOrUpdateFile_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int)
 		}
-		var arg3 string
+		var arg3 []string
 		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 string
-		if args[5] != nil {
-			arg5 = args[5].(string)
-		}
-		var arg6 *string
-		if args[6] != nil {
-			arg6 = args[6].(*string)
+			arg3 = args[3].([]string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
-			arg5,
-			arg6,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_CreateOrUpdateFile_Call) Return(err error) *MockClient_CreateOrUpdateFile_Call {
+func (_c *MockClient_AddLabelsToIssue_Call) Return(err error) *MockClient_AddLabelsToIssue_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockClient_CreateOrUpdateFile_Call) RunAndReturn(run func(ctx context.Context, repo string, path string, branch string, message string, content string, fileSHA *string) error) *MockClient_CreateOrUpdateFile_Call {
+func (_c *MockClient_AddLabelsToIssue_Call) RunAndReturn(run func(ctx context.Context, repo string, number int, labels []string) error) *MockClient_AddLabelsToIssue_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePullRequest provides a mock function for the type MockClient
-func (_mock *MockClient) CreatePullRequest(ctx context.Context, repo string, title string, body string, head string, base string) (*github.PullRequest, error) {
-	ret := _mock.Called(ctx, repo, title, body, head, base)
+// AuthenticatedUser provides a mock function for the type MockClient
+func (_mock *MockClient) AuthenticatedUser(ctx context.Context) (string, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePullRequest")
+		panic("no return value specified for AuthenticatedUser")
 	}
 
-	var r0 *github.PullRequest
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (*github.PullRequest, error)); ok {
-		return returnFunc(ctx, repo, title, body, head, base)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) *github.PullRequest); ok {
-		r0 = returnFunc(ctx, repo, title, body, head, base)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.PullRequest)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
-		r1 = returnFunc(ctx, repo, title, body, head, base)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockClient_CreatePullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePullRequest'
-type MockClient_CreatePullRequest_Call struct {
+// MockClient_AuthenticatedUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticatedUser'
+type MockClient_AuthenticatedUser_Call struct {
 	*mock.Call
 }
 
-// CreatePullRequest is a helper method to define mock.On call
+// AuthenticatedUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repo string
-//   - title string
-//   - body string
-//   - head string
-//   - base string
-func (_e *MockClient_Expecter) CreatePullRequest(ctx interface{}, repo interface{}, title interface{}, body interface{}, head interface{}, base interface{}) *MockClient_CreatePullRequest_Call {
-	return &MockClient_CreatePullRequest_Call{Call: _e.mock.On("CreatePullRequest", ctx, repo, title, body, head, base)}
+func (_e *MockClient_Expecter) AuthenticatedUser(ctx interface{}) *MockClient_AuthenticatedUser_Call {
+	return &MockClient_AuthenticatedUser_Call{Call: _e.mock.On("AuthenticatedUser", ctx)}
 }
 
-func (_c *MockClient_CreatePullRequest_Call) Run(run func(ctx context.Context, repo string, title string, body string, head string, base string)) *MockClient_CreatePullRequest_Call {
+func (_c *MockClient_AuthenticatedUser_Call) Run(run func(ctx context.Context)) *MockClient_AuthenticatedUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 string
-		if args[5] != nil {
-			arg5 = args[5].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
-			arg4,
-			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_CreatePullRequest_Call) Return(pullRequest *github.PullRequest, err error) *MockClient_CreatePullRequest_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *MockClient_AuthenticatedUser_Call) Return(s string, err error) *MockClient_AuthenticatedUser_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockClient_CreatePullRequest_Call) RunAndReturn(run func(ctx context.Context, repo string, title string, body string, head string, base string) (*github.PullRequest, error)) *MockClient_CreatePullRequest_Call {
+func (_c *MockClient_AuthenticatedUser_Call) RunAndReturn(run func(ctx context.Context) (string, error)) *MockClient_AuthenticatedUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindPullRequestByBranch provides a mock function for the type MockClient
-func (_mock *MockClient) FindPullRequestByBranch(ctx context.Context, repo string, branchName string) (*github.PullRequest, error) {
-	ret := _mock.Called(ctx, repo, branchName)
+// ChangedFiles provides a mock function for the type MockClient
+func (_mock *MockClient) ChangedFiles(ctx context.Context, repo string, base string, head string) ([]string, error) {
+	ret := _mock.Called(ctx, repo, base, head)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindPullRequestByBranch")
+		panic("no return value specified for ChangedFiles")
 	}
 
-	var r0 *github.PullRequest
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.PullRequest, error)); ok {
-		return returnFunc(ctx, repo, branchName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, repo, base, head)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.PullRequest); ok {
-		r0 = returnFunc(ctx, repo, branchName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) []string); ok {
+		r0 = returnFunc(ctx, repo, base, head)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.PullRequest)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, repo, branchName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, base, head)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockClient_FindPullRequestByBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPullRequestByBranch'
-type MockClient_FindPullRequestByBranch_Call struct {
+// MockClient_ChangedFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangedFiles'
+type MockClient_ChangedFiles_Call struct {
 	*mock.Call
 }
 
-// FindPullRequestByBranch is a helper method to define mock.On call
+// ChangedFiles is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - branchName string
-func (_e *MockClient_Expecter) FindPullRequestByBranch(ctx interface{}, repo interface{}, branchName interface{}) *MockClient_FindPullRequestByBranch_Call {
-	return &MockClient_FindPullRequestByBranch_Call{Call: _e.mock.On("FindPullRequestByBranch", ctx, repo, branchName)}
+//   - base string
+//   - head string
+func (_e *MockClient_Expecter) ChangedFiles(ctx interface{}, repo interface{}, base interface{}, head interface{}) *MockClient_ChangedFiles_Call {
+	return &MockClient_ChangedFiles_Call{Call: _e.mock.On("ChangedFiles", ctx, repo, base, head)}
 }
 
-func (_c *MockClient_FindPullRequestByBranch_Call) Run(run func(ctx context.Context, repo string, branchName string)) *MockClient_FindPullRequestByBranch_Call {
+func (_c *MockClient_ChangedFiles_Call) Run(run func(ctx context.Context, repo string, base string, head string)) *MockClient_ChangedFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -341,67 +292,64 @@ func (_c *MockClient_FindPullRequestByBranch_Call) Run(run func(ctx context.Cont
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_FindPullRequestByBranch_Call) Return(pullRequest *github.PullRequest, err error) *MockClient_FindPullRequestByBranch_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *MockClient_ChangedFiles_Call) Return(strings []string, err error) *MockClient_ChangedFiles_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockClient_FindPullRequestByBranch_Call) RunAndReturn(run func(ctx context.Context, repo string, branchName string) (*github.PullRequest, error)) *MockClient_FindPullRequestByBranch_Call {
+func (_c *MockClient_ChangedFiles_Call) RunAndReturn(run func(ctx context.Context, repo string, base string, head string) ([]string, error)) *MockClient_ChangedFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBranch provides a mock function for the type MockClient
-func (_mock *MockClient) GetBranch(ctx context.Context, repo string, branch string) (*github.Reference, error) {
-	ret := _mock.Called(ctx, repo, branch)
+// CommitFiles provides a mock function for the type MockClient
+func (_mock *MockClient) CommitFiles(ctx context.Context, repo string, branch string, message string, files map[string]string, deletions []string) error {
+	ret := _mock.Called(ctx, repo, branch, message, files, deletions)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBranch")
+		panic("no return value specified for CommitFiles")
 	}
 
-	var r0 *github.Reference
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.Reference, error)); ok {
-		return returnFunc(ctx, repo, branch)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.Reference); ok {
-		r0 = returnFunc(ctx, repo, branch)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.Reference)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, repo, branch)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, map[string]string, []string) error); ok {
+		r0 = returnFunc(ctx, repo, branch, message, files, deletions)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockClient_GetBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranch'
-type MockClient_GetBranch_Call struct {
+// MockClient_CommitFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitFiles'
+type MockClient_CommitFiles_Call struct {
 	*mock.Call
 }
 
-// GetBranch is a helper method to define mock.On call
+// CommitFiles is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
 //   - branch string
-func (_e *MockClient_Expecter) GetBranch(ctx interface{}, repo interface{}, branch interface{}) *MockClient_GetBranch_Call {
-	return &MockClient_GetBranch_Call{Call: _e.mock.On("GetBranch", ctx, repo, branch)}
+//   - message string
+//   - files map[string]string
+//   - deletions []string
+func (_e *MockClient_Expecter) CommitFiles(ctx interface{}, repo interface{}, branch interface{}, message interface{}, files interface{}, deletions interface{}) *MockClient_CommitFiles_Call {
+	return &MockClient_CommitFiles_Call{Call: _e.mock.On("CommitFiles", ctx, repo, branch, message, files, deletions)}
 }
 
-func (_c *MockClient_GetBranch_Call) Run(run func(ctx context.Context, repo string, branch string)) *MockClient_GetBranch_Call {
+func (_c *MockClient_CommitFiles_Call) Run(run func(ctx context.Context, repo string, branch string, message string, files map[string]string, deletions []string)) *MockClient_CommitFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -415,83 +363,72 @@ func (_c *MockClient_GetBranch_Call) Run(run func(ctx context.Context, repo stri
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 map[string]string
+		if args[4] != nil {
+			arg4 = args[4].(map[string]string)
+		}
+		var arg5 []string
+		if args[5] != nil {
+			arg5 = args[5].([]string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_GetBranch_Call) Return(reference *github.Reference, err error) *MockClient_GetBranch_Call {
-	_c.Call.Return(reference, err)
+func (_c *MockClient_CommitFiles_Call) Return(err error) *MockClient_CommitFiles_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockClient_GetBranch_Call) RunAndReturn(run func(ctx context.Context, repo string, branch string) (*github.Reference, error)) *MockClient_GetBranch_Call {
+func (_c *MockClient_CommitFiles_Call) RunAndReturn(run func(ctx context.Context, repo string, branch string, message string, files map[string]string, deletions []string) error) *MockClient_CommitFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetContentsRaw provides a mock function for the type MockClient
-func (_mock *MockClient) GetContentsRaw(ctx context.Context, repo string, path string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
-	ret := _mock.Called(ctx, repo, path)
+// CreateBranch provides a mock function for the type MockClient
+func (_mock *MockClient) CreateBranch(ctx context.Context, repo string, branchName string, baseSHA string) error {
+	ret := _mock.Called(ctx, repo, branchName, baseSHA)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetContentsRaw")
+		panic("no return value specified for CreateBranch")
 	}
 
-	var r0 *github.RepositoryContent
-	var r1 []*github.RepositoryContent
-	var r2 *github.Response
-	var r3 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)); ok {
-		return returnFunc(ctx, repo, path)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.RepositoryContent); ok {
-		r0 = returnFunc(ctx, repo, path)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.RepositoryContent)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) []*github.RepositoryContent); ok {
-		r1 = returnFunc(ctx, repo, path)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]*github.RepositoryContent)
-		}
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) *github.Response); ok {
-		r2 = returnFunc(ctx, repo, path)
-	} else {
-		if ret.Get(2) != nil {
-			r2 = ret.Get(2).(*github.Response)
-		}
-	}
-	if returnFunc, ok := ret.Get(3).(func(context.Context, string, string) error); ok {
-		r3 = returnFunc(ctx, repo, path)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, repo, branchName, baseSHA)
 	} else {
-		r3 = ret.Error(3)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2, r3
+	return r0
 }
 
-// MockClient_GetContentsRaw_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentsRaw'
-type MockClient_GetContentsRaw_Call struct {
+// MockClient_CreateBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBranch'
+type MockClient_CreateBranch_Call struct {
 	*mock.Call
 }
 
-// GetContentsRaw is a helper method to define mock.On call
+// CreateBranch is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - path string
-func (_e *MockClient_Expecter) GetContentsRaw(ctx interface{}, repo interface{}, path interface{}) *MockClient_GetContentsRaw_Call {
-	return &MockClient_GetContentsRaw_Call{Call: _e.mock.On("GetContentsRaw", ctx, repo, path)}
+//   - branchName string
+//   - baseSHA string
+func (_e *MockClient_Expecter) CreateBranch(ctx interface{}, repo interface{}, branchName interface{}, baseSHA interface{}) *MockClient_CreateBranch_Call {
+	return &MockClient_CreateBranch_Call{Call: _e.mock.On("CreateBranch", ctx, repo, branchName, baseSHA)}
 }
 
-func (_c *MockClient_GetContentsRaw_Call) Run(run func(ctx context.Context, repo string, path string)) *MockClient_GetContentsRaw_Call {
+func (_c *MockClient_CreateBranch_Call) Run(run func(ctx context.Context, repo string, branchName string, baseSHA string)) *MockClient_CreateBranch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -505,72 +442,74 @@ func (_c *MockClient_GetContentsRaw_Call) Run(run func(ctx context.Context, repo
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_GetContentsRaw_Call) Return(repositoryContent *github.RepositoryContent, repositoryContents []*github.RepositoryContent, response *github.Response, err error) *MockClient_GetContentsRaw_Call {
-	_c.Call.Return(repositoryContent, repositoryContents, response, err)
+func (_c *MockClient_CreateBranch_Call) Return(err error) *MockClient_CreateBranch_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockClient_GetContentsRaw_Call) RunAndReturn(run func(ctx context.Context, repo string, path string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)) *MockClient_GetContentsRaw_Call {
+func (_c *MockClient_CreateBranch_Call) RunAndReturn(run func(ctx context.Context, repo string, branchName string, baseSHA string) error) *MockClient_CreateBranch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileContent provides a mock function for the type MockClient
-func (_mock *MockClient) GetFileContent(ctx context.Context, repo string, path string, ref string) (string, string, error) {
-	ret := _mock.Called(ctx, repo, path, ref)
+// CreateIssue provides a mock function for the type MockClient
+func (_mock *MockClient) CreateIssue(ctx context.Context, repo string, title string, body string, labels []string) (*github.Issue, error) {
+	ret := _mock.Called(ctx, repo, title, body, labels)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileContent")
+		panic("no return value specified for CreateIssue")
 	}
 
-	var r0 string
-	var r1 string
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (string, string, error)); ok {
-		return returnFunc(ctx, repo, path, ref)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
-		r0 = returnFunc(ctx, repo, path, ref)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 *github.Issue
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []string) (*github.Issue, error)); ok {
+		return returnFunc(ctx, repo, title, body, labels)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) string); ok {
-		r1 = returnFunc(ctx, repo, path, ref)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []string) *github.Issue); ok {
+		r0 = returnFunc(ctx, repo, title, body, labels)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Issue)
+		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
-		r2 = returnFunc(ctx, repo, path, ref)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
+		r1 = returnFunc(ctx, repo, title, body, labels)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockClient_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
-type MockClient_GetFileContent_Call struct {
+// MockClient_CreateIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIssue'
+type MockClient_CreateIssue_Call struct {
 	*mock.Call
 }
 
-// GetFileContent is a helper method to define mock.On call
+// CreateIssue is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - path string
-//   - ref string
-func (_e *MockClient_Expecter) GetFileContent(ctx interface{}, repo interface{}, path interface{}, ref interface{}) *MockClient_GetFileContent_Call {
-	return &MockClient_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, repo, path, ref)}
+//   - title string
+//   - body string
+//   - labels []string
+func (_e *MockClient_Expecter) CreateIssue(ctx interface{}, repo interface{}, title interface{}, body interface{}, labels interface{}) *MockClient_CreateIssue_Call {
+	return &MockClient_CreateIssue_Call{Call: _e.mock.On("CreateIssue", ctx, repo, title, body, labels)}
 }
 
-func (_c *MockClient_GetFileContent_Call) Run(run func(ctx context.Context, repo string, path string, ref string)) *MockClient_GetFileContent_Call {
+func (_c *MockClient_CreateIssue_Call) Run(run func(ctx context.Context, repo string, title string, body string, labels []string)) *MockClient_CreateIssue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -588,77 +527,63 @@ func (_c *MockClient_GetFileContent_Call) Run(run func(ctx context.Context, repo
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_GetFileContent_Call) Return(content string, sha string, err error) *MockClient_GetFileContent_Call {
-	_c.Call.Return(content, sha, err)
+func (_c *MockClient_CreateIssue_Call) Return(issue *github.Issue, err error) *MockClient_CreateIssue_Call {
+	_c.Call.Return(issue, err)
 	return _c
 }
 
-func (_c *MockClient_GetFileContent_Call) RunAndReturn(run func(ctx context.Context, repo string, path string, ref string) (string, string, error)) *MockClient_GetFileContent_Call {
+func (_c *MockClient_CreateIssue_Call) RunAndReturn(run func(ctx context.Context, repo string, title string, body string, labels []string) (*github.Issue, error)) *MockClient_CreateIssue_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTree provides a mock function for the type MockClient
-func (_mock *MockClient) GetTree(ctx context.Context, repo string, sha string, recursive bool) (*github.Tree, *github.Response, error) {
-	ret := _mock.Called(ctx, repo, sha, recursive)
+// CreateIssueComment provides a mock function for the type MockClient
+func (_mock *MockClient) CreateIssueComment(ctx context.Context, repo string, number int, body string) error {
+	ret := _mock.Called(ctx, repo, number, body)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTree")
+		panic("no return value specified for CreateIssueComment")
 	}
 
-	var r0 *github.Tree
-	var r1 *github.Response
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) (*github.Tree, *github.Response, error)); ok {
-		return returnFunc(ctx, repo, sha, recursive)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) *github.Tree); ok {
-		r0 = returnFunc(ctx, repo, sha, recursive)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.Tree)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, bool) *github.Response); ok {
-		r1 = returnFunc(ctx, repo, sha, recursive)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*github.Response)
-		}
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, bool) error); ok {
-		r2 = returnFunc(ctx, repo, sha, recursive)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) error); ok {
+		r0 = returnFunc(ctx, repo, number, body)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockClient_GetTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTree'
-type MockClient_GetTree_Call struct {
+// MockClient_CreateIssueComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIssueComment'
+type MockClient_CreateIssueComment_Call struct {
 	*mock.Call
 }
 
-// GetTree is a helper method to define mock.On call
+// CreateIssueComment is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - sha string
-//   - recursive bool
-func (_e *MockClient_Expecter) GetTree(ctx interface{}, repo interface{}, sha interface{}, recursive interface{}) *MockClient_GetTree_Call {
-	return &MockClient_GetTree_Call{Call: _e.mock.On("GetTree", ctx, repo, sha, recursive)}
+//   - number int
+//   - body string
+func (_e *MockClient_Expecter) CreateIssueComment(ctx interface{}, repo interface{}, number interface{}, body interface{}) *MockClient_CreateIssueComment_Call {
+	return &MockClient_CreateIssueComment_Call{Call: _e.mock.On("CreateIssueComment", ctx, repo, number, body)}
 }
 
-func (_c *MockClient_GetTree_Call) Run(run func(ctx context.Context, repo string, sha string, recursive bool)) *MockClient_GetTree_Call {
+func (_c *MockClient_CreateIssueComment_Call) Run(run func(ctx context.Context, repo string, number int, body string)) *MockClient_CreateIssueComment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -668,13 +593,13 @@ func (_c *MockClient_GetTree_Call) Run(run func(ctx context.Context, repo string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int)
 		}
-		var arg3 bool
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(bool)
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
@@ -686,120 +611,131 @@ func (_c *MockClient_GetTree_Call) Run(run func(ctx context.Context, repo string
 	return _c
 }
 
-func (_c *MockClient_GetTree_Call) Return(tree *github.Tree, response *github.Response, err error) *MockClient_GetTree_Call {
-	_c.Call.Return(tree, response, err)
+func (_c *MockClient_CreateIssueComment_Call) Return(err error) *MockClient_CreateIssueComment_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockClient_GetTree_Call) RunAndReturn(run func(ctx context.Context, repo string, sha string, recursive bool) (*github.Tree, *github.Response, error)) *MockClient_GetTree_Call {
+func (_c *MockClient_CreateIssueComment_Call) RunAndReturn(run func(ctx context.Context, repo string, number int, body string) error) *MockClient_CreateIssueComment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAllRepos provides a mock function for the type MockClient
-func (_mock *MockClient) ListAllRepos(ctx context.Context) ([]*github.Repository, error) {
-	ret := _mock.Called(ctx)
+// CreateLabel provides a mock function for the type MockClient
+func (_mock *MockClient) CreateLabel(ctx context.Context, repo string, name string, color string) (*github.Label, error) {
+	ret := _mock.Called(ctx, repo, name, color)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAllRepos")
+		panic("no return value specified for CreateLabel")
 	}
 
-	var r0 []*github.Repository
+	var r0 *github.Label
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*github.Repository, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*github.Label, error)); ok {
+		return returnFunc(ctx, repo, name, color)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []*github.Repository); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *github.Label); ok {
+		r0 = returnFunc(ctx, repo, name, color)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*github.Repository)
+			r0 = ret.Get(0).(*github.Label)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, name, color)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockClient_ListAllRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllRepos'
-type MockClient_ListAllRepos_Call struct {
+// MockClient_CreateLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLabel'
+type MockClient_CreateLabel_Call struct {
 	*mock.Call
 }
 
-// ListAllRepos is a helper method to define mock.On call
+// CreateLabel is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockClient_Expecter) ListAllRepos(ctx interface{}) *MockClient_ListAllRepos_Call {
-	return &MockClient_ListAllRepos_Call{Call: _e.mock.On("ListAllRepos", ctx)}
+//   - repo string
+//   - name string
+//   - color string
+func (_e *MockClient_Expecter) CreateLabel(ctx interface{}, repo interface{}, name interface{}, color interface{}) *MockClient_CreateLabel_Call {
+	return &MockClient_CreateLabel_Call{Call: _e.mock.On("CreateLabel", ctx, repo, name, color)}
 }
 
-func (_c *MockClient_ListAllRepos_Call) Run(run func(ctx context.Context)) *MockClient_ListAllRepos_Call {
+func (_c *MockClient_CreateLabel_Call) Run(run func(ctx context.Context, repo string, name string, color string)) *MockClient_CreateLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_ListAllRepos_Call) Return(repositorys []*github.Repository, err error) *MockClient_ListAllRepos_Call {
-	_c.Call.Return(repositorys, err)
+func (_c *MockClient_CreateLabel_Call) Return(label *github.Label, err error) *MockClient_CreateLabel_Call {
+	_c.Call.Return(label, err)
 	return _c
 }
 
-func (_c *MockClient_ListAllRepos_Call) RunAndReturn(run func(ctx context.Context) ([]*github.Repository, error)) *MockClient_ListAllRepos_Call {
+func (_c *MockClient_CreateLabel_Call) RunAndReturn(run func(ctx context.Context, repo string, name string, color string) (*github.Label, error)) *MockClient_CreateLabel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPullRequests provides a mock function for the type MockClient
-func (_mock *MockClient) ListPullRequests(ctx context.Context, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
-	ret := _mock.Called(ctx, repo, opts)
+// CreateOrUpdateFile provides a mock function for the type MockClient
+func (_mock *MockClient) CreateOrUpdateFile(ctx context.Context, repo string, path string, branch string, message string, content string, fileSHA *string) error {
+	ret := _mock.Called(ctx, repo, path, branch, message, content, fileSHA)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPullRequests")
+		panic("no return value specified for CreateOrUpdateFile")
 	}
 
-	var r0 []*github.PullRequest
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *github.PullRequestListOptions) ([]*github.PullRequest, error)); ok {
-		return returnFunc(ctx, repo, opts)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *github.PullRequestListOptions) []*github.PullRequest); ok {
-		r0 = returnFunc(ctx, repo, opts)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*github.PullRequest)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *github.PullRequestListOptions) error); ok {
-		r1 = returnFunc(ctx, repo, opts)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, *string) error); ok {
+		r0 = returnFunc(ctx, repo, path, branch, message, content, fileSHA)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockClient_ListPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPullRequests'
-type MockClient_ListPullRequests_Call struct {
+// MockClient_CreateOrUpdateFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrUpdateFile'
+type MockClient_CreateOrUpdateFile_Call struct {
 	*mock.Call
 }
 
-// ListPullRequests is a helper method to define mock.On call
+// CreateOrUpdateFile is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repo string
-//   - opts *github.PullRequestListOptions
-func (_e *MockClient_Expecter) ListPullRequests(ctx interface{}, repo interface{}, opts interface{}) *MockClient_ListPullRequests_Call {
-	return &MockClient_ListPullRequests_Call{Call: _e.mock.On("ListPullRequests", ctx, repo, opts)}
+//   - path string
+//   - branch string
+//   - message string
+//   - content string
+//   - fileSHA *string
+func (_e *MockClient_Expecter) CreateOrUpdateFile(ctx interface{}, repo interface{}, path interface{}, branch interface{}, message interface{}, content interface{}, fileSHA interface{}) *MockClient_CreateOrUpdateFile_Call {
+	return &MockClient_CreateOrUpdateFile_Call{Call: _e.mock.On("CreateOrUpdateFile", ctx, repo, path, branch, message, content, fileSHA)}
 }
 
-func (_c *MockClient_ListPullRequests_Call) Run(run func(ctx context.Context, repo string, opts *github.PullRequestListOptions)) *MockClient_ListPullRequests_Call {
+func (_c *MockClient_CreateOrUpdateFile_Call) Run(run func(ctx context.Context, repo string, path string, branch string, message string, content string, fileSHA *string)) *MockClient_CreateOrUpdateFile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -809,25 +745,2120 @@ func (_c *MockClient_ListPullRequests_Call) Run(run func(ctx context.Context, re
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *github.PullRequestListOptions
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*github.PullRequestListOptions)
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 *string
+		if args[6] != nil {
+			arg6 = args[6].(*string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
 		)
 	})
 	return _c
 }
 
-func (_c *MockClient_ListPullRequests_Call) Return(pullRequests []*github.PullRequest, err error) *MockClient_ListPullRequests_Call {
-	_c.Call.Return(pullRequests, err)
+func (_c *MockClient_CreateOrUpdateFile_Call) Return(err error) *MockClient_CreateOrUpdateFile_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockClient_ListPullRequests_Call) RunAndReturn(run func(ctx context.Context, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error)) *MockClient_ListPullRequests_Call {
+func (_c *MockClient_CreateOrUpdateFile_Call) RunAndReturn(run func(ctx context.Context, repo string, path string, branch string, message string, content string, fileSHA *string) error) *MockClient_CreateOrUpdateFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePullRequest provides a mock function for the type MockClient
+func (_mock *MockClient) CreatePullRequest(ctx context.Context, repo string, title string, body string, head string, base string, draft bool) (*github.PullRequest, error) {
+	ret := _mock.Called(ctx, repo, title, body, head, base, draft)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePullRequest")
+	}
+
+	var r0 *github.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, bool) (*github.PullRequest, error)); ok {
+		return returnFunc(ctx, repo, title, body, head, base, draft)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, bool) *github.PullRequest); ok {
+		r0 = returnFunc(ctx, repo, title, body, head, base, draft)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, bool) error); ok {
+		r1 = returnFunc(ctx, repo, title, body, head, base, draft)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_CreatePullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePullRequest'
+type MockClient_CreatePullRequest_Call struct {
+	*mock.Call
+}
+
+// CreatePullRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - title string
+//   - body string
+//   - head string
+//   - base string
+//   - draft bool
+func (_e *MockClient_Expecter) CreatePullRequest(ctx interface{}, repo interface{}, title interface{}, body interface{}, head interface{}, base interface{}, draft interface{}) *MockClient_CreatePullRequest_Call {
+	return &MockClient_CreatePullRequest_Call{Call: _e.mock.On("CreatePullRequest", ctx, repo, title, body, head, base, draft)}
+}
+
+func (_c *MockClient_CreatePullRequest_Call) Run(run func(ctx context.Context, repo string, title string, body string, head string, base string, draft bool)) *MockClient_CreatePullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 bool
+		if args[6] != nil {
+			arg6 = args[6].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_CreatePullRequest_Call) Return(pullRequest *github.PullRequest, err error) *MockClient_CreatePullRequest_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockClient_CreatePullRequest_Call) RunAndReturn(run func(ctx context.Context, repo string, title string, body string, head string, base string, draft bool) (*github.PullRequest, error)) *MockClient_CreatePullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRuleset provides a mock function for the type MockClient
+func (_mock *MockClient) CreateRuleset(ctx context.Context, repo string, ruleset github.RepositoryRuleset) error {
+	ret := _mock.Called(ctx, repo, ruleset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRuleset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, github.RepositoryRuleset) error); ok {
+		r0 = returnFunc(ctx, repo, ruleset)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_CreateRuleset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRuleset'
+type MockClient_CreateRuleset_Call struct {
+	*mock.Call
+}
+
+// CreateRuleset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - ruleset github.RepositoryRuleset
+func (_e *MockClient_Expecter) CreateRuleset(ctx interface{}, repo interface{}, ruleset interface{}) *MockClient_CreateRuleset_Call {
+	return &MockClient_CreateRuleset_Call{Call: _e.mock.On("CreateRuleset", ctx, repo, ruleset)}
+}
+
+func (_c *MockClient_CreateRuleset_Call) Run(run func(ctx context.Context, repo string, ruleset github.RepositoryRuleset)) *MockClient_CreateRuleset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 github.RepositoryRuleset
+		if args[2] != nil {
+			arg2 = args[2].(github.RepositoryRuleset)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateRuleset_Call) Return(err error) *MockClient_CreateRuleset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_CreateRuleset_Call) RunAndReturn(run func(ctx context.Context, repo string, ruleset github.RepositoryRuleset) error) *MockClient_CreateRuleset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFile provides a mock function for the type MockClient
+func (_mock *MockClient) DeleteFile(ctx context.Context, repo string, path string, branch string, message string, fileSHA string) error {
+	ret := _mock.Called(ctx, repo, path, branch, message, fileSHA)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) error); ok {
+		r0 = returnFunc(ctx, repo, path, branch, message, fileSHA)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type MockClient_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - path string
+//   - branch string
+//   - message string
+//   - fileSHA string
+func (_e *MockClient_Expecter) DeleteFile(ctx interface{}, repo interface{}, path interface{}, branch interface{}, message interface{}, fileSHA interface{}) *MockClient_DeleteFile_Call {
+	return &MockClient_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, repo, path, branch, message, fileSHA)}
+}
+
+func (_c *MockClient_DeleteFile_Call) Run(run func(ctx context.Context, repo string, path string, branch string, message string, fileSHA string)) *MockClient_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteFile_Call) Return(err error) *MockClient_DeleteFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_DeleteFile_Call) RunAndReturn(run func(ctx context.Context, repo string, path string, branch string, message string, fileSHA string) error) *MockClient_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIssueComment provides a mock function for the type MockClient
+func (_mock *MockClient) DeleteIssueComment(ctx context.Context, repo string, commentID int64) error {
+	ret := _mock.Called(ctx, repo, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIssueComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, repo, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_DeleteIssueComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIssueComment'
+type MockClient_DeleteIssueComment_Call struct {
+	*mock.Call
+}
+
+// DeleteIssueComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - commentID int64
+func (_e *MockClient_Expecter) DeleteIssueComment(ctx interface{}, repo interface{}, commentID interface{}) *MockClient_DeleteIssueComment_Call {
+	return &MockClient_DeleteIssueComment_Call{Call: _e.mock.On("DeleteIssueComment", ctx, repo, commentID)}
+}
+
+func (_c *MockClient_DeleteIssueComment_Call) Run(run func(ctx context.Context, repo string, commentID int64)) *MockClient_DeleteIssueComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteIssueComment_Call) Return(err error) *MockClient_DeleteIssueComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_DeleteIssueComment_Call) RunAndReturn(run func(ctx context.Context, repo string, commentID int64) error) *MockClient_DeleteIssueComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditIssue provides a mock function for the type MockClient
+func (_mock *MockClient) EditIssue(ctx context.Context, repo string, number int, title string, body string) (*github.Issue, error) {
+	ret := _mock.Called(ctx, repo, number, title, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditIssue")
+	}
+
+	var r0 *github.Issue
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string) (*github.Issue, error)); ok {
+		return returnFunc(ctx, repo, number, title, body)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string) *github.Issue); ok {
+		r0 = returnFunc(ctx, repo, number, title, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Issue)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, number, title, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_EditIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditIssue'
+type MockClient_EditIssue_Call struct {
+	*mock.Call
+}
+
+// EditIssue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - number int
+//   - title string
+//   - body string
+func (_e *MockClient_Expecter) EditIssue(ctx interface{}, repo interface{}, number interface{}, title interface{}, body interface{}) *MockClient_EditIssue_Call {
+	return &MockClient_EditIssue_Call{Call: _e.mock.On("EditIssue", ctx, repo, number, title, body)}
+}
+
+func (_c *MockClient_EditIssue_Call) Run(run func(ctx context.Context, repo string, number int, title string, body string)) *MockClient_EditIssue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_EditIssue_Call) Return(issue *github.Issue, err error) *MockClient_EditIssue_Call {
+	_c.Call.Return(issue, err)
+	return _c
+}
+
+func (_c *MockClient_EditIssue_Call) RunAndReturn(run func(ctx context.Context, repo string, number int, title string, body string) (*github.Issue, error)) *MockClient_EditIssue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditIssueComment provides a mock function for the type MockClient
+func (_mock *MockClient) EditIssueComment(ctx context.Context, repo string, commentID int64, body string) error {
+	ret := _mock.Called(ctx, repo, commentID, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditIssueComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, string) error); ok {
+		r0 = returnFunc(ctx, repo, commentID, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_EditIssueComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditIssueComment'
+type MockClient_EditIssueComment_Call struct {
+	*mock.Call
+}
+
+// EditIssueComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - commentID int64
+//   - body string
+func (_e *MockClient_Expecter) EditIssueComment(ctx interface{}, repo interface{}, commentID interface{}, body interface{}) *MockClient_EditIssueComment_Call {
+	return &MockClient_EditIssueComment_Call{Call: _e.mock.On("EditIssueComment", ctx, repo, commentID, body)}
+}
+
+func (_c *MockClient_EditIssueComment_Call) Run(run func(ctx context.Context, repo string, commentID int64, body string)) *MockClient_EditIssueComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_EditIssueComment_Call) Return(err error) *MockClient_EditIssueComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_EditIssueComment_Call) RunAndReturn(run func(ctx context.Context, repo string, commentID int64, body string) error) *MockClient_EditIssueComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditPullRequestBody provides a mock function for the type MockClient
+func (_mock *MockClient) EditPullRequestBody(ctx context.Context, repo string, number int, body string) error {
+	ret := _mock.Called(ctx, repo, number, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditPullRequestBody")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) error); ok {
+		r0 = returnFunc(ctx, repo, number, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_EditPullRequestBody_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditPullRequestBody'
+type MockClient_EditPullRequestBody_Call struct {
+	*mock.Call
+}
+
+// EditPullRequestBody is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - number int
+//   - body string
+func (_e *MockClient_Expecter) EditPullRequestBody(ctx interface{}, repo interface{}, number interface{}, body interface{}) *MockClient_EditPullRequestBody_Call {
+	return &MockClient_EditPullRequestBody_Call{Call: _e.mock.On("EditPullRequestBody", ctx, repo, number, body)}
+}
+
+func (_c *MockClient_EditPullRequestBody_Call) Run(run func(ctx context.Context, repo string, number int, body string)) *MockClient_EditPullRequestBody_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_EditPullRequestBody_Call) Return(err error) *MockClient_EditPullRequestBody_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_EditPullRequestBody_Call) RunAndReturn(run func(ctx context.Context, repo string, number int, body string) error) *MockClient_EditPullRequestBody_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditRepository provides a mock function for the type MockClient
+func (_mock *MockClient) EditRepository(ctx context.Context, repo string, settings *github.Repository) (*github.Repository, error) {
+	ret := _mock.Called(ctx, repo, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditRepository")
+	}
+
+	var r0 *github.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *github.Repository) (*github.Repository, error)); ok {
+		return returnFunc(ctx, repo, settings)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *github.Repository) *github.Repository); ok {
+		r0 = returnFunc(ctx, repo, settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Repository)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *github.Repository) error); ok {
+		r1 = returnFunc(ctx, repo, settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_EditRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditRepository'
+type MockClient_EditRepository_Call struct {
+	*mock.Call
+}
+
+// EditRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - settings *github.Repository
+func (_e *MockClient_Expecter) EditRepository(ctx interface{}, repo interface{}, settings interface{}) *MockClient_EditRepository_Call {
+	return &MockClient_EditRepository_Call{Call: _e.mock.On("EditRepository", ctx, repo, settings)}
+}
+
+func (_c *MockClient_EditRepository_Call) Run(run func(ctx context.Context, repo string, settings *github.Repository)) *MockClient_EditRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *github.Repository
+		if args[2] != nil {
+			arg2 = args[2].(*github.Repository)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_EditRepository_Call) Return(repository *github.Repository, err error) *MockClient_EditRepository_Call {
+	_c.Call.Return(repository, err)
+	return _c
+}
+
+func (_c *MockClient_EditRepository_Call) RunAndReturn(run func(ctx context.Context, repo string, settings *github.Repository) (*github.Repository, error)) *MockClient_EditRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableAutoMerge provides a mock function for the type MockClient
+func (_mock *MockClient) EnableAutoMerge(ctx context.Context, pullRequestID string, mergeMethod string) error {
+	ret := _mock.Called(ctx, pullRequestID, mergeMethod)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableAutoMerge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, pullRequestID, mergeMethod)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_EnableAutoMerge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableAutoMerge'
+type MockClient_EnableAutoMerge_Call struct {
+	*mock.Call
+}
+
+// EnableAutoMerge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pullRequestID string
+//   - mergeMethod string
+func (_e *MockClient_Expecter) EnableAutoMerge(ctx interface{}, pullRequestID interface{}, mergeMethod interface{}) *MockClient_EnableAutoMerge_Call {
+	return &MockClient_EnableAutoMerge_Call{Call: _e.mock.On("EnableAutoMerge", ctx, pullRequestID, mergeMethod)}
+}
+
+func (_c *MockClient_EnableAutoMerge_Call) Run(run func(ctx context.Context, pullRequestID string, mergeMethod string)) *MockClient_EnableAutoMerge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_EnableAutoMerge_Call) Return(err error) *MockClient_EnableAutoMerge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_EnableAutoMerge_Call) RunAndReturn(run func(ctx context.Context, pullRequestID string, mergeMethod string) error) *MockClient_EnableAutoMerge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPullRequestByBranch provides a mock function for the type MockClient
+func (_mock *MockClient) FindPullRequestByBranch(ctx context.Context, repo string, branchName string) (*github.PullRequest, error) {
+	ret := _mock.Called(ctx, repo, branchName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPullRequestByBranch")
+	}
+
+	var r0 *github.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.PullRequest, error)); ok {
+		return returnFunc(ctx, repo, branchName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.PullRequest); ok {
+		r0 = returnFunc(ctx, repo, branchName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, branchName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_FindPullRequestByBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPullRequestByBranch'
+type MockClient_FindPullRequestByBranch_Call struct {
+	*mock.Call
+}
+
+// FindPullRequestByBranch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - branchName string
+func (_e *MockClient_Expecter) FindPullRequestByBranch(ctx interface{}, repo interface{}, branchName interface{}) *MockClient_FindPullRequestByBranch_Call {
+	return &MockClient_FindPullRequestByBranch_Call{Call: _e.mock.On("FindPullRequestByBranch", ctx, repo, branchName)}
+}
+
+func (_c *MockClient_FindPullRequestByBranch_Call) Run(run func(ctx context.Context, repo string, branchName string)) *MockClient_FindPullRequestByBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_FindPullRequestByBranch_Call) Return(pullRequest *github.PullRequest, err error) *MockClient_FindPullRequestByBranch_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockClient_FindPullRequestByBranch_Call) RunAndReturn(run func(ctx context.Context, repo string, branchName string) (*github.PullRequest, error)) *MockClient_FindPullRequestByBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBranch provides a mock function for the type MockClient
+func (_mock *MockClient) GetBranch(ctx context.Context, repo string, branch string) (*github.Reference, error) {
+	ret := _mock.Called(ctx, repo, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBranch")
+	}
+
+	var r0 *github.Reference
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.Reference, error)); ok {
+		return returnFunc(ctx, repo, branch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.Reference); ok {
+		r0 = returnFunc(ctx, repo, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Reference)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranch'
+type MockClient_GetBranch_Call struct {
+	*mock.Call
+}
+
+// GetBranch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - branch string
+func (_e *MockClient_Expecter) GetBranch(ctx interface{}, repo interface{}, branch interface{}) *MockClient_GetBranch_Call {
+	return &MockClient_GetBranch_Call{Call: _e.mock.On("GetBranch", ctx, repo, branch)}
+}
+
+func (_c *MockClient_GetBranch_Call) Run(run func(ctx context.Context, repo string, branch string)) *MockClient_GetBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetBranch_Call) Return(reference *github.Reference, err error) *MockClient_GetBranch_Call {
+	_c.Call.Return(reference, err)
+	return _c
+}
+
+func (_c *MockClient_GetBranch_Call) RunAndReturn(run func(ctx context.Context, repo string, branch string) (*github.Reference, error)) *MockClient_GetBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBranchProtection provides a mock function for the type MockClient
+func (_mock *MockClient) GetBranchProtection(ctx context.Context, repo string, branch string) (*github.Protection, error) {
+	ret := _mock.Called(ctx, repo, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBranchProtection")
+	}
+
+	var r0 *github.Protection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.Protection, error)); ok {
+		return returnFunc(ctx, repo, branch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.Protection); ok {
+		r0 = returnFunc(ctx, repo, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Protection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetBranchProtection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBranchProtection'
+type MockClient_GetBranchProtection_Call struct {
+	*mock.Call
+}
+
+// GetBranchProtection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - branch string
+func (_e *MockClient_Expecter) GetBranchProtection(ctx interface{}, repo interface{}, branch interface{}) *MockClient_GetBranchProtection_Call {
+	return &MockClient_GetBranchProtection_Call{Call: _e.mock.On("GetBranchProtection", ctx, repo, branch)}
+}
+
+func (_c *MockClient_GetBranchProtection_Call) Run(run func(ctx context.Context, repo string, branch string)) *MockClient_GetBranchProtection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetBranchProtection_Call) Return(protection *github.Protection, err error) *MockClient_GetBranchProtection_Call {
+	_c.Call.Return(protection, err)
+	return _c
+}
+
+func (_c *MockClient_GetBranchProtection_Call) RunAndReturn(run func(ctx context.Context, repo string, branch string) (*github.Protection, error)) *MockClient_GetBranchProtection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentsRaw provides a mock function for the type MockClient
+func (_mock *MockClient) GetContentsRaw(ctx context.Context, repo string, path string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	ret := _mock.Called(ctx, repo, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentsRaw")
+	}
+
+	var r0 *github.RepositoryContent
+	var r1 []*github.RepositoryContent
+	var r2 *github.Response
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)); ok {
+		return returnFunc(ctx, repo, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.RepositoryContent); ok {
+		r0 = returnFunc(ctx, repo, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryContent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) []*github.RepositoryContent); ok {
+		r1 = returnFunc(ctx, repo, path)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*github.RepositoryContent)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) *github.Response); ok {
+		r2 = returnFunc(ctx, repo, path)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, string, string) error); ok {
+		r3 = returnFunc(ctx, repo, path)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockClient_GetContentsRaw_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentsRaw'
+type MockClient_GetContentsRaw_Call struct {
+	*mock.Call
+}
+
+// GetContentsRaw is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - path string
+func (_e *MockClient_Expecter) GetContentsRaw(ctx interface{}, repo interface{}, path interface{}) *MockClient_GetContentsRaw_Call {
+	return &MockClient_GetContentsRaw_Call{Call: _e.mock.On("GetContentsRaw", ctx, repo, path)}
+}
+
+func (_c *MockClient_GetContentsRaw_Call) Run(run func(ctx context.Context, repo string, path string)) *MockClient_GetContentsRaw_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetContentsRaw_Call) Return(repositoryContent *github.RepositoryContent, repositoryContents []*github.RepositoryContent, response *github.Response, err error) *MockClient_GetContentsRaw_Call {
+	_c.Call.Return(repositoryContent, repositoryContents, response, err)
+	return _c
+}
+
+func (_c *MockClient_GetContentsRaw_Call) RunAndReturn(run func(ctx context.Context, repo string, path string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)) *MockClient_GetContentsRaw_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileContent provides a mock function for the type MockClient
+func (_mock *MockClient) GetFileContent(ctx context.Context, repo string, path string, ref string) (string, string, error) {
+	ret := _mock.Called(ctx, repo, path, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileContent")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (string, string, error)); ok {
+		return returnFunc(ctx, repo, path, ref)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = returnFunc(ctx, repo, path, ref)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) string); ok {
+		r1 = returnFunc(ctx, repo, path, ref)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = returnFunc(ctx, repo, path, ref)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockClient_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
+type MockClient_GetFileContent_Call struct {
+	*mock.Call
+}
+
+// GetFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - path string
+//   - ref string
+func (_e *MockClient_Expecter) GetFileContent(ctx interface{}, repo interface{}, path interface{}, ref interface{}) *MockClient_GetFileContent_Call {
+	return &MockClient_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, repo, path, ref)}
+}
+
+func (_c *MockClient_GetFileContent_Call) Run(run func(ctx context.Context, repo string, path string, ref string)) *MockClient_GetFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetFileContent_Call) Return(content string, sha string, err error) *MockClient_GetFileContent_Call {
+	_c.Call.Return(content, sha, err)
+	return _c
+}
+
+func (_c *MockClient_GetFileContent_Call) RunAndReturn(run func(ctx context.Context, repo string, path string, ref string) (string, string, error)) *MockClient_GetFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLabel provides a mock function for the type MockClient
+func (_mock *MockClient) GetLabel(ctx context.Context, repo string, name string) (*github.Label, *github.Response, error) {
+	ret := _mock.Called(ctx, repo, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLabel")
+	}
+
+	var r0 *github.Label
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.Label, *github.Response, error)); ok {
+		return returnFunc(ctx, repo, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.Label); ok {
+		r0 = returnFunc(ctx, repo, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Label)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) *github.Response); ok {
+		r1 = returnFunc(ctx, repo, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, repo, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockClient_GetLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLabel'
+type MockClient_GetLabel_Call struct {
+	*mock.Call
+}
+
+// GetLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - name string
+func (_e *MockClient_Expecter) GetLabel(ctx interface{}, repo interface{}, name interface{}) *MockClient_GetLabel_Call {
+	return &MockClient_GetLabel_Call{Call: _e.mock.On("GetLabel", ctx, repo, name)}
+}
+
+func (_c *MockClient_GetLabel_Call) Run(run func(ctx context.Context, repo string, name string)) *MockClient_GetLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetLabel_Call) Return(label *github.Label, response *github.Response, err error) *MockClient_GetLabel_Call {
+	_c.Call.Return(label, response, err)
+	return _c
+}
+
+func (_c *MockClient_GetLabel_Call) RunAndReturn(run func(ctx context.Context, repo string, name string) (*github.Label, *github.Response, error)) *MockClient_GetLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepository provides a mock function for the type MockClient
+func (_mock *MockClient) GetRepository(ctx context.Context, repo string) (*github.Repository, error) {
+	ret := _mock.Called(ctx, repo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepository")
+	}
+
+	var r0 *github.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*github.Repository, error)); ok {
+		return returnFunc(ctx, repo)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *github.Repository); ok {
+		r0 = returnFunc(ctx, repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Repository)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepository'
+type MockClient_GetRepository_Call struct {
+	*mock.Call
+}
+
+// GetRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+func (_e *MockClient_Expecter) GetRepository(ctx interface{}, repo interface{}) *MockClient_GetRepository_Call {
+	return &MockClient_GetRepository_Call{Call: _e.mock.On("GetRepository", ctx, repo)}
+}
+
+func (_c *MockClient_GetRepository_Call) Run(run func(ctx context.Context, repo string)) *MockClient_GetRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetRepository_Call) Return(repository *github.Repository, err error) *MockClient_GetRepository_Call {
+	_c.Call.Return(repository, err)
+	return _c
+}
+
+func (_c *MockClient_GetRepository_Call) RunAndReturn(run func(ctx context.Context, repo string) (*github.Repository, error)) *MockClient_GetRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRulesetByName provides a mock function for the type MockClient
+func (_mock *MockClient) GetRulesetByName(ctx context.Context, repo string, name string) (*github.RepositoryRuleset, error) {
+	ret := _mock.Called(ctx, repo, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRulesetByName")
+	}
+
+	var r0 *github.RepositoryRuleset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.RepositoryRuleset, error)); ok {
+		return returnFunc(ctx, repo, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.RepositoryRuleset); ok {
+		r0 = returnFunc(ctx, repo, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryRuleset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetRulesetByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRulesetByName'
+type MockClient_GetRulesetByName_Call struct {
+	*mock.Call
+}
+
+// GetRulesetByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - name string
+func (_e *MockClient_Expecter) GetRulesetByName(ctx interface{}, repo interface{}, name interface{}) *MockClient_GetRulesetByName_Call {
+	return &MockClient_GetRulesetByName_Call{Call: _e.mock.On("GetRulesetByName", ctx, repo, name)}
+}
+
+func (_c *MockClient_GetRulesetByName_Call) Run(run func(ctx context.Context, repo string, name string)) *MockClient_GetRulesetByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetRulesetByName_Call) Return(repositoryRuleset *github.RepositoryRuleset, err error) *MockClient_GetRulesetByName_Call {
+	_c.Call.Return(repositoryRuleset, err)
+	return _c
+}
+
+func (_c *MockClient_GetRulesetByName_Call) RunAndReturn(run func(ctx context.Context, repo string, name string) (*github.RepositoryRuleset, error)) *MockClient_GetRulesetByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTree provides a mock function for the type MockClient
+func (_mock *MockClient) GetTree(ctx context.Context, repo string, sha string, recursive bool) (*github.Tree, *github.Response, error) {
+	ret := _mock.Called(ctx, repo, sha, recursive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTree")
+	}
+
+	var r0 *github.Tree
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) (*github.Tree, *github.Response, error)); ok {
+		return returnFunc(ctx, repo, sha, recursive)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) *github.Tree); ok {
+		r0 = returnFunc(ctx, repo, sha, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Tree)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, bool) *github.Response); ok {
+		r1 = returnFunc(ctx, repo, sha, recursive)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, bool) error); ok {
+		r2 = returnFunc(ctx, repo, sha, recursive)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockClient_GetTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTree'
+type MockClient_GetTree_Call struct {
+	*mock.Call
+}
+
+// GetTree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - sha string
+//   - recursive bool
+func (_e *MockClient_Expecter) GetTree(ctx interface{}, repo interface{}, sha interface{}, recursive interface{}) *MockClient_GetTree_Call {
+	return &MockClient_GetTree_Call{Call: _e.mock.On("GetTree", ctx, repo, sha, recursive)}
+}
+
+func (_c *MockClient_GetTree_Call) Run(run func(ctx context.Context, repo string, sha string, recursive bool)) *MockClient_GetTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetTree_Call) Return(tree *github.Tree, response *github.Response, err error) *MockClient_GetTree_Call {
+	_c.Call.Return(tree, response, err)
+	return _c
+}
+
+func (_c *MockClient_GetTree_Call) RunAndReturn(run func(ctx context.Context, repo string, sha string, recursive bool) (*github.Tree, *github.Response, error)) *MockClient_GetTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllRepos provides a mock function for the type MockClient
+func (_mock *MockClient) ListAllRepos(ctx context.Context) ([]*github.Repository, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllRepos")
+	}
+
+	var r0 []*github.Repository
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*github.Repository, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*github.Repository); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.Repository)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ListAllRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllRepos'
+type MockClient_ListAllRepos_Call struct {
+	*mock.Call
+}
+
+// ListAllRepos is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListAllRepos(ctx interface{}) *MockClient_ListAllRepos_Call {
+	return &MockClient_ListAllRepos_Call{Call: _e.mock.On("ListAllRepos", ctx)}
+}
+
+func (_c *MockClient_ListAllRepos_Call) Run(run func(ctx context.Context)) *MockClient_ListAllRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ListAllRepos_Call) Return(repositorys []*github.Repository, err error) *MockClient_ListAllRepos_Call {
+	_c.Call.Return(repositorys, err)
+	return _c
+}
+
+func (_c *MockClient_ListAllRepos_Call) RunAndReturn(run func(ctx context.Context) ([]*github.Repository, error)) *MockClient_ListAllRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIssueComments provides a mock function for the type MockClient
+func (_mock *MockClient) ListIssueComments(ctx context.Context, repo string, number int) ([]*github.IssueComment, error) {
+	ret := _mock.Called(ctx, repo, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIssueComments")
+	}
+
+	var r0 []*github.IssueComment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*github.IssueComment, error)); ok {
+		return returnFunc(ctx, repo, number)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*github.IssueComment); ok {
+		r0 = returnFunc(ctx, repo, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.IssueComment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, repo, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ListIssueComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIssueComments'
+type MockClient_ListIssueComments_Call struct {
+	*mock.Call
+}
+
+// ListIssueComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - number int
+func (_e *MockClient_Expecter) ListIssueComments(ctx interface{}, repo interface{}, number interface{}) *MockClient_ListIssueComments_Call {
+	return &MockClient_ListIssueComments_Call{Call: _e.mock.On("ListIssueComments", ctx, repo, number)}
+}
+
+func (_c *MockClient_ListIssueComments_Call) Run(run func(ctx context.Context, repo string, number int)) *MockClient_ListIssueComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ListIssueComments_Call) Return(issueComments []*github.IssueComment, err error) *MockClient_ListIssueComments_Call {
+	_c.Call.Return(issueComments, err)
+	return _c
+}
+
+func (_c *MockClient_ListIssueComments_Call) RunAndReturn(run func(ctx context.Context, repo string, number int) ([]*github.IssueComment, error)) *MockClient_ListIssueComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIssues provides a mock function for the type MockClient
+func (_mock *MockClient) ListIssues(ctx context.Context, repo string, creator string) ([]*github.Issue, error) {
+	ret := _mock.Called(ctx, repo, creator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIssues")
+	}
+
+	var r0 []*github.Issue
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*github.Issue, error)); ok {
+		return returnFunc(ctx, repo, creator)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*github.Issue); ok {
+		r0 = returnFunc(ctx, repo, creator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.Issue)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repo, creator)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ListIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIssues'
+type MockClient_ListIssues_Call struct {
+	*mock.Call
+}
+
+// ListIssues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - creator string
+func (_e *MockClient_Expecter) ListIssues(ctx interface{}, repo interface{}, creator interface{}) *MockClient_ListIssues_Call {
+	return &MockClient_ListIssues_Call{Call: _e.mock.On("ListIssues", ctx, repo, creator)}
+}
+
+func (_c *MockClient_ListIssues_Call) Run(run func(ctx context.Context, repo string, creator string)) *MockClient_ListIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ListIssues_Call) Return(issues []*github.Issue, err error) *MockClient_ListIssues_Call {
+	_c.Call.Return(issues, err)
+	return _c
+}
+
+func (_c *MockClient_ListIssues_Call) RunAndReturn(run func(ctx context.Context, repo string, creator string) ([]*github.Issue, error)) *MockClient_ListIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPullRequests provides a mock function for the type MockClient
+func (_mock *MockClient) ListPullRequests(ctx context.Context, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+	ret := _mock.Called(ctx, repo, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequests")
+	}
+
+	var r0 []*github.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *github.PullRequestListOptions) ([]*github.PullRequest, error)); ok {
+		return returnFunc(ctx, repo, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *github.PullRequestListOptions) []*github.PullRequest); ok {
+		r0 = returnFunc(ctx, repo, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *github.PullRequestListOptions) error); ok {
+		r1 = returnFunc(ctx, repo, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ListPullRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPullRequests'
+type MockClient_ListPullRequests_Call struct {
+	*mock.Call
+}
+
+// ListPullRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - opts *github.PullRequestListOptions
+func (_e *MockClient_Expecter) ListPullRequests(ctx interface{}, repo interface{}, opts interface{}) *MockClient_ListPullRequests_Call {
+	return &MockClient_ListPullRequests_Call{Call: _e.mock.On("ListPullRequests", ctx, repo, opts)}
+}
+
+func (_c *MockClient_ListPullRequests_Call) Run(run func(ctx context.Context, repo string, opts *github.PullRequestListOptions)) *MockClient_ListPullRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *github.PullRequestListOptions
+		if args[2] != nil {
+			arg2 = args[2].(*github.PullRequestListOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ListPullRequests_Call) Return(pullRequests []*github.PullRequest, err error) *MockClient_ListPullRequests_Call {
+	_c.Call.Return(pullRequests, err)
+	return _c
+}
+
+func (_c *MockClient_ListPullRequests_Call) RunAndReturn(run func(ctx context.Context, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, error)) *MockClient_ListPullRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PinIssue provides a mock function for the type MockClient
+func (_mock *MockClient) PinIssue(ctx context.Context, issueID string) error {
+	ret := _mock.Called(ctx, issueID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinIssue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, issueID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_PinIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinIssue'
+type MockClient_PinIssue_Call struct {
+	*mock.Call
+}
+
+// PinIssue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issueID string
+func (_e *MockClient_Expecter) PinIssue(ctx interface{}, issueID interface{}) *MockClient_PinIssue_Call {
+	return &MockClient_PinIssue_Call{Call: _e.mock.On("PinIssue", ctx, issueID)}
+}
+
+func (_c *MockClient_PinIssue_Call) Run(run func(ctx context.Context, issueID string)) *MockClient_PinIssue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_PinIssue_Call) Return(err error) *MockClient_PinIssue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_PinIssue_Call) RunAndReturn(run func(ctx context.Context, issueID string) error) *MockClient_PinIssue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestReviewers provides a mock function for the type MockClient
+func (_mock *MockClient) RequestReviewers(ctx context.Context, repo string, number int, reviewers []string, teamReviewers []string) error {
+	ret := _mock.Called(ctx, repo, number, reviewers, teamReviewers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReviewers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, []string, []string) error); ok {
+		r0 = returnFunc(ctx, repo, number, reviewers, teamReviewers)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_RequestReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestReviewers'
+type MockClient_RequestReviewers_Call struct {
+	*mock.Call
+}
+
+// RequestReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - number int
+//   - reviewers []string
+//   - teamReviewers []string
+func (_e *MockClient_Expecter) RequestReviewers(ctx interface{}, repo interface{}, number interface{}, reviewers interface{}, teamReviewers interface{}) *MockClient_RequestReviewers_Call {
+	return &MockClient_RequestReviewers_Call{Call: _e.mock.On("RequestReviewers", ctx, repo, number, reviewers, teamReviewers)}
+}
+
+func (_c *MockClient_RequestReviewers_Call) Run(run func(ctx context.Context, repo string, number int, reviewers []string, teamReviewers []string)) *MockClient_RequestReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_RequestReviewers_Call) Return(err error) *MockClient_RequestReviewers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_RequestReviewers_Call) RunAndReturn(run func(ctx context.Context, repo string, number int, reviewers []string, teamReviewers []string) error) *MockClient_RequestReviewers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveRef provides a mock function for the type MockClient
+func (_mock *MockClient) ResolveRef(ctx context.Context, owner string, repo string, ref string) (string, error) {
+	ret := _mock.Called(ctx, owner, repo, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveRef")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return returnFunc(ctx, owner, repo, ref)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = returnFunc(ctx, owner, repo, ref)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, owner, repo, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ResolveRef_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveRef'
+type MockClient_ResolveRef_Call struct {
+	*mock.Call
+}
+
+// ResolveRef is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - ref string
+func (_e *MockClient_Expecter) ResolveRef(ctx interface{}, owner interface{}, repo interface{}, ref interface{}) *MockClient_ResolveRef_Call {
+	return &MockClient_ResolveRef_Call{Call: _e.mock.On("ResolveRef", ctx, owner, repo, ref)}
+}
+
+func (_c *MockClient_ResolveRef_Call) Run(run func(ctx context.Context, owner string, repo string, ref string)) *MockClient_ResolveRef_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ResolveRef_Call) Return(s string, err error) *MockClient_ResolveRef_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockClient_ResolveRef_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, ref string) (string, error)) *MockClient_ResolveRef_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBranchProtection provides a mock function for the type MockClient
+func (_mock *MockClient) UpdateBranchProtection(ctx context.Context, repo string, branch string, protection *github.ProtectionRequest) error {
+	ret := _mock.Called(ctx, repo, branch, protection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBranchProtection")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *github.ProtectionRequest) error); ok {
+		r0 = returnFunc(ctx, repo, branch, protection)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_UpdateBranchProtection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBranchProtection'
+type MockClient_UpdateBranchProtection_Call struct {
+	*mock.Call
+}
+
+// UpdateBranchProtection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - branch string
+//   - protection *github.ProtectionRequest
+func (_e *MockClient_Expecter) UpdateBranchProtection(ctx interface{}, repo interface{}, branch interface{}, protection interface{}) *MockClient_UpdateBranchProtection_Call {
+	return &MockClient_UpdateBranchProtection_Call{Call: _e.mock.On("UpdateBranchProtection", ctx, repo, branch, protection)}
+}
+
+func (_c *MockClient_UpdateBranchProtection_Call) Run(run func(ctx context.Context, repo string, branch string, protection *github.ProtectionRequest)) *MockClient_UpdateBranchProtection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *github.ProtectionRequest
+		if args[3] != nil {
+			arg3 = args[3].(*github.ProtectionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateBranchProtection_Call) Return(err error) *MockClient_UpdateBranchProtection_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_UpdateBranchProtection_Call) RunAndReturn(run func(ctx context.Context, repo string, branch string, protection *github.ProtectionRequest) error) *MockClient_UpdateBranchProtection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRuleset provides a mock function for the type MockClient
+func (_mock *MockClient) UpdateRuleset(ctx context.Context, repo string, ruleset github.RepositoryRuleset) error {
+	ret := _mock.Called(ctx, repo, ruleset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRuleset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, github.RepositoryRuleset) error); ok {
+		r0 = returnFunc(ctx, repo, ruleset)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_UpdateRuleset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRuleset'
+type MockClient_UpdateRuleset_Call struct {
+	*mock.Call
+}
+
+// UpdateRuleset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - ruleset github.RepositoryRuleset
+func (_e *MockClient_Expecter) UpdateRuleset(ctx interface{}, repo interface{}, ruleset interface{}) *MockClient_UpdateRuleset_Call {
+	return &MockClient_UpdateRuleset_Call{Call: _e.mock.On("UpdateRuleset", ctx, repo, ruleset)}
+}
+
+func (_c *MockClient_UpdateRuleset_Call) Run(run func(ctx context.Context, repo string, ruleset github.RepositoryRuleset)) *MockClient_UpdateRuleset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 github.RepositoryRuleset
+		if args[2] != nil {
+			arg2 = args[2].(github.RepositoryRuleset)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateRuleset_Call) Return(err error) *MockClient_UpdateRuleset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_UpdateRuleset_Call) RunAndReturn(run func(ctx context.Context, repo string, ruleset github.RepositoryRuleset) error) *MockClient_UpdateRuleset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadSARIF provides a mock function for the type MockClient
+func (_mock *MockClient) UploadSARIF(ctx context.Context, repo string, commitSHA string, ref string, sarif []byte) error {
+	ret := _mock.Called(ctx, repo, commitSHA, ref, sarif)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadSARIF")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, repo, commitSHA, ref, sarif)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_UploadSARIF_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadSARIF'
+type MockClient_UploadSARIF_Call struct {
+	*mock.Call
+}
+
+// UploadSARIF is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo string
+//   - commitSHA string
+//   - ref string
+//   - sarif []byte
+func (_e *MockClient_Expecter) UploadSARIF(ctx interface{}, repo interface{}, commitSHA interface{}, ref interface{}, sarif interface{}) *MockClient_UploadSARIF_Call {
+	return &MockClient_UploadSARIF_Call{Call: _e.mock.On("UploadSARIF", ctx, repo, commitSHA, ref, sarif)}
+}
+
+func (_c *MockClient_UploadSARIF_Call) Run(run func(ctx context.Context, repo string, commitSHA string, ref string, sarif []byte)) *MockClient_UploadSARIF_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []byte
+		if args[4] != nil {
+			arg4 = args[4].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_UploadSARIF_Call) Return(err error) *MockClient_UploadSARIF_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_UploadSARIF_Call) RunAndReturn(run func(ctx context.Context, repo string, commitSHA string, ref string, sarif []byte) error) *MockClient_UploadSARIF_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -38,6 +38,282 @@ func (_m *MockGitAdapter) EXPECT() *MockGitAdapter_Expecter {
 	return &MockGitAdapter_Expecter{mock: &_m.Mock}
 }
 
+// CreateCommit provides a mock function for the type MockGitAdapter
+func (_mock *MockGitAdapter) CreateCommit(ctx context.Context, owner string, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, commit, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCommit")
+	}
+
+	var r0 *github.Commit
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, github.Commit, *github.CreateCommitOptions) (*github.Commit, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, commit, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, github.Commit, *github.CreateCommitOptions) *github.Commit); ok {
+		r0 = returnFunc(ctx, owner, repo, commit, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Commit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, github.Commit, *github.CreateCommitOptions) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, commit, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, github.Commit, *github.CreateCommitOptions) error); ok {
+		r2 = returnFunc(ctx, owner, repo, commit, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockGitAdapter_CreateCommit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCommit'
+type MockGitAdapter_CreateCommit_Call struct {
+	*mock.Call
+}
+
+// CreateCommit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - commit github.Commit
+//   - opts *github.CreateCommitOptions
+func (_e *MockGitAdapter_Expecter) CreateCommit(ctx interface{}, owner interface{}, repo interface{}, commit interface{}, opts interface{}) *MockGitAdapter_CreateCommit_Call {
+	return &MockGitAdapter_CreateCommit_Call{Call: _e.mock.On("CreateCommit", ctx, owner, repo, commit, opts)}
+}
+
+func (_c *MockGitAdapter_CreateCommit_Call) Run(run func(ctx context.Context, owner string, repo string, commit github.Commit, opts *github.CreateCommitOptions)) *MockGitAdapter_CreateCommit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 github.Commit
+		if args[3] != nil {
+			arg3 = args[3].(github.Commit)
+		}
+		var arg4 *github.CreateCommitOptions
+		if args[4] != nil {
+			arg4 = args[4].(*github.CreateCommitOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitAdapter_CreateCommit_Call) Return(commit *github.Commit, response *github.Response, err error) *MockGitAdapter_CreateCommit_Call {
+	_c.Call.Return(commit, response, err)
+	return _c
+}
+
+func (_c *MockGitAdapter_CreateCommit_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error)) *MockGitAdapter_CreateCommit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTree provides a mock function for the type MockGitAdapter
+func (_mock *MockGitAdapter) CreateTree(ctx context.Context, owner string, repo string, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, baseTree, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTree")
+	}
+
+	var r0 *github.Tree
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []*github.TreeEntry) (*github.Tree, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, baseTree, entries)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []*github.TreeEntry) *github.Tree); ok {
+		r0 = returnFunc(ctx, owner, repo, baseTree, entries)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Tree)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, []*github.TreeEntry) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, baseTree, entries)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string, []*github.TreeEntry) error); ok {
+		r2 = returnFunc(ctx, owner, repo, baseTree, entries)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockGitAdapter_CreateTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTree'
+type MockGitAdapter_CreateTree_Call struct {
+	*mock.Call
+}
+
+// CreateTree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - baseTree string
+//   - entries []*github.TreeEntry
+func (_e *MockGitAdapter_Expecter) CreateTree(ctx interface{}, owner interface{}, repo interface{}, baseTree interface{}, entries interface{}) *MockGitAdapter_CreateTree_Call {
+	return &MockGitAdapter_CreateTree_Call{Call: _e.mock.On("CreateTree", ctx, owner, repo, baseTree, entries)}
+}
+
+func (_c *MockGitAdapter_CreateTree_Call) Run(run func(ctx context.Context, owner string, repo string, baseTree string, entries []*github.TreeEntry)) *MockGitAdapter_CreateTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []*github.TreeEntry
+		if args[4] != nil {
+			arg4 = args[4].([]*github.TreeEntry)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitAdapter_CreateTree_Call) Return(tree *github.Tree, response *github.Response, err error) *MockGitAdapter_CreateTree_Call {
+	_c.Call.Return(tree, response, err)
+	return _c
+}
+
+func (_c *MockGitAdapter_CreateTree_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error)) *MockGitAdapter_CreateTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommit provides a mock function for the type MockGitAdapter
+func (_mock *MockGitAdapter) GetCommit(ctx context.Context, owner string, repo string, sha string) (*github.Commit, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, sha)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommit")
+	}
+
+	var r0 *github.Commit
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*github.Commit, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, sha)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *github.Commit); ok {
+		r0 = returnFunc(ctx, owner, repo, sha)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Commit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, sha)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = returnFunc(ctx, owner, repo, sha)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockGitAdapter_GetCommit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommit'
+type MockGitAdapter_GetCommit_Call struct {
+	*mock.Call
+}
+
+// GetCommit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - sha string
+func (_e *MockGitAdapter_Expecter) GetCommit(ctx interface{}, owner interface{}, repo interface{}, sha interface{}) *MockGitAdapter_GetCommit_Call {
+	return &MockGitAdapter_GetCommit_Call{Call: _e.mock.On("GetCommit", ctx, owner, repo, sha)}
+}
+
+func (_c *MockGitAdapter_GetCommit_Call) Run(run func(ctx context.Context, owner string, repo string, sha string)) *MockGitAdapter_GetCommit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitAdapter_GetCommit_Call) Return(commit *github.Commit, response *github.Response, err error) *MockGitAdapter_GetCommit_Call {
+	_c.Call.Return(commit, response, err)
+	return _c
+}
+
+func (_c *MockGitAdapter_GetCommit_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, sha string) (*github.Commit, *github.Response, error)) *MockGitAdapter_GetCommit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTree provides a mock function for the type MockGitAdapter
 func (_mock *MockGitAdapter) GetTree(ctx context.Context, owner string, repo string, sha string, recursive bool) (*github.Tree, *github.Response, error) {
 	ret := _mock.Called(ctx, owner, repo, sha, recursive)
@@ -126,6 +126,100 @@ func (_c *MockPullRequestsAdapter_Create_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// Edit provides a mock function for the type MockPullRequestsAdapter
+func (_mock *MockPullRequestsAdapter) Edit(ctx context.Context, owner string, repo string, number int, pull *github.PullRequest) (*github.PullRequest, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, number, pull)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Edit")
+	}
+
+	var r0 *github.PullRequest
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, *github.PullRequest) (*github.PullRequest, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, number, pull)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, *github.PullRequest) *github.PullRequest); ok {
+		r0 = returnFunc(ctx, owner, repo, number, pull)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, *github.PullRequest) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, number, pull)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, int, *github.PullRequest) error); ok {
+		r2 = returnFunc(ctx, owner, repo, number, pull)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockPullRequestsAdapter_Edit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Edit'
+type MockPullRequestsAdapter_Edit_Call struct {
+	*mock.Call
+}
+
+// Edit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - number int
+//   - pull *github.PullRequest
+func (_e *MockPullRequestsAdapter_Expecter) Edit(ctx interface{}, owner interface{}, repo interface{}, number interface{}, pull interface{}) *MockPullRequestsAdapter_Edit_Call {
+	return &MockPullRequestsAdapter_Edit_Call{Call: _e.mock.On("Edit", ctx, owner, repo, number, pull)}
+}
+
+func (_c *MockPullRequestsAdapter_Edit_Call) Run(run func(ctx context.Context, owner string, repo string, number int, pull *github.PullRequest)) *MockPullRequestsAdapter_Edit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 *github.PullRequest
+		if args[4] != nil {
+			arg4 = args[4].(*github.PullRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestsAdapter_Edit_Call) Return(pullRequest *github.PullRequest, response *github.Response, err error) *MockPullRequestsAdapter_Edit_Call {
+	_c.Call.Return(pullRequest, response, err)
+	return _c
+}
+
+func (_c *MockPullRequestsAdapter_Edit_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, number int, pull *github.PullRequest) (*github.PullRequest, *github.Response, error)) *MockPullRequestsAdapter_Edit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type MockPullRequestsAdapter
 func (_mock *MockPullRequestsAdapter) List(ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
 	ret := _mock.Called(ctx, owner, repo, opts)
@@ -213,3 +307,97 @@ func (_c *MockPullRequestsAdapter_List_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// RequestReviewers provides a mock function for the type MockPullRequestsAdapter
+func (_mock *MockPullRequestsAdapter) RequestReviewers(ctx context.Context, owner string, repo string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, number, reviewers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReviewers")
+	}
+
+	var r0 *github.PullRequest
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, github.ReviewersRequest) (*github.PullRequest, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, number, reviewers)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, github.ReviewersRequest) *github.PullRequest); ok {
+		r0 = returnFunc(ctx, owner, repo, number, reviewers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, github.ReviewersRequest) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, number, reviewers)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, int, github.ReviewersRequest) error); ok {
+		r2 = returnFunc(ctx, owner, repo, number, reviewers)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockPullRequestsAdapter_RequestReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestReviewers'
+type MockPullRequestsAdapter_RequestReviewers_Call struct {
+	*mock.Call
+}
+
+// RequestReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - number int
+//   - reviewers github.ReviewersRequest
+func (_e *MockPullRequestsAdapter_Expecter) RequestReviewers(ctx interface{}, owner interface{}, repo interface{}, number interface{}, reviewers interface{}) *MockPullRequestsAdapter_RequestReviewers_Call {
+	return &MockPullRequestsAdapter_RequestReviewers_Call{Call: _e.mock.On("RequestReviewers", ctx, owner, repo, number, reviewers)}
+}
+
+func (_c *MockPullRequestsAdapter_RequestReviewers_Call) Run(run func(ctx context.Context, owner string, repo string, number int, reviewers github.ReviewersRequest)) *MockPullRequestsAdapter_RequestReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 github.ReviewersRequest
+		if args[4] != nil {
+			arg4 = args[4].(github.ReviewersRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestsAdapter_RequestReviewers_Call) Return(pullRequest *github.PullRequest, response *github.Response, err error) *MockPullRequestsAdapter_RequestReviewers_Call {
+	_c.Call.Return(pullRequest, response, err)
+	return _c
+}
+
+func (_c *MockPullRequestsAdapter_RequestReviewers_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error)) *MockPullRequestsAdapter_RequestReviewers_Call {
+	_c.Call.Return(run)
+	return _c
+}
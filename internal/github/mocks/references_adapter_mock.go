@@ -213,3 +213,97 @@ func (_c *MockReferencesAdapter_GetRef_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateRef provides a mock function for the type MockReferencesAdapter
+func (_mock *MockReferencesAdapter) UpdateRef(ctx context.Context, owner string, repo string, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, ref, updateRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRef")
+	}
+
+	var r0 *github.Reference
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, github.UpdateRef) (*github.Reference, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, ref, updateRef)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, github.UpdateRef) *github.Reference); ok {
+		r0 = returnFunc(ctx, owner, repo, ref, updateRef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Reference)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, github.UpdateRef) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, ref, updateRef)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string, github.UpdateRef) error); ok {
+		r2 = returnFunc(ctx, owner, repo, ref, updateRef)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockReferencesAdapter_UpdateRef_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRef'
+type MockReferencesAdapter_UpdateRef_Call struct {
+	*mock.Call
+}
+
+// UpdateRef is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - ref string
+//   - updateRef github.UpdateRef
+func (_e *MockReferencesAdapter_Expecter) UpdateRef(ctx interface{}, owner interface{}, repo interface{}, ref interface{}, updateRef interface{}) *MockReferencesAdapter_UpdateRef_Call {
+	return &MockReferencesAdapter_UpdateRef_Call{Call: _e.mock.On("UpdateRef", ctx, owner, repo, ref, updateRef)}
+}
+
+func (_c *MockReferencesAdapter_UpdateRef_Call) Run(run func(ctx context.Context, owner string, repo string, ref string, updateRef github.UpdateRef)) *MockReferencesAdapter_UpdateRef_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 github.UpdateRef
+		if args[4] != nil {
+			arg4 = args[4].(github.UpdateRef)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReferencesAdapter_UpdateRef_Call) Return(reference *github.Reference, response *github.Response, err error) *MockReferencesAdapter_UpdateRef_Call {
+	_c.Call.Return(reference, response, err)
+	return _c
+}
+
+func (_c *MockReferencesAdapter_UpdateRef_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error)) *MockReferencesAdapter_UpdateRef_Call {
+	_c.Call.Return(run)
+	return _c
+}
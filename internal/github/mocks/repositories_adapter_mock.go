@@ -38,6 +38,106 @@ func (_m *MockRepositoriesAdapter) EXPECT() *MockRepositoriesAdapter_Expecter {
 	return &MockRepositoriesAdapter_Expecter{mock: &_m.Mock}
 }
 
+// CompareCommits provides a mock function for the type MockRepositoriesAdapter
+func (_mock *MockRepositoriesAdapter) CompareCommits(ctx context.Context, owner string, repo string, base string, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, base, head, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareCommits")
+	}
+
+	var r0 *github.CommitsComparison
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, *github.ListOptions) (*github.CommitsComparison, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, base, head, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, *github.ListOptions) *github.CommitsComparison); ok {
+		r0 = returnFunc(ctx, owner, repo, base, head, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.CommitsComparison)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, *github.ListOptions) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, base, head, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string, string, *github.ListOptions) error); ok {
+		r2 = returnFunc(ctx, owner, repo, base, head, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepositoriesAdapter_CompareCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareCommits'
+type MockRepositoriesAdapter_CompareCommits_Call struct {
+	*mock.Call
+}
+
+// CompareCommits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - base string
+//   - head string
+//   - opts *github.ListOptions
+func (_e *MockRepositoriesAdapter_Expecter) CompareCommits(ctx interface{}, owner interface{}, repo interface{}, base interface{}, head interface{}, opts interface{}) *MockRepositoriesAdapter_CompareCommits_Call {
+	return &MockRepositoriesAdapter_CompareCommits_Call{Call: _e.mock.On("CompareCommits", ctx, owner, repo, base, head, opts)}
+}
+
+func (_c *MockRepositoriesAdapter_CompareCommits_Call) Run(run func(ctx context.Context, owner string, repo string, base string, head string, opts *github.ListOptions)) *MockRepositoriesAdapter_CompareCommits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 *github.ListOptions
+		if args[5] != nil {
+			arg5 = args[5].(*github.ListOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_CompareCommits_Call) Return(commitsComparison *github.CommitsComparison, response *github.Response, err error) *MockRepositoriesAdapter_CompareCommits_Call {
+	_c.Call.Return(commitsComparison, response, err)
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_CompareCommits_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, base string, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)) *MockRepositoriesAdapter_CompareCommits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateFile provides a mock function for the type MockRepositoriesAdapter
 func (_mock *MockRepositoriesAdapter) CreateFile(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
 	ret := _mock.Called(ctx, owner, repo, path, opts)
@@ -132,6 +232,362 @@ func (_c *MockRepositoriesAdapter_CreateFile_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// DeleteFile provides a mock function for the type MockRepositoriesAdapter
+func (_mock *MockRepositoriesAdapter) DeleteFile(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, path, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 *github.RepositoryContentResponse
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, path, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, *github.RepositoryContentFileOptions) *github.RepositoryContentResponse); ok {
+		r0 = returnFunc(ctx, owner, repo, path, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryContentResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, *github.RepositoryContentFileOptions) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, path, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string, *github.RepositoryContentFileOptions) error); ok {
+		r2 = returnFunc(ctx, owner, repo, path, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepositoriesAdapter_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type MockRepositoriesAdapter_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - path string
+//   - opts *github.RepositoryContentFileOptions
+func (_e *MockRepositoriesAdapter_Expecter) DeleteFile(ctx interface{}, owner interface{}, repo interface{}, path interface{}, opts interface{}) *MockRepositoriesAdapter_DeleteFile_Call {
+	return &MockRepositoriesAdapter_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, owner, repo, path, opts)}
+}
+
+func (_c *MockRepositoriesAdapter_DeleteFile_Call) Run(run func(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions)) *MockRepositoriesAdapter_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 *github.RepositoryContentFileOptions
+		if args[4] != nil {
+			arg4 = args[4].(*github.RepositoryContentFileOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_DeleteFile_Call) Return(repositoryContentResponse *github.RepositoryContentResponse, response *github.Response, err error) *MockRepositoriesAdapter_DeleteFile_Call {
+	_c.Call.Return(repositoryContentResponse, response, err)
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_DeleteFile_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)) *MockRepositoriesAdapter_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Edit provides a mock function for the type MockRepositoriesAdapter
+func (_mock *MockRepositoriesAdapter) Edit(ctx context.Context, owner string, repo string, repository *github.Repository) (*github.Repository, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, repository)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Edit")
+	}
+
+	var r0 *github.Repository
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *github.Repository) (*github.Repository, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, repository)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *github.Repository) *github.Repository); ok {
+		r0 = returnFunc(ctx, owner, repo, repository)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Repository)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *github.Repository) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, repository)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, *github.Repository) error); ok {
+		r2 = returnFunc(ctx, owner, repo, repository)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepositoriesAdapter_Edit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Edit'
+type MockRepositoriesAdapter_Edit_Call struct {
+	*mock.Call
+}
+
+// Edit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - repository *github.Repository
+func (_e *MockRepositoriesAdapter_Expecter) Edit(ctx interface{}, owner interface{}, repo interface{}, repository interface{}) *MockRepositoriesAdapter_Edit_Call {
+	return &MockRepositoriesAdapter_Edit_Call{Call: _e.mock.On("Edit", ctx, owner, repo, repository)}
+}
+
+func (_c *MockRepositoriesAdapter_Edit_Call) Run(run func(ctx context.Context, owner string, repo string, repository *github.Repository)) *MockRepositoriesAdapter_Edit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *github.Repository
+		if args[3] != nil {
+			arg3 = args[3].(*github.Repository)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_Edit_Call) Return(repository *github.Repository, response *github.Response, err error) *MockRepositoriesAdapter_Edit_Call {
+	_c.Call.Return(repository, response, err)
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_Edit_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, repository *github.Repository) (*github.Repository, *github.Response, error)) *MockRepositoriesAdapter_Edit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockRepositoriesAdapter
+func (_mock *MockRepositoriesAdapter) Get(ctx context.Context, owner string, repo string) (*github.Repository, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *github.Repository
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*github.Repository, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *github.Repository); ok {
+		r0 = returnFunc(ctx, owner, repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Repository)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, owner, repo)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepositoriesAdapter_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRepositoriesAdapter_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+func (_e *MockRepositoriesAdapter_Expecter) Get(ctx interface{}, owner interface{}, repo interface{}) *MockRepositoriesAdapter_Get_Call {
+	return &MockRepositoriesAdapter_Get_Call{Call: _e.mock.On("Get", ctx, owner, repo)}
+}
+
+func (_c *MockRepositoriesAdapter_Get_Call) Run(run func(ctx context.Context, owner string, repo string)) *MockRepositoriesAdapter_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_Get_Call) Return(repository *github.Repository, response *github.Response, err error) *MockRepositoriesAdapter_Get_Call {
+	_c.Call.Return(repository, response, err)
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_Get_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string) (*github.Repository, *github.Response, error)) *MockRepositoriesAdapter_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommitSHA1 provides a mock function for the type MockRepositoriesAdapter
+func (_mock *MockRepositoriesAdapter) GetCommitSHA1(ctx context.Context, owner string, repo string, ref string, lastSHA string) (string, *github.Response, error) {
+	ret := _mock.Called(ctx, owner, repo, ref, lastSHA)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommitSHA1")
+	}
+
+	var r0 string
+	var r1 *github.Response
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, *github.Response, error)); ok {
+		return returnFunc(ctx, owner, repo, ref, lastSHA)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = returnFunc(ctx, owner, repo, ref, lastSHA)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) *github.Response); ok {
+		r1 = returnFunc(ctx, owner, repo, ref, lastSHA)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string, string) error); ok {
+		r2 = returnFunc(ctx, owner, repo, ref, lastSHA)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepositoriesAdapter_GetCommitSHA1_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommitSHA1'
+type MockRepositoriesAdapter_GetCommitSHA1_Call struct {
+	*mock.Call
+}
+
+// GetCommitSHA1 is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - ref string
+//   - lastSHA string
+func (_e *MockRepositoriesAdapter_Expecter) GetCommitSHA1(ctx interface{}, owner interface{}, repo interface{}, ref interface{}, lastSHA interface{}) *MockRepositoriesAdapter_GetCommitSHA1_Call {
+	return &MockRepositoriesAdapter_GetCommitSHA1_Call{Call: _e.mock.On("GetCommitSHA1", ctx, owner, repo, ref, lastSHA)}
+}
+
+func (_c *MockRepositoriesAdapter_GetCommitSHA1_Call) Run(run func(ctx context.Context, owner string, repo string, ref string, lastSHA string)) *MockRepositoriesAdapter_GetCommitSHA1_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_GetCommitSHA1_Call) Return(s string, response *github.Response, err error) *MockRepositoriesAdapter_GetCommitSHA1_Call {
+	_c.Call.Return(s, response, err)
+	return _c
+}
+
+func (_c *MockRepositoriesAdapter_GetCommitSHA1_Call) RunAndReturn(run func(ctx context.Context, owner string, repo string, ref string, lastSHA string) (string, *github.Response, error)) *MockRepositoriesAdapter_GetCommitSHA1_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetContents provides a mock function for the type MockRepositoriesAdapter
 func (_mock *MockRepositoriesAdapter) GetContents(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
 	ret := _mock.Called(ctx, owner, repo, path, opts)
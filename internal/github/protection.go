@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"errors"
+
+	gh "github.com/google/go-github/v80/github"
+)
+
+// GetBranchProtection returns the protection of branch, nil when the branch is not protected.
+func (c *client) GetBranchProtection(ctx context.Context, repo, branch string) (*gh.Protection, error) {
+	protection, _, err := c.protection.GetBranchProtection(ctx, c.org, repo, branch)
+	if errors.Is(err, gh.ErrBranchNotProtected) {
+		return nil, nil
+	}
+	return protection, err
+}
+
+// UpdateBranchProtection replaces the protection of branch.
+func (c *client) UpdateBranchProtection(ctx context.Context, repo, branch string, protection *gh.ProtectionRequest) error {
+	_, _, err := c.protection.UpdateBranchProtection(ctx, c.org, repo, branch, protection)
+	return err
+}
+
+// GetRulesetByName returns the ruleset of repo called name with its rules, nil
+// when there is none. Rulesets inherited from the organization are ignored.
+func (c *client) GetRulesetByName(ctx context.Context, repo, name string) (*gh.RepositoryRuleset, error) {
+	opts := &gh.RepositoryListRulesetsOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+
+	for {
+		rulesets, resp, err := c.rulesets.GetAllRulesets(ctx, c.org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ruleset := range rulesets {
+			if ruleset.Name == name {
+				// The listing does not include the rules
+				full, _, err := c.rulesets.GetRuleset(ctx, c.org, repo, ruleset.GetID(), false)
+				return full, err
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (c *client) CreateRuleset(ctx context.Context, repo string, ruleset gh.RepositoryRuleset) error {
+	_, _, err := c.rulesets.CreateRuleset(ctx, c.org, repo, ruleset)
+	return err
+}
+
+// UpdateRuleset replaces the ruleset with the ID of ruleset. A ruleset without
+// bypass actors clears the existing ones.
+func (c *client) UpdateRuleset(ctx context.Context, repo string, ruleset gh.RepositoryRuleset) error {
+	var err error
+	if len(ruleset.BypassActors) == 0 {
+		_, _, err = c.rulesets.UpdateRulesetNoBypassActor(ctx, c.org, repo, ruleset.GetID(), ruleset)
+	} else {
+		_, _, err = c.rulesets.UpdateRuleset(ctx, c.org, repo, ruleset.GetID(), ruleset)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+func TestGetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	protectionSvc := github.NewMockBranchProtectionAdapter(t)
+
+	protectionSvc.
+		EXPECT().
+		GetBranchProtection(mock.Anything, "org-name", "repo-1", "main").
+		Once().
+		Return(&gh.Protection{EnforceAdmins: &gh.AdminEnforcement{Enabled: true}}, &gh.Response{}, nil)
+
+	c := &client{protection: protectionSvc, org: "org-name"}
+
+	protection, err := c.GetBranchProtection(ctx, "repo-1", "main")
+
+	assert.NoError(t, err)
+	assert.True(t, protection.GetEnforceAdmins().Enabled)
+}
+
+func TestGetBranchProtection_NotProtected(t *testing.T) {
+	ctx := context.Background()
+	protectionSvc := github.NewMockBranchProtectionAdapter(t)
+
+	protectionSvc.
+		EXPECT().
+		GetBranchProtection(mock.Anything, "org-name", "repo-1", "main").
+		Once().
+		Return(nil, &gh.Response{}, gh.ErrBranchNotProtected)
+
+	c := &client{protection: protectionSvc, org: "org-name"}
+
+	protection, err := c.GetBranchProtection(ctx, "repo-1", "main")
+
+	assert.NoError(t, err)
+	assert.Nil(t, protection)
+}
+
+func TestUpdateBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	protectionSvc := github.NewMockBranchProtectionAdapter(t)
+	request := &gh.ProtectionRequest{EnforceAdmins: true}
+
+	protectionSvc.
+		EXPECT().
+		UpdateBranchProtection(mock.Anything, "org-name", "repo-1", "main", request).
+		Once().
+		Return(&gh.Protection{}, &gh.Response{}, nil)
+
+	c := &client{protection: protectionSvc, org: "org-name"}
+
+	err := c.UpdateBranchProtection(ctx, "repo-1", "main", request)
+
+	assert.NoError(t, err)
+}
+
+func TestGetRulesetByName(t *testing.T) {
+	ctx := context.Background()
+	rulesetsSvc := github.NewMockRulesetsAdapter(t)
+
+	rulesetsSvc.
+		EXPECT().
+		GetAllRulesets(mock.Anything, "org-name", "repo-1", mock.MatchedBy(func(o *gh.RepositoryListRulesetsOptions) bool {
+			return o.Page == 0
+		})).
+		Once().
+		Return([]*gh.RepositoryRuleset{{ID: gh.Ptr(int64(1)), Name: "release"}}, &gh.Response{NextPage: 2}, nil)
+	rulesetsSvc.
+		EXPECT().
+		GetAllRulesets(mock.Anything, "org-name", "repo-1", mock.MatchedBy(func(o *gh.RepositoryListRulesetsOptions) bool {
+			return o.Page == 2
+		})).
+		Once().
+		Return([]*gh.RepositoryRuleset{{ID: gh.Ptr(int64(7)), Name: "default-branch"}}, &gh.Response{}, nil)
+	rulesetsSvc.
+		EXPECT().
+		GetRuleset(mock.Anything, "org-name", "repo-1", int64(7), false).
+		Once().
+		Return(&gh.RepositoryRuleset{ID: gh.Ptr(int64(7)), Name: "default-branch", Rules: &gh.RepositoryRulesetRules{}}, &gh.Response{}, nil)
+
+	c := &client{rulesets: rulesetsSvc, org: "org-name"}
+
+	ruleset, err := c.GetRulesetByName(ctx, "repo-1", "default-branch")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), ruleset.GetID())
+	assert.NotNil(t, ruleset.Rules)
+}
+
+func TestGetRulesetByName_Missing(t *testing.T) {
+	ctx := context.Background()
+	rulesetsSvc := github.NewMockRulesetsAdapter(t)
+
+	rulesetsSvc.
+		EXPECT().
+		GetAllRulesets(mock.Anything, "org-name", "repo-1", mock.Anything).
+		Once().
+		Return(nil, &gh.Response{}, nil)
+
+	c := &client{rulesets: rulesetsSvc, org: "org-name"}
+
+	ruleset, err := c.GetRulesetByName(ctx, "repo-1", "default-branch")
+
+	assert.NoError(t, err)
+	assert.Nil(t, ruleset)
+}
+
+func TestUpdateRuleset(t *testing.T) {
+	ctx := context.Background()
+	rulesetsSvc := github.NewMockRulesetsAdapter(t)
+
+	withBypass := gh.RepositoryRuleset{ID: gh.Ptr(int64(7)), Name: "default-branch", BypassActors: []*gh.BypassActor{{ActorID: gh.Ptr(int64(5))}}}
+	withoutBypass := gh.RepositoryRuleset{ID: gh.Ptr(int64(7)), Name: "default-branch"}
+
+	rulesetsSvc.EXPECT().UpdateRuleset(mock.Anything, "org-name", "repo-1", int64(7), withBypass).Once().Return(&withBypass, &gh.Response{}, nil)
+	// Bypass actors are only cleared by the dedicated endpoint
+	rulesetsSvc.EXPECT().UpdateRulesetNoBypassActor(mock.Anything, "org-name", "repo-1", int64(7), withoutBypass).Once().Return(&withoutBypass, &gh.Response{}, nil)
+
+	c := &client{rulesets: rulesetsSvc, org: "org-name"}
+
+	assert.NoError(t, c.UpdateRuleset(ctx, "repo-1", withBypass))
+	assert.NoError(t, c.UpdateRuleset(ctx, "repo-1", withoutBypass))
+}
@@ -11,12 +11,13 @@ func (c *client) ListPullRequests(ctx context.Context, repo string, opts *gh.Pul
 	return prs, err
 }
 
-func (c *client) CreatePullRequest(ctx context.Context, repo, title, body, head, base string) (*gh.PullRequest, error) {
+func (c *client) CreatePullRequest(ctx context.Context, repo, title, body, head, base string, draft bool) (*gh.PullRequest, error) {
 	pr := &gh.NewPullRequest{
 		Title: gh.Ptr(title),
 		Body:  gh.Ptr(body),
 		Head:  gh.Ptr(head),
 		Base:  gh.Ptr(base),
+		Draft: gh.Ptr(draft),
 	}
 	created, _, err := c.pullRequests.Create(ctx, c.org, repo, pr)
 	return created, err
@@ -36,3 +37,18 @@ func (c *client) FindPullRequestByBranch(ctx context.Context, repo, branchName s
 	}
 	return nil, nil
 }
+
+func (c *client) RequestReviewers(ctx context.Context, repo string, number int, reviewers, teamReviewers []string) error {
+	req := gh.ReviewersRequest{
+		Reviewers:     reviewers,
+		TeamReviewers: teamReviewers,
+	}
+	_, _, err := c.pullRequests.RequestReviewers(ctx, c.org, repo, number, req)
+	return err
+}
+
+// EditPullRequestBody replaces the description of a pull request.
+func (c *client) EditPullRequestBody(ctx context.Context, repo string, number int, body string) error {
+	_, _, err := c.pullRequests.Edit(ctx, c.org, repo, number, &gh.PullRequest{Body: gh.Ptr(body)})
+	return err
+}
@@ -70,7 +70,8 @@ func TestCreatePullRequest_Success(t *testing.T) {
 				return pr.GetTitle() == "Test PR" &&
 					pr.GetBody() == "PR body" &&
 					pr.GetHead() == "feature-branch" &&
-					pr.GetBase() == "main"
+					pr.GetBase() == "main" &&
+					!pr.GetDraft()
 			}),
 		).
 		Once().
@@ -86,7 +87,7 @@ func TestCreatePullRequest_Success(t *testing.T) {
 
 	c := &client{pullRequests: prSvc, org: "org-name"}
 
-	pr, err := c.CreatePullRequest(ctx, "repo-name", "Test PR", "PR body", "feature-branch", "main")
+	pr, err := c.CreatePullRequest(ctx, "repo-name", "Test PR", "PR body", "feature-branch", "main", false)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, pr)
@@ -106,13 +107,35 @@ func TestCreatePullRequest_Error(t *testing.T) {
 
 	c := &client{pullRequests: prSvc, org: "org-name"}
 
-	pr, err := c.CreatePullRequest(ctx, "repo-name", "Test PR", "PR body", "feature-branch", "main")
+	pr, err := c.CreatePullRequest(ctx, "repo-name", "Test PR", "PR body", "feature-branch", "main", false)
 
 	assert.Error(t, err)
 	assert.Nil(t, pr)
 	assert.Contains(t, err.Error(), "PR already exists")
 }
 
+func TestCreatePullRequest_Draft(t *testing.T) {
+	ctx := context.Background()
+	prSvc := github.NewMockPullRequestsAdapter(t)
+
+	prSvc.
+		EXPECT().
+		Create(mock.Anything, "org-name", "repo-name",
+			mock.MatchedBy(func(pr *gh.NewPullRequest) bool {
+				return pr.GetDraft()
+			}),
+		).
+		Once().
+		Return(&gh.PullRequest{Number: gh.Ptr(43), Draft: gh.Ptr(true)}, &gh.Response{}, nil)
+
+	c := &client{pullRequests: prSvc, org: "org-name"}
+
+	pr, err := c.CreatePullRequest(ctx, "repo-name", "Test PR", "PR body", "feature-branch", "main", true)
+
+	assert.NoError(t, err)
+	assert.True(t, pr.GetDraft())
+}
+
 func TestFindPullRequestByBranch_Found(t *testing.T) {
 	ctx := context.Background()
 	prSvc := github.NewMockPullRequestsAdapter(t)
@@ -177,3 +200,75 @@ func TestFindPullRequestByBranch_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, pr)
 }
+
+func TestRequestReviewers_Success(t *testing.T) {
+	ctx := context.Background()
+	prSvc := github.NewMockPullRequestsAdapter(t)
+
+	prSvc.
+		EXPECT().
+		RequestReviewers(mock.Anything, "org-name", "repo-name", 42, gh.ReviewersRequest{
+			Reviewers:     []string{"alice"},
+			TeamReviewers: []string{"platform"},
+		}).
+		Once().
+		Return(&gh.PullRequest{Number: gh.Ptr(42)}, &gh.Response{}, nil)
+
+	c := &client{pullRequests: prSvc, org: "org-name"}
+
+	err := c.RequestReviewers(ctx, "repo-name", 42, []string{"alice"}, []string{"platform"})
+
+	assert.NoError(t, err)
+}
+
+func TestRequestReviewers_Error(t *testing.T) {
+	ctx := context.Background()
+	prSvc := github.NewMockPullRequestsAdapter(t)
+
+	prSvc.
+		EXPECT().
+		RequestReviewers(mock.Anything, "org-name", "repo-name", 42, mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("reviewer is not a collaborator"))
+
+	c := &client{pullRequests: prSvc, org: "org-name"}
+
+	err := c.RequestReviewers(ctx, "repo-name", 42, []string{"alice"}, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reviewer is not a collaborator")
+}
+
+func TestEditPullRequestBody_Success(t *testing.T) {
+	ctx := context.Background()
+	prSvc := github.NewMockPullRequestsAdapter(t)
+
+	prSvc.
+		EXPECT().
+		Edit(mock.Anything, "org-name", "repo-name", 42, &gh.PullRequest{Body: gh.Ptr("new body")}).
+		Once().
+		Return(&gh.PullRequest{Number: gh.Ptr(42)}, &gh.Response{}, nil)
+
+	c := &client{pullRequests: prSvc, org: "org-name"}
+
+	err := c.EditPullRequestBody(ctx, "repo-name", 42, "new body")
+
+	assert.NoError(t, err)
+}
+
+func TestEditPullRequestBody_Error(t *testing.T) {
+	ctx := context.Background()
+	prSvc := github.NewMockPullRequestsAdapter(t)
+
+	prSvc.
+		EXPECT().
+		Edit(mock.Anything, "org-name", "repo-name", 42, mock.Anything).
+		Once().
+		Return(nil, nil, errors.New("pull request is closed"))
+
+	c := &client{pullRequests: prSvc, org: "org-name"}
+
+	err := c.EditPullRequestBody(ctx, "repo-name", 42, "new body")
+
+	assert.EqualError(t, err, "pull request is closed")
+}
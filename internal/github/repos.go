@@ -30,3 +30,23 @@ func (c *client) ListAllRepos(ctx context.Context) ([]*gh.Repository, error) {
 
 	return allRepos, nil
 }
+
+// ResolveRef returns the SHA of the commit a branch or tag points to, in any
+// repository: owner is not necessarily the organization of the client.
+func (c *client) ResolveRef(ctx context.Context, owner, repo, ref string) (string, error) {
+	sha, _, err := c.repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+	return sha, err
+}
+
+// GetRepository returns repo with its settings, which the organization
+// listing does not include.
+func (c *client) GetRepository(ctx context.Context, repo string) (*gh.Repository, error) {
+	repository, _, err := c.repositories.Get(ctx, c.org, repo)
+	return repository, err
+}
+
+// EditRepository changes the settings set in settings, the others are left as is.
+func (c *client) EditRepository(ctx context.Context, repo string, settings *gh.Repository) (*gh.Repository, error) {
+	repository, _, err := c.repositories.Edit(ctx, c.org, repo, settings)
+	return repository, err
+}
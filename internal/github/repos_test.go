@@ -94,3 +94,58 @@ func TestListAllRepos_ContextTimeout(t *testing.T) {
 	assert.Len(t, repos, 0)
 	assert.Less(t, elapsed, 50*time.Millisecond)
 }
+
+func TestResolveRef(t *testing.T) {
+	ctx := context.Background()
+	reposSvc := github.NewMockRepositoriesAdapter(t)
+
+	reposSvc.
+		EXPECT().
+		GetCommitSHA1(mock.Anything, "actions", "checkout", "v4", "").
+		Once().
+		Return("11bd71901bbe5b1630ceea73d27597364c9af683", &gh.Response{}, nil)
+
+	c := &client{repositories: reposSvc, org: "org-name"}
+
+	sha, err := c.ResolveRef(ctx, "actions", "checkout", "v4")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "11bd71901bbe5b1630ceea73d27597364c9af683", sha)
+}
+
+func TestGetRepository(t *testing.T) {
+	ctx := context.Background()
+	reposSvc := github.NewMockRepositoriesAdapter(t)
+
+	reposSvc.
+		EXPECT().
+		Get(mock.Anything, "org-name", "repo-1").
+		Once().
+		Return(&gh.Repository{Name: gh.Ptr("repo-1"), HasWiki: gh.Ptr(true)}, &gh.Response{}, nil)
+
+	c := &client{repositories: reposSvc, org: "org-name"}
+
+	repo, err := c.GetRepository(ctx, "repo-1")
+
+	assert.NoError(t, err)
+	assert.True(t, repo.GetHasWiki())
+}
+
+func TestEditRepository(t *testing.T) {
+	ctx := context.Background()
+	reposSvc := github.NewMockRepositoriesAdapter(t)
+
+	settings := &gh.Repository{HasWiki: gh.Ptr(false)}
+	reposSvc.
+		EXPECT().
+		Edit(mock.Anything, "org-name", "repo-1", settings).
+		Once().
+		Return(&gh.Repository{Name: gh.Ptr("repo-1"), HasWiki: gh.Ptr(false)}, &gh.Response{}, nil)
+
+	c := &client{repositories: reposSvc, org: "org-name"}
+
+	repo, err := c.EditRepository(ctx, "repo-1", settings)
+
+	assert.NoError(t, err)
+	assert.False(t, repo.GetHasWiki())
+}
@@ -0,0 +1,280 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/tracker-tv/github-policy-bots/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+// tracingTransport traces every API call as a child of the span in the request context.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.StartClient(req.Context(), req.Method+" "+endpointFor(req.URL.Path),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLPath(req.URL.Path),
+	)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	span.End()
+	return resp, nil
+}
+
+// tracedClient wraps every Client method in a span, so the API calls it
+// makes are grouped under the operation that needed them.
+type tracedClient struct {
+	next Client
+	org  string
+}
+
+func (c *tracedClient) start(ctx context.Context, method, repo string, attrs ...attribute.KeyValue) (context.Context, func(error)) {
+	if repo != "" {
+		attrs = append(attrs, tracing.Repo(c.org+"/"+repo))
+	}
+	ctx, span := tracing.Start(ctx, "github."+method, attrs...)
+	return ctx, func(err error) { tracing.End(span, err) }
+}
+
+func (c *tracedClient) ListAllRepos(ctx context.Context) (repos []*gh.Repository, err error) {
+	ctx, end := c.start(ctx, "ListAllRepos", "")
+	defer func() { end(err) }()
+	return c.next.ListAllRepos(ctx)
+}
+
+func (c *tracedClient) GetContentsRaw(ctx context.Context, repo, path string) (file *gh.RepositoryContent, dir []*gh.RepositoryContent, resp *gh.Response, err error) {
+	ctx, end := c.start(ctx, "GetContentsRaw", repo, tracing.Path(path))
+	defer func() { end(err) }()
+	return c.next.GetContentsRaw(ctx, repo, path)
+}
+
+func (c *tracedClient) GetTree(ctx context.Context, repo, sha string, recursive bool) (tree *gh.Tree, resp *gh.Response, err error) {
+	ctx, end := c.start(ctx, "GetTree", repo)
+	defer func() { end(err) }()
+	return c.next.GetTree(ctx, repo, sha, recursive)
+}
+
+func (c *tracedClient) ChangedFiles(ctx context.Context, repo, base, head string) (files []string, err error) {
+	ctx, end := c.start(ctx, "ChangedFiles", repo)
+	defer func() { end(err) }()
+	return c.next.ChangedFiles(ctx, repo, base, head)
+}
+
+func (c *tracedClient) ResolveRef(ctx context.Context, owner, repo, ref string) (sha string, err error) {
+	ctx, end := c.start(ctx, "ResolveRef", "", tracing.Repo(owner+"/"+repo))
+	defer func() { end(err) }()
+	return c.next.ResolveRef(ctx, owner, repo, ref)
+}
+
+func (c *tracedClient) GetRepository(ctx context.Context, repo string) (repository *gh.Repository, err error) {
+	ctx, end := c.start(ctx, "GetRepository", repo)
+	defer func() { end(err) }()
+	return c.next.GetRepository(ctx, repo)
+}
+
+func (c *tracedClient) EditRepository(ctx context.Context, repo string, settings *gh.Repository) (repository *gh.Repository, err error) {
+	ctx, end := c.start(ctx, "EditRepository", repo)
+	defer func() { end(err) }()
+	return c.next.EditRepository(ctx, repo, settings)
+}
+
+func (c *tracedClient) GetBranch(ctx context.Context, repo, branch string) (ref *gh.Reference, err error) {
+	ctx, end := c.start(ctx, "GetBranch", repo)
+	defer func() { end(err) }()
+	return c.next.GetBranch(ctx, repo, branch)
+}
+
+func (c *tracedClient) CreateBranch(ctx context.Context, repo, branchName, baseSHA string) (err error) {
+	ctx, end := c.start(ctx, "CreateBranch", repo)
+	defer func() { end(err) }()
+	return c.next.CreateBranch(ctx, repo, branchName, baseSHA)
+}
+
+func (c *tracedClient) GetBranchProtection(ctx context.Context, repo, branch string) (protection *gh.Protection, err error) {
+	ctx, end := c.start(ctx, "GetBranchProtection", repo)
+	defer func() { end(err) }()
+	return c.next.GetBranchProtection(ctx, repo, branch)
+}
+
+func (c *tracedClient) UpdateBranchProtection(ctx context.Context, repo, branch string, protection *gh.ProtectionRequest) (err error) {
+	ctx, end := c.start(ctx, "UpdateBranchProtection", repo)
+	defer func() { end(err) }()
+	return c.next.UpdateBranchProtection(ctx, repo, branch, protection)
+}
+
+func (c *tracedClient) GetRulesetByName(ctx context.Context, repo, name string) (ruleset *gh.RepositoryRuleset, err error) {
+	ctx, end := c.start(ctx, "GetRulesetByName", repo)
+	defer func() { end(err) }()
+	return c.next.GetRulesetByName(ctx, repo, name)
+}
+
+func (c *tracedClient) CreateRuleset(ctx context.Context, repo string, ruleset gh.RepositoryRuleset) (err error) {
+	ctx, end := c.start(ctx, "CreateRuleset", repo)
+	defer func() { end(err) }()
+	return c.next.CreateRuleset(ctx, repo, ruleset)
+}
+
+func (c *tracedClient) UpdateRuleset(ctx context.Context, repo string, ruleset gh.RepositoryRuleset) (err error) {
+	ctx, end := c.start(ctx, "UpdateRuleset", repo)
+	defer func() { end(err) }()
+	return c.next.UpdateRuleset(ctx, repo, ruleset)
+}
+
+func (c *tracedClient) GetFileContent(ctx context.Context, repo, path, ref string) (content string, sha string, err error) {
+	ctx, end := c.start(ctx, "GetFileContent", repo, tracing.Path(path))
+	defer func() { end(err) }()
+	return c.next.GetFileContent(ctx, repo, path, ref)
+}
+
+func (c *tracedClient) CreateOrUpdateFile(ctx context.Context, repo, path, branch, message, content string, fileSHA *string) (err error) {
+	ctx, end := c.start(ctx, "CreateOrUpdateFile", repo, tracing.Path(path))
+	defer func() { end(err) }()
+	return c.next.CreateOrUpdateFile(ctx, repo, path, branch, message, content, fileSHA)
+}
+
+func (c *tracedClient) DeleteFile(ctx context.Context, repo, path, branch, message, fileSHA string) (err error) {
+	ctx, end := c.start(ctx, "DeleteFile", repo, tracing.Path(path))
+	defer func() { end(err) }()
+	return c.next.DeleteFile(ctx, repo, path, branch, message, fileSHA)
+}
+
+func (c *tracedClient) CommitFiles(ctx context.Context, repo, branch, message string, files map[string]string, deletions []string) (err error) {
+	ctx, end := c.start(ctx, "CommitFiles", repo)
+	defer func() { end(err) }()
+	return c.next.CommitFiles(ctx, repo, branch, message, files, deletions)
+}
+
+func (c *tracedClient) ListPullRequests(ctx context.Context, repo string, opts *gh.PullRequestListOptions) (prs []*gh.PullRequest, err error) {
+	ctx, end := c.start(ctx, "ListPullRequests", repo)
+	defer func() { end(err) }()
+	return c.next.ListPullRequests(ctx, repo, opts)
+}
+
+func (c *tracedClient) CreatePullRequest(ctx context.Context, repo, title, body, head, base string, draft bool) (pr *gh.PullRequest, err error) {
+	ctx, end := c.start(ctx, "CreatePullRequest", repo)
+	defer func() { end(err) }()
+	return c.next.CreatePullRequest(ctx, repo, title, body, head, base, draft)
+}
+
+func (c *tracedClient) FindPullRequestByBranch(ctx context.Context, repo, branchName string) (pr *gh.PullRequest, err error) {
+	ctx, end := c.start(ctx, "FindPullRequestByBranch", repo)
+	defer func() { end(err) }()
+	return c.next.FindPullRequestByBranch(ctx, repo, branchName)
+}
+
+func (c *tracedClient) RequestReviewers(ctx context.Context, repo string, number int, reviewers, teamReviewers []string) (err error) {
+	ctx, end := c.start(ctx, "RequestReviewers", repo)
+	defer func() { end(err) }()
+	return c.next.RequestReviewers(ctx, repo, number, reviewers, teamReviewers)
+}
+
+func (c *tracedClient) EditPullRequestBody(ctx context.Context, repo string, number int, body string) (err error) {
+	ctx, end := c.start(ctx, "EditPullRequestBody", repo)
+	defer func() { end(err) }()
+	return c.next.EditPullRequestBody(ctx, repo, number, body)
+}
+
+func (c *tracedClient) EnableAutoMerge(ctx context.Context, pullRequestID, mergeMethod string) (err error) {
+	ctx, end := c.start(ctx, "EnableAutoMerge", "", attribute.String("policybot.pull_request_id", pullRequestID))
+	defer func() { end(err) }()
+	return c.next.EnableAutoMerge(ctx, pullRequestID, mergeMethod)
+}
+
+func (c *tracedClient) GetLabel(ctx context.Context, repo, name string) (label *gh.Label, resp *gh.Response, err error) {
+	ctx, end := c.start(ctx, "GetLabel", repo)
+	defer func() { end(err) }()
+	return c.next.GetLabel(ctx, repo, name)
+}
+
+func (c *tracedClient) CreateLabel(ctx context.Context, repo, name, color string) (label *gh.Label, err error) {
+	ctx, end := c.start(ctx, "CreateLabel", repo)
+	defer func() { end(err) }()
+	return c.next.CreateLabel(ctx, repo, name, color)
+}
+
+func (c *tracedClient) AddLabelsToIssue(ctx context.Context, repo string, number int, labels []string) (err error) {
+	ctx, end := c.start(ctx, "AddLabelsToIssue", repo)
+	defer func() { end(err) }()
+	return c.next.AddLabelsToIssue(ctx, repo, number, labels)
+}
+
+func (c *tracedClient) AddAssignees(ctx context.Context, repo string, number int, assignees []string) (err error) {
+	ctx, end := c.start(ctx, "AddAssignees", repo)
+	defer func() { end(err) }()
+	return c.next.AddAssignees(ctx, repo, number, assignees)
+}
+
+func (c *tracedClient) CreateIssue(ctx context.Context, repo, title, body string, labels []string) (issue *gh.Issue, err error) {
+	ctx, end := c.start(ctx, "CreateIssue", repo)
+	defer func() { end(err) }()
+	return c.next.CreateIssue(ctx, repo, title, body, labels)
+}
+
+func (c *tracedClient) EditIssue(ctx context.Context, repo string, number int, title, body string) (issue *gh.Issue, err error) {
+	ctx, end := c.start(ctx, "EditIssue", repo)
+	defer func() { end(err) }()
+	return c.next.EditIssue(ctx, repo, number, title, body)
+}
+
+func (c *tracedClient) ListIssues(ctx context.Context, repo, creator string) (issues []*gh.Issue, err error) {
+	ctx, end := c.start(ctx, "ListIssues", repo)
+	defer func() { end(err) }()
+	return c.next.ListIssues(ctx, repo, creator)
+}
+
+func (c *tracedClient) PinIssue(ctx context.Context, issueID string) (err error) {
+	ctx, end := c.start(ctx, "PinIssue", "", attribute.String("policybot.issue_id", issueID))
+	defer func() { end(err) }()
+	return c.next.PinIssue(ctx, issueID)
+}
+
+func (c *tracedClient) ListIssueComments(ctx context.Context, repo string, number int) (comments []*gh.IssueComment, err error) {
+	ctx, end := c.start(ctx, "ListIssueComments", repo)
+	defer func() { end(err) }()
+	return c.next.ListIssueComments(ctx, repo, number)
+}
+
+func (c *tracedClient) CreateIssueComment(ctx context.Context, repo string, number int, body string) (err error) {
+	ctx, end := c.start(ctx, "CreateIssueComment", repo)
+	defer func() { end(err) }()
+	return c.next.CreateIssueComment(ctx, repo, number, body)
+}
+
+func (c *tracedClient) EditIssueComment(ctx context.Context, repo string, commentID int64, body string) (err error) {
+	ctx, end := c.start(ctx, "EditIssueComment", repo)
+	defer func() { end(err) }()
+	return c.next.EditIssueComment(ctx, repo, commentID, body)
+}
+
+func (c *tracedClient) DeleteIssueComment(ctx context.Context, repo string, commentID int64) (err error) {
+	ctx, end := c.start(ctx, "DeleteIssueComment", repo)
+	defer func() { end(err) }()
+	return c.next.DeleteIssueComment(ctx, repo, commentID)
+}
+
+func (c *tracedClient) UploadSARIF(ctx context.Context, repo, commitSHA, ref string, sarif []byte) (err error) {
+	ctx, end := c.start(ctx, "UploadSARIF", repo, attribute.Int("policybot.sarif_bytes", len(sarif)))
+	defer func() { end(err) }()
+	return c.next.UploadSARIF(ctx, repo, commitSHA, ref, sarif)
+}
+
+func (c *tracedClient) AuthenticatedUser(ctx context.Context) (login string, err error) {
+	ctx, end := c.start(ctx, "AuthenticatedUser", "")
+	defer func() { end(err) }()
+	return c.next.AuthenticatedUser(ctx)
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestTracedClient_RecordsSpan(t *testing.T) {
+	recorder := recordSpans(t)
+	next := github.NewMockClient(t)
+	c := &tracedClient{next: next, org: "org-name"}
+
+	next.EXPECT().
+		GetFileContent(mock.Anything, "repo-name", "Dockerfile", "HEAD").
+		Once().
+		Return("", "", errors.New("not found"))
+
+	_, _, err := c.GetFileContent(context.Background(), "repo-name", "Dockerfile", "HEAD")
+	assert.EqualError(t, err, "not found")
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "github.GetFileContent", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), tracing.Repo("org-name/repo-name"))
+	assert.Contains(t, spans[0].Attributes(), tracing.Path("Dockerfile"))
+}
+
+func TestTracingTransport_RoundTrip(t *testing.T) {
+	recorder := recordSpans(t)
+	transport := &tracingTransport{next: http.DefaultTransport}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "github.GetBranch")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/repos/org/repo/git/ref/heads/main", nil)
+	assert.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "GET /repos/{owner}/{repo}/git/ref/{ref}", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusNotFound))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
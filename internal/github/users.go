@@ -0,0 +1,14 @@
+package github
+
+import (
+	"context"
+)
+
+// AuthenticatedUser returns the login of the user the token belongs to.
+func (c *client) AuthenticatedUser(ctx context.Context) (string, error) {
+	user, _, err := c.users.Get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	github "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+func TestAuthenticatedUser_Success(t *testing.T) {
+	ctx := context.Background()
+	usersSvc := github.NewMockUsersAdapter(t)
+
+	usersSvc.
+		EXPECT().
+		Get(mock.Anything, "").
+		Once().
+		Return(&gh.User{Login: gh.Ptr("policy-bot")}, &gh.Response{}, nil)
+
+	c := &client{users: usersSvc, org: "org-name"}
+
+	login, err := c.AuthenticatedUser(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "policy-bot", login)
+}
+
+func TestAuthenticatedUser_Error(t *testing.T) {
+	ctx := context.Background()
+	usersSvc := github.NewMockUsersAdapter(t)
+
+	usersSvc.
+		EXPECT().
+		Get(mock.Anything, "").
+		Once().
+		Return(nil, nil, errors.New("401 Bad credentials"))
+
+	c := &client{users: usersSvc, org: "org-name"}
+
+	login, err := c.AuthenticatedUser(ctx)
+
+	assert.EqualError(t, err, "401 Bad credentials")
+	assert.Empty(t, login)
+}
@@ -0,0 +1,102 @@
+// Package logging configures the structured logger of the bot and carries it
+// through contexts, so every layer logs with the attributes of its caller.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Attribute keys shared by every log line.
+const (
+	RepoKey      = "repo"
+	PolicyKey    = "policy"
+	ActionKey    = "action"
+	PathKey      = "path"
+	RequestIDKey = "request_id"
+	ErrorKey     = "error"
+)
+
+func Repo(fullName string) slog.Attr { return slog.String(RepoKey, fullName) }
+func Policy(name string) slog.Attr   { return slog.String(PolicyKey, name) }
+func Action(action string) slog.Attr { return slog.String(ActionKey, action) }
+func Path(path string) slog.Attr     { return slog.String(PathKey, path) }
+func RequestID(id string) slog.Attr  { return slog.String(RequestIDKey, id) }
+
+func Error(err error) slog.Attr {
+	if err == nil {
+		return slog.String(ErrorKey, "")
+	}
+	return slog.String(ErrorKey, err.Error())
+}
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose value is never logged.
+var sensitiveKeys = []string{"token", "authorization", "password", "secret", "pat"}
+
+// tokenPattern matches GitHub tokens: classic and fine-grained PATs, OAuth, app and refresh tokens.
+var tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// New returns a logger writing to w in format ("text" or "json") from level
+// ("debug", "info", "warn" or "error") up. Tokens are redacted from every record.
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: redact}
+
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q", format)
+}
+
+func redact(_ []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	for _, sensitive := range sensitiveKeys {
+		if key == sensitive || strings.HasSuffix(key, "_"+sensitive) {
+			return slog.String(a.Key, redacted)
+		}
+	}
+
+	if a.Value.Kind() == slog.KindString {
+		if v := a.Value.String(); tokenPattern.MatchString(v) {
+			return slog.String(a.Key, tokenPattern.ReplaceAllString(v, redacted))
+		}
+	}
+	return a
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or slog.Default.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger has the given attributes.
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
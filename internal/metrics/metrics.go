@@ -0,0 +1,172 @@
+// Package metrics exposes Prometheus metrics on the compliance of repositories
+// and on the bot itself. Every method is safe to call on a nil *Metrics, so
+// callers do not need to check whether metrics are enabled.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const namespace = "policybot"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	reposScanned       prometheus.Counter
+	deviations         *prometheus.CounterVec
+	remediations       *prometheus.CounterVec
+	apiRequests        *prometheus.CounterVec
+	rateLimitRemaining prometheus.Gauge
+	runDuration        prometheus.Gauge
+	lastRun            prometheus.Gauge
+	results            *prometheus.GaugeVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		reposScanned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "repositories_scanned_total",
+			Help:      "Repositories evaluated against the policies.",
+		}),
+		deviations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deviations_total",
+			Help:      "Deviations found, by policy and action.",
+		}, []string{"policy", "action"}),
+		remediations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "remediations_total",
+			Help:      "Remediations attempted, by policy and outcome.",
+		}, []string{"policy", "outcome"}),
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "github_api_requests_total",
+			Help:      "GitHub API calls, by endpoint, method and status code.",
+		}, []string{"endpoint", "method", "status"}),
+		rateLimitRemaining: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "github_rate_limit_remaining",
+			Help:      "Requests remaining in the current GitHub rate-limit window.",
+		}),
+		runDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "run_duration_seconds",
+			Help:      "Duration of the last run.",
+		}),
+		lastRun: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_run_timestamp_seconds",
+			Help:      "Unix time the last run finished.",
+		}),
+		results: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "policy_results",
+			Help:      "Repositories per policy and status in the last run.",
+		}, []string{"policy", "status"}),
+	}
+
+	m.registry.MustRegister(
+		m.reposScanned, m.deviations, m.remediations, m.apiRequests,
+		m.rateLimitRemaining, m.runDuration, m.lastRun, m.results,
+	)
+	return m
+}
+
+func (m *Metrics) RepositoryScanned() {
+	if m == nil {
+		return
+	}
+	m.reposScanned.Inc()
+}
+
+func (m *Metrics) DeviationFound(policy, action string) {
+	if m == nil {
+		return
+	}
+	m.deviations.WithLabelValues(policy, action).Inc()
+}
+
+// Remediated records the outcome of a remediation: "created", "updated", "skipped" or "error".
+func (m *Metrics) Remediated(policy, outcome string) {
+	if m == nil {
+		return
+	}
+	m.remediations.WithLabelValues(policy, outcome).Inc()
+}
+
+// APIRequest records a GitHub API call. endpoint must be a path template,
+// e.g. /repos/{owner}/{repo}/pulls, to keep the label cardinality low.
+func (m *Metrics) APIRequest(endpoint, method string, status int) {
+	if m == nil {
+		return
+	}
+	m.apiRequests.WithLabelValues(endpoint, method, strconv.Itoa(status)).Inc()
+}
+
+func (m *Metrics) RateLimitRemaining(remaining int) {
+	if m == nil {
+		return
+	}
+	m.rateLimitRemaining.Set(float64(remaining))
+}
+
+// RunFinished records the duration of a run and the number of repositories
+// per policy and status it found, replacing those of the previous run.
+func (m *Metrics) RunFinished(duration time.Duration, results map[string]map[string]int) {
+	if m == nil {
+		return
+	}
+	m.runDuration.Set(duration.Seconds())
+	m.lastRun.SetToCurrentTime()
+
+	m.results.Reset()
+	for policy, statuses := range results {
+		for status, count := range statuses {
+			m.results.WithLabelValues(policy, status).Set(float64(count))
+		}
+	}
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Serve exposes the metrics on addr under /metrics until ctx is done.
+func (m *Metrics) Serve(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Push sends the metrics to a Pushgateway-compatible endpoint, for one-shot runs.
+func (m *Metrics) Push(url string) error {
+	return push.New(url, "policy_bot").Gatherer(m.registry).Push()
+}
+
+// WriteTextfile writes the metrics for the node exporter textfile collector.
+func (m *Metrics) WriteTextfile(path string) error {
+	return prometheus.WriteToTextfile(path, m.registry)
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.RepositoryScanned()
+	m.RepositoryScanned()
+	m.DeviationFound("go", "update")
+	m.Remediated("go", "created")
+	m.APIRequest("/repos/{owner}/{repo}/pulls", "GET", 200)
+	m.RateLimitRemaining(4321)
+	m.RunFinished(90*time.Second, map[string]map[string]int{"go": {"compliant": 3, "remediated": 1}})
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "policybot_repositories_scanned_total 2")
+	assert.Contains(t, out, `policybot_deviations_total{action="update",policy="go"} 1`)
+	assert.Contains(t, out, `policybot_remediations_total{outcome="created",policy="go"} 1`)
+	assert.Contains(t, out, `policybot_github_api_requests_total{endpoint="/repos/{owner}/{repo}/pulls",method="GET",status="200"} 1`)
+	assert.Contains(t, out, "policybot_github_rate_limit_remaining 4321")
+	assert.Contains(t, out, "policybot_run_duration_seconds 90")
+	assert.Contains(t, out, `policybot_policy_results{policy="go",status="compliant"} 3`)
+	assert.Contains(t, out, `policybot_policy_results{policy="go",status="remediated"} 1`)
+}
+
+func TestMetrics_RunFinishedReplacesResults(t *testing.T) {
+	m := New()
+	m.RunFinished(time.Second, map[string]map[string]int{"go": {"drifted": 1}})
+	m.RunFinished(time.Second, map[string]map[string]int{"go": {"compliant": 1}})
+
+	out := scrape(t, m)
+	assert.NotContains(t, out, `status="drifted"`)
+	assert.Contains(t, out, `policybot_policy_results{policy="go",status="compliant"} 1`)
+}
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.RepositoryScanned()
+		m.DeviationFound("go", "update")
+		m.Remediated("go", "created")
+		m.APIRequest("/user", "GET", 200)
+		m.RateLimitRemaining(1)
+		m.RunFinished(time.Second, nil)
+	})
+}
+
+func TestMetrics_WriteTextfile(t *testing.T) {
+	m := New()
+	m.RepositoryScanned()
+	path := filepath.Join(t.TempDir(), "policybot.prom")
+
+	assert.NoError(t, m.WriteTextfile(path))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "policybot_repositories_scanned_total 1")
+}
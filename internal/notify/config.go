@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SinkConfig configures one notifier. Secrets are read from the environment
+// variables named by the *_env fields rather than stored in the file.
+type SinkConfig struct {
+	Type   string   `yaml:"type"` // slack, webhook or smtp
+	Only   Only     `yaml:"only"`
+	Topics []string `yaml:"topics"`
+
+	Timeout time.Duration `yaml:"timeout"` // DefaultTimeout when not set
+
+	// slack and webhook
+	URL    string `yaml:"url"`
+	URLEnv string `yaml:"url_env"`
+
+	// webhook
+	SecretEnv string `yaml:"secret_env"`
+
+	// smtp
+	Addr        string   `yaml:"addr"` // host:port
+	From        string   `yaml:"from"`
+	To          []string `yaml:"to"`
+	Username    string   `yaml:"username"`
+	PasswordEnv string   `yaml:"password_env"`
+}
+
+type fileConfig struct {
+	Notifiers []SinkConfig `yaml:"notifiers"`
+}
+
+// Load builds the notifiers described by data, resolving secrets with getenv.
+func Load(data []byte, getenv func(string) string) ([]Notifier, error) {
+	var cfg fileConfig
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing notifiers: %w", err)
+	}
+
+	var notifiers []Notifier
+	var errs []error
+	for i, sink := range cfg.Notifiers {
+		n, err := sink.build(getenv)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notifiers[%d]: %w", i, err))
+			continue
+		}
+		notifiers = append(notifiers, Filtered(n, Filter{Only: sink.Only, Topics: sink.Topics}))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return notifiers, nil
+}
+
+func (c SinkConfig) build(getenv func(string) string) (Notifier, error) {
+	switch c.Only {
+	case "", OnlyChanges, OnlyAll, OnlyErrors, OnlyNewPRs:
+	default:
+		return nil, fmt.Errorf("unknown filter %q", c.Only)
+	}
+
+	url := c.URL
+	if c.URLEnv != "" {
+		url = getenv(c.URLEnv)
+	}
+
+	timeout := c.Timeout
+	if timeout < 0 {
+		return nil, fmt.Errorf("negative timeout %s", timeout)
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	switch c.Type {
+	case "slack":
+		if url == "" {
+			return nil, errors.New("slack requires url or url_env")
+		}
+		return NewSlack(url, timeout), nil
+	case "webhook":
+		if url == "" {
+			return nil, errors.New("webhook requires url or url_env")
+		}
+		var secret []byte
+		if c.SecretEnv != "" {
+			// An unset secret would silently send unsigned events
+			secret = []byte(getenv(c.SecretEnv))
+			if len(secret) == 0 {
+				return nil, fmt.Errorf("webhook secret_env %s is empty", c.SecretEnv)
+			}
+		}
+		return NewWebhook(url, secret, timeout), nil
+	case "smtp":
+		if c.Addr == "" || c.From == "" || len(c.To) == 0 {
+			return nil, errors.New("smtp requires addr, from and to")
+		}
+		var auth smtp.Auth
+		if c.Username != "" {
+			host, _, err := net.SplitHostPort(c.Addr)
+			if err != nil {
+				return nil, fmt.Errorf("smtp addr: %w", err)
+			}
+			auth = smtp.PlainAuth("", c.Username, getenv(c.PasswordEnv), host)
+		}
+		return NewSMTP(c.Addr, c.From, c.To, auth, timeout), nil
+	}
+	return nil, fmt.Errorf("unknown notifier type %q", c.Type)
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	env := map[string]string{
+		"SLACK_URL":      "https://hooks.slack.com/services/T/B/X",
+		"WEBHOOK_SECRET": "s3cret",
+		"SMTP_PASSWORD":  "hunter2",
+	}
+	data := []byte(`
+notifiers:
+  - type: slack
+    url_env: SLACK_URL
+    only: errors
+  - type: slack
+    url: https://hooks.slack.com/services/T/B/payments
+    topics: [team-payments]
+  - type: webhook
+    url: https://audit.example.com/policy-bot
+    secret_env: WEBHOOK_SECRET
+    only: all
+    timeout: 3s
+  - type: smtp
+    addr: mail.example.com:587
+    from: bot@example.com
+    to: [platform@example.com]
+    username: bot
+    password_env: SMTP_PASSWORD
+`)
+
+	notifiers, err := Load(data, func(key string) string { return env[key] })
+	assert.NoError(t, err)
+	assert.Len(t, notifiers, 4)
+
+	errorsOnly := notifiers[0].(*filtered)
+	assert.Equal(t, Filter{Only: OnlyErrors}, errorsOnly.filter)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", errorsOnly.next.(*slack).url)
+	assert.Equal(t, DefaultTimeout, errorsOnly.next.(*slack).client.Timeout)
+
+	payments := notifiers[1].(*filtered)
+	assert.Equal(t, []string{"team-payments"}, payments.filter.Topics)
+
+	audit := notifiers[2].(*filtered).next.(*webhook)
+	assert.Equal(t, []byte("s3cret"), audit.secret)
+	assert.Equal(t, 3*time.Second, audit.client.Timeout)
+
+	mail := notifiers[3].(*filtered).next.(*email)
+	assert.Equal(t, "mail.example.com:587", mail.addr)
+	assert.NotNil(t, mail.auth)
+}
+
+func TestLoad_Empty(t *testing.T) {
+	notifiers, err := Load(nil, func(string) string { return "" })
+
+	assert.NoError(t, err)
+	assert.Empty(t, notifiers)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unknown field", "notifiers:\n  - type: slack\n    channel: '#ops'\n", "parsing notifiers"},
+		{"unknown type", "notifiers:\n  - type: teams\n    url: https://example.com\n", `notifiers[0]: unknown notifier type "teams"`},
+		{"unknown filter", "notifiers:\n  - type: slack\n    url: https://example.com\n    only: warnings\n", `notifiers[0]: unknown filter "warnings"`},
+		{"unset url", "notifiers:\n  - type: webhook\n    url_env: MISSING\n", "notifiers[0]: webhook requires url or url_env"},
+		{"unset secret", "notifiers:\n  - type: webhook\n    url: https://example.com\n    secret_env: MISSING\n", "notifiers[0]: webhook secret_env MISSING is empty"},
+		{"negative timeout", "notifiers:\n  - type: slack\n    url: https://example.com\n    timeout: -1s\n", "notifiers[0]: negative timeout -1s"},
+		{"incomplete smtp", "notifiers:\n  - type: smtp\n    addr: mail.example.com:25\n", "notifiers[0]: smtp requires addr, from and to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load([]byte(tt.data), func(string) string { return "" })
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// describe returns a one-line description of a remediation.
+func describe(r service.RemediationResult) string {
+	repo, policy := r.Drift.Repository.FullName, r.Drift.Policy.Name
+
+	switch {
+	case r.Error != nil:
+		return fmt.Sprintf("%s: remediating %s failed: %v", repo, policy, r.Error)
+	case r.Drift.Action == models.PolicyActionInvalidConfig:
+		return fmt.Sprintf("%s: invalid %s: %s", repo, r.Drift.TargetPath, r.Drift.Reason)
+	case r.Drift.Action == models.PolicyActionUnpinned:
+		return fmt.Sprintf("%s: %s uses unpinned actions: %s", repo, r.Drift.TargetPath, strings.Join(r.Drift.UnpinnedActions, ", "))
+	case r.Drift.Action == models.PolicyActionExempt:
+		return fmt.Sprintf("%s: %s deviation exempt until %s", repo, policy, r.Drift.Exemption.Expires.Format(time.DateOnly))
+	case r.Drift.Protection != nil && r.Action == "updated":
+		return fmt.Sprintf("%s: applied %s to %s: %s", repo, policy, r.Drift.Protection.Branch, service.DescribeSettings(r.Drift.Settings))
+	case r.Drift.Protection != nil:
+		return fmt.Sprintf("%s: protection of %s differs from %s: %s", repo, r.Drift.Protection.Branch, policy, service.DescribeSettings(r.Drift.Settings))
+	case len(r.Drift.Settings) > 0 && r.Action == "updated":
+		return fmt.Sprintf("%s: applied %s settings: %s", repo, policy, service.DescribeSettings(r.Drift.Settings))
+	case len(r.Drift.Settings) > 0:
+		return fmt.Sprintf("%s: settings differ from %s: %s", repo, policy, service.DescribeSettings(r.Drift.Settings))
+	case r.Action == "created":
+		return fmt.Sprintf("%s: opened %s to %s %s", repo, r.PRURL, r.Drift.Action, r.Drift.TargetPath)
+	case r.Action == "updated":
+		return fmt.Sprintf("%s: updated %s for %s", repo, r.PRURL, policy)
+	}
+	return fmt.Sprintf("%s: %s already up to date in %s", repo, policy, r.PRURL)
+}
+
+// headline summarizes a run in one line.
+func headline(s Summary) string {
+	if s.Error != "" {
+		return fmt.Sprintf("Policy bot run failed after %s: %s", s.FinishedAt.Sub(s.StartedAt).Round(time.Second), s.Error)
+	}
+
+	var counts []string
+	for _, status := range report.Statuses {
+		if n := s.Counts[status]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "no results")
+	}
+	return fmt.Sprintf("Policy bot evaluated %d repositories in %s: %s",
+		s.Repositories, s.FinishedAt.Sub(s.StartedAt).Round(time.Second), strings.Join(counts, ", "))
+}
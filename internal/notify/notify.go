@@ -0,0 +1,102 @@
+// Package notify sends the outcome of runs to chat, HTTP endpoints and email.
+package notify
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// Notifier is called after every remediation and at the end of every run.
+type Notifier interface {
+	Remediated(ctx context.Context, result service.RemediationResult) error
+	RunFinished(ctx context.Context, summary Summary) error
+}
+
+// Summary describes a finished run.
+type Summary struct {
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	Repositories int                   // Repositories evaluated
+	Counts       map[report.Status]int // Policy results of the whole run, per status
+	Results      []service.RemediationResult
+	Error        string // Why the run stopped early, if it did
+}
+
+// Only selects the remediations a notifier receives.
+type Only string
+
+const (
+	OnlyChanges Only = "changes" // PRs opened or updated, invalid configurations and errors (default)
+	OnlyAll     Only = "all"     // Every remediation, including up-to-date PRs and exemptions
+	OnlyErrors  Only = "errors"  // Failed remediations
+	OnlyNewPRs  Only = "new-prs" // PRs opened
+)
+
+// Filter routes remediations to a notifier.
+type Filter struct {
+	Only   Only
+	Topics []string // Only repositories with one of these topics, all when empty
+}
+
+// Match reports whether result passes the filter.
+func (f Filter) Match(result service.RemediationResult) bool {
+	if len(f.Topics) > 0 && !slices.ContainsFunc(result.Drift.Repository.Topics, func(topic string) bool {
+		return slices.Contains(f.Topics, topic)
+	}) {
+		return false
+	}
+
+	switch f.Only {
+	case OnlyAll:
+		return true
+	case OnlyErrors:
+		return result.Error != nil
+	case OnlyNewPRs:
+		return result.Error == nil && result.Action == "created"
+	default:
+		return result.Error != nil ||
+			result.Action == "created" ||
+			result.Action == "updated" ||
+			result.Drift.Action == models.PolicyActionInvalidConfig
+	}
+}
+
+type filtered struct {
+	next   Notifier
+	filter Filter
+}
+
+// Filtered passes next only the remediations matching filter. Run summaries
+// keep the matching remediations, and are dropped when none matches unless
+// the filter keeps every change or the run failed.
+func Filtered(next Notifier, filter Filter) Notifier {
+	return &filtered{next: next, filter: filter}
+}
+
+func (n *filtered) Remediated(ctx context.Context, result service.RemediationResult) error {
+	if !n.filter.Match(result) {
+		return nil
+	}
+	return n.next.Remediated(ctx, result)
+}
+
+func (n *filtered) RunFinished(ctx context.Context, summary Summary) error {
+	var results []service.RemediationResult
+	for _, r := range summary.Results {
+		if n.filter.Match(r) {
+			results = append(results, r)
+		}
+	}
+	summary.Results = results
+
+	selective := n.filter.Only == OnlyErrors || n.filter.Only == OnlyNewPRs || len(n.filter.Topics) > 0
+	if selective && len(results) == 0 && summary.Error == "" {
+		return nil
+	}
+	return n.next.RunFinished(ctx, summary)
+}
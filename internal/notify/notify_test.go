@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+type recorder struct {
+	remediations []service.RemediationResult
+	summaries    []Summary
+}
+
+func (r *recorder) Remediated(_ context.Context, result service.RemediationResult) error {
+	r.remediations = append(r.remediations, result)
+	return nil
+}
+
+func (r *recorder) RunFinished(_ context.Context, summary Summary) error {
+	r.summaries = append(r.summaries, summary)
+	return nil
+}
+
+func result(repo string, topics []string, action string, err error) service.RemediationResult {
+	return service.RemediationResult{
+		Drift: models.PolicyDeviation{
+			Repository: models.Repository{FullName: "tracker-tv/" + repo, Topics: topics},
+			Policy:     models.PolicyWorkflow{Name: "go"},
+			Action:     models.PolicyActionUpdate,
+			TargetPath: ".github/workflows/go.yml",
+		},
+		Action: action,
+		PRURL:  "https://github.com/tracker-tv/" + repo + "/pull/1",
+		Error:  err,
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	created := result("api", nil, "created", nil)
+	updated := result("api", nil, "updated", nil)
+	skipped := result("api", nil, "skipped", nil)
+	failed := result("api", nil, "", errors.New("boom"))
+	invalid := result("api", nil, "", nil)
+	invalid.Drift.Action = models.PolicyActionInvalidConfig
+
+	tests := []struct {
+		only Only
+		want []bool // created, updated, skipped, failed, invalid
+	}{
+		{"", []bool{true, true, false, true, true}},
+		{OnlyChanges, []bool{true, true, false, true, true}},
+		{OnlyAll, []bool{true, true, true, true, true}},
+		{OnlyErrors, []bool{false, false, false, true, false}},
+		{OnlyNewPRs, []bool{true, false, false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.only), func(t *testing.T) {
+			f := Filter{Only: tt.only}
+			got := []bool{f.Match(created), f.Match(updated), f.Match(skipped), f.Match(failed), f.Match(invalid)}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter_MatchTopics(t *testing.T) {
+	f := Filter{Topics: []string{"team-payments", "team-search"}}
+
+	assert.True(t, f.Match(result("api", []string{"go", "team-search"}, "created", nil)))
+	assert.False(t, f.Match(result("web", []string{"team-frontend"}, "created", nil)))
+	assert.False(t, f.Match(result("misc", nil, "created", nil)))
+}
+
+func TestFiltered_Remediated(t *testing.T) {
+	rec := &recorder{}
+	n := Filtered(rec, Filter{Only: OnlyErrors})
+
+	assert.NoError(t, n.Remediated(context.Background(), result("api", nil, "created", nil)))
+	assert.NoError(t, n.Remediated(context.Background(), result("web", nil, "", errors.New("boom"))))
+
+	assert.Len(t, rec.remediations, 1)
+	assert.Equal(t, "tracker-tv/web", rec.remediations[0].Drift.Repository.FullName)
+}
+
+func TestFiltered_RunFinished(t *testing.T) {
+	summary := Summary{
+		Repositories: 2,
+		Results: []service.RemediationResult{
+			result("api", []string{"team-search"}, "created", nil),
+			result("web", []string{"team-frontend"}, "skipped", nil),
+		},
+	}
+
+	t.Run("keeps matching results", func(t *testing.T) {
+		rec := &recorder{}
+		assert.NoError(t, Filtered(rec, Filter{}).RunFinished(context.Background(), summary))
+
+		assert.Len(t, rec.summaries, 1)
+		assert.Len(t, rec.summaries[0].Results, 1)
+		assert.Equal(t, 2, rec.summaries[0].Repositories)
+	})
+
+	t.Run("sends summaries without changes by default", func(t *testing.T) {
+		rec := &recorder{}
+		assert.NoError(t, Filtered(rec, Filter{}).RunFinished(context.Background(), Summary{Repositories: 2}))
+
+		assert.Len(t, rec.summaries, 1)
+	})
+
+	t.Run("drops selective summaries without results", func(t *testing.T) {
+		rec := &recorder{}
+		assert.NoError(t, Filtered(rec, Filter{Only: OnlyErrors}).RunFinished(context.Background(), summary))
+		assert.NoError(t, Filtered(rec, Filter{Topics: []string{"team-payments"}}).RunFinished(context.Background(), summary))
+
+		assert.Empty(t, rec.summaries)
+	})
+
+	t.Run("always sends failed runs", func(t *testing.T) {
+		rec := &recorder{}
+		failed := Summary{Error: "listing repositories: 502 Bad Gateway"}
+		assert.NoError(t, Filtered(rec, Filter{Only: OnlyNewPRs}).RunFinished(context.Background(), failed))
+
+		assert.Len(t, rec.summaries, 1)
+	})
+}
+
+func TestDescribe_Unpinned(t *testing.T) {
+	r := result("api", nil, "skipped", nil)
+	r.Drift.Action = models.PolicyActionUnpinned
+	r.Drift.TargetPath = ".github/workflows/release.yml"
+	r.Drift.UnpinnedActions = []string{"actions/checkout@v4"}
+
+	assert.Equal(t, "tracker-tv/api: .github/workflows/release.yml uses unpinned actions: actions/checkout@v4", describe(r))
+	assert.False(t, Filter{}.Match(r), "unpinned actions are only reported")
+}
+
+func TestDescribe_Settings(t *testing.T) {
+	r := result("api", nil, "updated", nil)
+	r.Drift.Policy = models.PolicyWorkflow{Name: "merge-settings"}
+	r.Drift.Action = models.PolicyActionSettings
+	r.Drift.TargetPath = ""
+	r.Drift.Settings = []models.SettingChange{{Setting: "has_wiki", Current: "true", Expected: "false"}}
+
+	assert.Equal(t, "tracker-tv/api: applied merge-settings settings: has_wiki: true -> false", describe(r))
+	assert.True(t, Filter{}.Match(r))
+
+	r.Action = "reported"
+	assert.Equal(t, "tracker-tv/api: settings differ from merge-settings: has_wiki: true -> false", describe(r))
+	assert.False(t, Filter{}.Match(r), "settings drift is only reported")
+}
+
+func TestDescribe_Protection(t *testing.T) {
+	r := result("api", nil, "updated", nil)
+	r.Drift.Policy = models.PolicyWorkflow{Name: "default-branch"}
+	r.Drift.Action = models.PolicyActionProtection
+	r.Drift.TargetPath = ""
+	r.Drift.Settings = []models.SettingChange{{Setting: "linear_history", Current: "false", Expected: "true"}}
+	r.Drift.Protection = &models.BranchProtection{Branch: "main", LinearHistory: true}
+
+	assert.Equal(t, "tracker-tv/api: applied default-branch to main: linear_history: false -> true", describe(r))
+
+	r.Action = "reported"
+	assert.Equal(t, "tracker-tv/api: protection of main differs from default-branch: linear_history: false -> true", describe(r))
+}
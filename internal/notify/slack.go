@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+)
+
+// maxSlackLines caps the remediations listed in a run summary.
+const maxSlackLines = 20
+
+// DefaultTimeout bounds the requests of the notifiers, which are sent during
+// the run: an unresponsive sink must not stall it.
+const DefaultTimeout = 10 * time.Second
+
+type slack struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlack posts to a Slack or Mattermost incoming webhook, giving up on a
+// request after timeout.
+func NewSlack(url string, timeout time.Duration) Notifier {
+	return &slack{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (n *slack) Remediated(ctx context.Context, result service.RemediationResult) error {
+	return n.post(ctx, describe(result))
+}
+
+func (n *slack) RunFinished(ctx context.Context, summary Summary) error {
+	lines := []string{headline(summary)}
+	for i, r := range summary.Results {
+		if i == maxSlackLines {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(summary.Results)-maxSlackLines))
+			break
+		}
+		lines = append(lines, "• "+describe(r))
+	}
+	return n.post(ctx, strings.Join(lines, "\n"))
+}
+
+func (n *slack) post(ctx context.Context, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	return post(ctx, n.client, n.url, body, nil)
+}
+
+// post sends a JSON body to url and fails on any non-2xx response.
+func post(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return nil
+}
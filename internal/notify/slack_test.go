@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+)
+
+func TestSlack_RunFinished(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	err := NewSlack(server.URL, DefaultTimeout).RunFinished(context.Background(), Summary{
+		StartedAt:    start,
+		FinishedAt:   start.Add(83 * time.Second),
+		Repositories: 12,
+		Counts:       map[report.Status]int{report.StatusCompliant: 10, report.StatusRemediated: 2},
+		Results:      []service.RemediationResult{result("api", nil, "created", nil)},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Policy bot evaluated 12 repositories in 1m23s: 10 compliant, 2 remediated\n"+
+		"• tracker-tv/api: opened https://github.com/tracker-tv/api/pull/1 to update .github/workflows/go.yml",
+		payload["text"])
+}
+
+func TestSlack_RunFinishedTruncates(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	var results []service.RemediationResult
+	for range maxSlackLines + 5 {
+		results = append(results, result("api", nil, "updated", nil))
+	}
+
+	assert.NoError(t, NewSlack(server.URL, DefaultTimeout).RunFinished(context.Background(), Summary{Results: results}))
+	assert.Contains(t, payload["text"], "… and 5 more")
+}
+
+func TestSlack_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewSlack(server.URL, DefaultTimeout).Remediated(context.Background(), result("api", nil, "created", nil))
+
+	assert.ErrorContains(t, err, "403 Forbidden")
+}
@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+)
+
+type email struct {
+	addr    string
+	from    string
+	to      []string
+	auth    smtp.Auth
+	timeout time.Duration
+	send    func(ctx context.Context, addr string, timeout time.Duration, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTP emails a digest of every run through the server at addr (host:port),
+// giving up after timeout. auth may be nil for servers that accept
+// unauthenticated mail.
+func NewSMTP(addr, from string, to []string, auth smtp.Auth, timeout time.Duration) Notifier {
+	return &email{addr: addr, from: from, to: to, auth: auth, timeout: timeout, send: sendMail}
+}
+
+// Remediated does nothing: remediations are listed in the digest.
+func (n *email) Remediated(context.Context, service.RemediationResult) error {
+	return nil
+}
+
+func (n *email) RunFinished(ctx context.Context, summary Summary) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\r\n", headline(summary))
+	if len(summary.Results) > 0 {
+		body.WriteString("\r\n")
+		for _, r := range summary.Results {
+			fmt.Fprintf(&body, "- %s\r\n", describe(r))
+		}
+	}
+
+	subject := "Policy bot digest"
+	if summary.Error != "" {
+		subject = "Policy bot run failed"
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s",
+		n.from, strings.Join(n.to, ", "), subject, summary.FinishedAt.Format(time.RFC1123Z), body.String())
+
+	if err := n.send(ctx, n.addr, n.timeout, n.auth, n.from, n.to, []byte(msg)); err != nil {
+		return fmt.Errorf("sending digest: %w", err)
+	}
+	return nil
+}
+
+// sendMail is smtp.SendMail bounded by timeout and ctx, which the standard
+// library does not support: an unresponsive server must not stall the run.
+func sendMail(ctx context.Context, addr string, timeout time.Duration, a smtp.Auth, from string, to []string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// The whole exchange shares the deadline, and a cancelled ctx interrupts it
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("server does not support AUTH")
+		}
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+)
+
+func TestSMTP_RunFinished(t *testing.T) {
+	n := NewSMTP("mail.example.com:587", "bot@example.com", []string{"platform@example.com", "sre@example.com"}, nil, DefaultTimeout).(*email)
+	var gotAddr, gotFrom string
+	var gotTimeout time.Duration
+	var gotTo []string
+	var gotMsg string
+	n.send = func(_ context.Context, addr string, timeout time.Duration, _ smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTimeout, gotFrom, gotTo, gotMsg = addr, timeout, from, to, string(msg)
+		return nil
+	}
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	err := n.RunFinished(context.Background(), Summary{
+		StartedAt:    start,
+		FinishedAt:   start.Add(time.Minute),
+		Repositories: 4,
+		Counts:       map[report.Status]int{report.StatusCompliant: 3, report.StatusRemediated: 1},
+		Results:      []service.RemediationResult{result("api", nil, "created", nil)},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mail.example.com:587", gotAddr)
+	assert.Equal(t, DefaultTimeout, gotTimeout)
+	assert.Equal(t, "bot@example.com", gotFrom)
+	assert.Equal(t, []string{"platform@example.com", "sre@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "To: platform@example.com, sre@example.com\r\n")
+	assert.Contains(t, gotMsg, "Subject: Policy bot digest\r\n")
+	assert.Contains(t, gotMsg, "\r\n\r\nPolicy bot evaluated 4 repositories in 1m0s: 3 compliant, 1 remediated\r\n")
+	assert.Contains(t, gotMsg, "- tracker-tv/api: opened https://github.com/tracker-tv/api/pull/1 to update .github/workflows/go.yml\r\n")
+}
+
+func TestSMTP_RunFailed(t *testing.T) {
+	n := NewSMTP("mail.example.com:25", "bot@example.com", []string{"sre@example.com"}, nil, DefaultTimeout).(*email)
+	var gotMsg string
+	n.send = func(_ context.Context, _ string, _ time.Duration, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+		gotMsg = string(msg)
+		return errors.New("connection refused")
+	}
+
+	err := n.RunFinished(context.Background(), Summary{Error: "listing repositories: 502 Bad Gateway"})
+
+	assert.EqualError(t, err, "sending digest: connection refused")
+	assert.Contains(t, gotMsg, "Subject: Policy bot run failed\r\n")
+}
+
+func TestSMTP_RemediatedIsDigested(t *testing.T) {
+	n := NewSMTP("mail.example.com:25", "bot@example.com", []string{"sre@example.com"}, nil, DefaultTimeout).(*email)
+	n.send = func(context.Context, string, time.Duration, smtp.Auth, string, []string, []byte) error {
+		t.Fatal("remediations must not be sent on their own")
+		return nil
+	}
+
+	assert.NoError(t, n.Remediated(context.Background(), result("api", nil, "created", nil)))
+}
+
+func TestSMTP_UnresponsiveServerTimesOut(t *testing.T) {
+	n := NewSMTP(silentServer(t), "bot@example.com", []string{"sre@example.com"}, nil, 50*time.Millisecond)
+	started := time.Now()
+	err := n.RunFinished(context.Background(), Summary{})
+
+	assert.ErrorContains(t, err, "sending digest: ")
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestSMTP_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	n := NewSMTP(silentServer(t), "bot@example.com", []string{"sre@example.com"}, nil, time.Minute)
+	started := time.Now()
+	err := n.RunFinished(ctx, Summary{})
+
+	assert.ErrorContains(t, err, "sending digest: ")
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
+
+// silentServer accepts connections but never greets, like an unresponsive
+// SMTP server, and returns its address.
+func silentServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	return listener.Addr().String()
+}
@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the body, as "sha256=<hex>", like GitHub webhooks.
+const SignatureHeader = "X-Policy-Bot-Signature-256"
+
+type webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhook posts JSON events to url, signed with secret when it is not
+// empty, giving up on a request after timeout.
+func NewWebhook(url string, secret []byte, timeout time.Duration) Notifier {
+	return &webhook{url: url, secret: secret, client: &http.Client{Timeout: timeout}}
+}
+
+type remediationEvent struct {
+	Repository string `json:"repository"`
+	Policy     string `json:"policy"`
+	Deviation  string `json:"deviation"`
+	Path       string `json:"path"`
+	Result     string `json:"result,omitempty"`
+	PRURL      string `json:"pr_url,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Message    string `json:"message"`
+}
+
+type runEvent struct {
+	StartedAt    time.Time             `json:"started_at"`
+	FinishedAt   time.Time             `json:"finished_at"`
+	Repositories int                   `json:"repositories"`
+	Counts       map[report.Status]int `json:"counts"`
+	Remediations []remediationEvent    `json:"remediations"`
+	Error        string                `json:"error,omitempty"`
+	Message      string                `json:"message"`
+}
+
+type envelope struct {
+	Event       string            `json:"event"` // "remediation" or "run"
+	Remediation *remediationEvent `json:"remediation,omitempty"`
+	Run         *runEvent         `json:"run,omitempty"`
+}
+
+func newRemediationEvent(r service.RemediationResult) remediationEvent {
+	e := remediationEvent{
+		Repository: r.Drift.Repository.FullName,
+		Policy:     r.Drift.Policy.Name,
+		Deviation:  string(r.Drift.Action),
+		Path:       r.Drift.TargetPath,
+		Result:     r.Action,
+		PRURL:      r.PRURL,
+		Message:    describe(r),
+	}
+	if r.Error != nil {
+		e.Error = r.Error.Error()
+	}
+	return e
+}
+
+func (n *webhook) Remediated(ctx context.Context, result service.RemediationResult) error {
+	e := newRemediationEvent(result)
+	return n.send(ctx, envelope{Event: "remediation", Remediation: &e})
+}
+
+func (n *webhook) RunFinished(ctx context.Context, summary Summary) error {
+	run := runEvent{
+		StartedAt:    summary.StartedAt,
+		FinishedAt:   summary.FinishedAt,
+		Repositories: summary.Repositories,
+		Counts:       summary.Counts,
+		Remediations: make([]remediationEvent, 0, len(summary.Results)),
+		Error:        summary.Error,
+		Message:      headline(summary),
+	}
+	for _, r := range summary.Results {
+		run.Remediations = append(run.Remediations, newRemediationEvent(r))
+	}
+	return n.send(ctx, envelope{Event: "run", Run: &run})
+}
+
+func (n *webhook) send(ctx context.Context, e envelope) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	var headers map[string]string
+	if len(n.secret) > 0 {
+		headers = map[string]string{SignatureHeader: Sign(n.secret, body)}
+	}
+	return post(ctx, n.client, n.url, body, headers)
+}
+
+// Sign returns the signature of body sent in SignatureHeader, for receivers to verify deliveries.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Remediated(t *testing.T) {
+	secret := []byte("s3cret")
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, secret, DefaultTimeout).Remediated(context.Background(), result("api", nil, "", errors.New("boom")))
+	assert.NoError(t, err)
+
+	assert.Equal(t, Sign(secret, body), signature)
+
+	var got envelope
+	assert.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "remediation", got.Event)
+	assert.Nil(t, got.Run)
+	assert.Equal(t, &remediationEvent{
+		Repository: "tracker-tv/api",
+		Policy:     "go",
+		Deviation:  "update",
+		Path:       ".github/workflows/go.yml",
+		PRURL:      "https://github.com/tracker-tv/api/pull/1",
+		Error:      "boom",
+		Message:    "tracker-tv/api: remediating go failed: boom",
+	}, got.Remediation)
+}
+
+func TestWebhook_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	err := NewWebhook(server.URL, nil, 50*time.Millisecond).RunFinished(context.Background(), Summary{})
+
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestWebhook_RunFinishedUnsigned(t *testing.T) {
+	var got envelope
+	var signed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, signed = r.Header[http.CanonicalHeaderKey(SignatureHeader)]
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, nil, DefaultTimeout).RunFinished(context.Background(), Summary{Repositories: 3})
+	assert.NoError(t, err)
+
+	assert.False(t, signed)
+	assert.Equal(t, "run", got.Event)
+	assert.Equal(t, 3, got.Run.Repositories)
+	assert.Empty(t, got.Run.Remediations)
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		Sign([]byte("key"), []byte("The quick brown fox jumps over the lazy dog")))
+}
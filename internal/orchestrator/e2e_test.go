@@ -0,0 +1,215 @@
+package orchestrator
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/github/githubtest"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+const goWorkflow = "name: go\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"
+
+// newE2E starts a fake GitHub holding the policy sources and a few
+// repositories, and wires the real services against it.
+func newE2E(t *testing.T) (*githubtest.Server, func(opts ...Option) *GithubActionsBot) {
+	s := githubtest.NewServer(t, "tracker-tv")
+	s.AddRepo(githubtest.Repo{Name: "github-actions-ttv", Files: map[string]string{"workflows/go.yml": goWorkflow}})
+	s.AddRepo(githubtest.Repo{Name: "api", Files: map[string]string{"go.mod": "module api\n"}})
+	s.AddRepo(githubtest.Repo{Name: "web", Files: map[string]string{"package.json": "{}\n"}})
+	s.AddRepo(githubtest.Repo{Name: "legacy", Archived: true, Files: map[string]string{"go.mod": "module legacy\n"}})
+
+	gh := github.New("token", "tracker-tv", github.WithBaseURL(s.BaseURL()))
+	policies := []models.PolicyWorkflow{{
+		Name:      "go",
+		MatchFile: "go.mod",
+		Source:    s.RawURL("github-actions-ttv", "main", "workflows/go.yml"),
+	}}
+
+	return s, func(opts ...Option) *GithubActionsBot {
+		return NewGithubActionsBot(
+			service.NewRepositoriesService(gh),
+			service.NewPolicyService(policies, gh),
+			service.NewRemediationService(gh, service.WithPullRequestDefaults(models.PullRequestOptions{Labels: []string{"policy-bot"}})),
+			opts...,
+		)
+	}
+}
+
+func TestE2E_OpensPullRequest(t *testing.T) {
+	s, newBot := newE2E(t)
+
+	results, err := newBot().Run(context.Background())
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, "created", results[0].Action)
+	assert.Equal(t, "api", results[0].Drift.Repository.Name)
+
+	prs := s.PullRequests("api")
+	if !assert.Len(t, prs, 1) {
+		return
+	}
+	assert.Equal(t, "chore/go", prs[0].Head)
+	assert.Equal(t, "main", prs[0].Base)
+	assert.Equal(t, []string{"policy-bot"}, prs[0].Labels)
+
+	workflow, ok := s.File("api", "chore/go", results[0].Drift.TargetPath)
+	assert.True(t, ok)
+	assert.True(t, strings.Contains(workflow, goWorkflow), "the workflow of the source is committed")
+	_, ok = s.File("api", "main", results[0].Drift.TargetPath)
+	assert.False(t, ok, "the default branch is left untouched")
+
+	assert.Empty(t, s.PullRequests("web"))
+	assert.Empty(t, s.PullRequests("legacy"), "archived repositories are ignored")
+}
+
+func TestE2E_SecondRunIsIdempotent(t *testing.T) {
+	s, newBot := newE2E(t)
+	_, err := newBot().Run(context.Background())
+	assert.NoError(t, err)
+
+	results, err := newBot().Run(context.Background())
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.Equal(t, "skipped", results[0].Action)
+	assert.Len(t, s.PullRequests("api"), 1)
+}
+
+func TestE2E_SourceChangeUpdatesPullRequest(t *testing.T) {
+	s, newBot := newE2E(t)
+	_, err := newBot().Run(context.Background())
+	assert.NoError(t, err)
+	s.Commit("github-actions-ttv", "main", "Run on pull requests", map[string]string{"workflows/go.yml": strings.Replace(goWorkflow, "on: push", "on: pull_request", 1)})
+
+	results, err := newBot().Run(context.Background())
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.Equal(t, "updated", results[0].Action)
+	workflow, _ := s.File("api", "chore/go", results[0].Drift.TargetPath)
+	assert.True(t, strings.Contains(workflow, "on: pull_request"))
+	assert.Len(t, s.PullRequests("api"), 1)
+}
+
+func TestE2E_InvalidSourceErrorsItsPolicy(t *testing.T) {
+	s, newBot := newE2E(t)
+	s.Commit("github-actions-ttv", "main", "Break the workflow", map[string]string{"workflows/go.yml": "name: go\non: push\njobs:\n  test:\n    needs: build\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"})
+
+	var r report.Report
+	results, err := newBot(WithReport(&r)).Run(context.Background())
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.Equal(t, "api", results[0].Drift.Repository.Name)
+	assert.ErrorContains(t, results[0].Error, "invalid policy: ")
+	assert.ErrorContains(t, results[0].Error, "job test needs unknown job build")
+	assert.Len(t, r.Repositories, 3, "every repository is evaluated")
+	assert.Equal(t, 1, r.Counts()[report.StatusErrored])
+	assert.Empty(t, s.PullRequests("api"))
+}
+
+// rawTransport serves raw.githubusercontent.com from the fake GitHub.
+type rawTransport struct{ server *githubtest.Server }
+
+func (t rawTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(t.server.URL + "/raw" + req.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL, req.Host = target, target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestE2E_InvalidPinnedVersionIsNotPushed(t *testing.T) {
+	s := githubtest.NewServer(t, "tracker-tv")
+	s.AddRepo(githubtest.Repo{Name: "github-actions-ttv", Files: map[string]string{"workflows/go.yml": goWorkflow}})
+	s.Commit("github-actions-ttv", "v1", "Break the workflow", map[string]string{"workflows/go.yml": "name: go\non: push\njobs:\n  test:\n    needs: build\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"})
+	s.AddRepo(githubtest.Repo{Name: "api", Files: map[string]string{
+		"go.mod":                 "module api\n",
+		".github/policy-bot.yml": "policies:\n  go:\n    version: v1\n",
+	}})
+
+	gh := github.New("token", "tracker-tv", github.WithBaseURL(s.BaseURL()))
+	policies := []models.PolicyWorkflow{{
+		Name:      "go",
+		MatchFile: "go.mod",
+		Source:    "https://raw.githubusercontent.com/tracker-tv/github-actions-ttv/main/workflows/go.yml",
+	}}
+	bot := NewGithubActionsBot(
+		service.NewRepositoriesService(gh),
+		service.NewPolicyService(policies, gh, service.WithHTTPClient(&http.Client{Transport: rawTransport{s}})),
+		service.NewRemediationService(gh),
+	)
+
+	results, err := bot.Run(context.Background())
+
+	// The default source is valid, the version the repository pins is checked before its PR
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.ErrorContains(t, results[0].Error, "validating expected content: invalid workflow: line 5: job test needs unknown job build")
+	assert.Empty(t, s.PullRequests("api"))
+}
+
+func TestE2E_FaultsOnlyFailTheirRepository(t *testing.T) {
+	s, newBot := newE2E(t)
+	s.AddRepo(githubtest.Repo{Name: "worker", Files: map[string]string{"go.mod": "module worker\n"}})
+	s.AddRepo(githubtest.Repo{Name: "cli", Files: map[string]string{"go.mod": "module cli\n"}})
+	s.Inject(githubtest.Unprocessable("POST", "/repos/tracker-tv/worker/pulls"))
+	s.Inject(githubtest.NotFound("GET", "/repos/tracker-tv/cli/git/trees/*"))
+
+	var r report.Report
+	results, err := newBot(WithReport(&r)).Run(context.Background())
+
+	assert.NoError(t, err)
+	byRepo := make(map[string]service.RemediationResult)
+	for _, result := range results {
+		byRepo[result.Drift.Repository.Name] = result
+	}
+	assert.Equal(t, "created", byRepo["api"].Action)
+	assert.Error(t, byRepo["worker"].Error)
+	assert.Empty(t, s.PullRequests("worker"))
+	assert.Empty(t, s.PullRequests("cli"))
+
+	errored := make(map[string]bool)
+	for _, repo := range r.Repositories {
+		errored[repo.Name] = repo.Error != ""
+	}
+	assert.Equal(t, map[string]bool{"api": false, "cli": true, "github-actions-ttv": false, "web": false, "worker": false}, errored)
+	assert.Equal(t, 2, r.Counts()[report.StatusErrored])
+}
+
+func TestE2E_RateLimitStopsCalls(t *testing.T) {
+	s, newBot := newE2E(t)
+	s.Inject(githubtest.RateLimited("GET", "/repos/tracker-tv/api/git/trees/*"))
+
+	var r report.Report
+	_, err := newBot(WithReport(&r)).Run(context.Background())
+
+	assert.NoError(t, err)
+	// The client remembers the reset time and fails the following repositories without calling GitHub
+	assert.Equal(t, 3, r.Counts()[report.StatusErrored], "api, github-actions-ttv and web")
+	for _, request := range s.Requests() {
+		assert.False(t, strings.Contains(request, "/repos/tracker-tv/web/"), request)
+	}
+}
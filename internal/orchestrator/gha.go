@@ -3,59 +3,422 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
+	"github.com/tracker-tv/github-policy-bots/internal/notify"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
 	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/internal/tracing"
+	"github.com/tracker-tv/github-policy-bots/internal/workflow"
+	"github.com/tracker-tv/github-policy-bots/models"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type GithubActionsBot struct {
 	repos       service.RepositoryService
 	policy      service.PolicyService
 	remediation service.RemediationService
+	settings    service.SettingsService
+	protection  service.ProtectionService
+	report      *report.Report
+	metrics     *metrics.Metrics
+	notifiers   []notify.Notifier
+	now         func() time.Time
 }
 
-func NewGithubActionsBot(repos service.RepositoryService, policy service.PolicyService, remediation service.RemediationService) *GithubActionsBot {
-	return &GithubActionsBot{repos: repos, policy: policy, remediation: remediation}
+// Option configures optional behaviour of the bot.
+type Option func(*GithubActionsBot)
+
+// WithReport records the outcome of every repository and policy evaluated by Run into r.
+func WithReport(r *report.Report) Option {
+	return func(b *GithubActionsBot) {
+		b.report = r
+	}
+}
+
+// WithMetrics records repositories scanned, deviations, remediations and run durations in m.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(b *GithubActionsBot) {
+		b.metrics = m
+	}
+}
+
+// WithNotifiers notifies every remediation and the summary of every run.
+func WithNotifiers(notifiers ...notify.Notifier) Option {
+	return func(b *GithubActionsBot) {
+		b.notifiers = append(b.notifiers, notifiers...)
+	}
+}
+
+// WithSettings enforces the settings policies of s next to the workflow policies.
+func WithSettings(s service.SettingsService) Option {
+	return func(b *GithubActionsBot) {
+		b.settings = s
+	}
+}
+
+// WithProtection enforces the branch protection policies of s next to the workflow policies.
+func WithProtection(s service.ProtectionService) Option {
+	return func(b *GithubActionsBot) {
+		b.protection = s
+	}
 }
 
-func (b *GithubActionsBot) Run(ctx context.Context) ([]service.RemediationResult, error) {
+func NewGithubActionsBot(repos service.RepositoryService, policy service.PolicyService, remediation service.RemediationService, opts ...Option) *GithubActionsBot {
+	b := &GithubActionsBot{repos: repos, policy: policy, remediation: remediation, now: time.Now}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *GithubActionsBot) Run(ctx context.Context) (results []service.RemediationResult, err error) {
+	ctx, span := tracing.Start(ctx, "Run")
+	defer func() { tracing.End(span, err) }()
+
+	runReport := report.Report{StartedAt: b.now()}
+	defer func() {
+		runReport.FinishedAt = b.now()
+		if b.report != nil {
+			*b.report = runReport
+		}
+		b.metrics.RunFinished(runReport.Duration(), resultsByPolicy(runReport))
+
+		summary := notify.Summary{
+			StartedAt:    runReport.StartedAt,
+			FinishedAt:   runReport.FinishedAt,
+			Repositories: len(runReport.Repositories),
+			Counts:       runReport.Counts(),
+			Results:      results,
+		}
+		if err != nil {
+			summary.Error = err.Error()
+		}
+		b.notify(ctx, func(n notify.Notifier) error { return n.RunFinished(ctx, summary) })
+	}()
+
+	// A broken source is caught once instead of being pushed to every repository,
+	// the repositories it applies to report it as errored
+	b.validate(ctx)
+
 	repos, err := b.repos.ListAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var results []service.RemediationResult
-
 	for _, repo := range repos {
 		if repo.Archived {
 			continue
 		}
 
-		repoFiles, err := b.repos.ListFiles(ctx, repo.Name)
+		repoResults, repoReport := b.runRepository(ctx, repo)
+		results = append(results, repoResults...)
+		runReport.Repositories = append(runReport.Repositories, repoReport)
+		b.metrics.RepositoryScanned()
+	}
+
+	return results, nil
+}
+
+// RunRepository evaluates and remediates a single repository, e.g. in response to a webhook.
+func (b *GithubActionsBot) RunRepository(ctx context.Context, repo models.Repository) []service.RemediationResult {
+	if repo.Archived {
+		return nil
+	}
+	started := b.now()
+	b.validate(ctx)
+
+	results, repoReport := b.runRepository(ctx, repo)
+	b.metrics.RepositoryScanned()
+	if b.report != nil {
+		*b.report = report.Report{StartedAt: started, FinishedAt: b.now(), Repositories: []report.Repository{repoReport}}
+	}
+	return results
+}
+
+// notify calls every notifier. A failing notifier is logged and does not stop the run.
+func (b *GithubActionsBot) notify(ctx context.Context, call func(notify.Notifier) error) {
+	for _, n := range b.notifiers {
+		if err := call(n); err != nil {
+			logging.FromContext(ctx).Warn("could not notify", logging.Error(err))
+		}
+	}
+}
+
+// recording reports whether the outcome of every policy must be recorded, compliant ones included.
+func (b *GithubActionsBot) recording() bool {
+	return b.report != nil || b.metrics != nil || len(b.notifiers) > 0
+}
+
+// resultsByPolicy counts the repositories per policy and status.
+func resultsByPolicy(r report.Report) map[string]map[string]int {
+	counts := make(map[string]map[string]int)
+	for _, repo := range r.Repositories {
+		for _, p := range repo.Policies {
+			if counts[p.Name] == nil {
+				counts[p.Name] = make(map[string]int)
+			}
+			counts[p.Name][string(p.Status)]++
+		}
+	}
+	return counts
+}
+
+func (b *GithubActionsBot) runRepository(ctx context.Context, repo models.Repository) (results []service.RemediationResult, repoReport report.Repository) {
+	started := b.now()
+	repoReport = report.Repository{Name: repo.Name, FullName: repo.FullName}
+	ctx = logging.With(ctx, logging.Repo(repo.FullName))
+	logger := logging.FromContext(ctx)
+	defer func() { repoReport.Duration = b.now().Sub(started) }()
+
+	ctx, span := tracing.Start(ctx, "repository", tracing.Repo(repo.FullName))
+	defer span.End()
+
+	repoFiles, err := b.listFiles(ctx, repo)
+	if err != nil {
+		logger.Warn("could not list files", logging.Error(err))
+		repoReport.Error = fmt.Sprintf("listing files: %v", err)
+		return nil, repoReport
+	}
+
+	evaluation, err := b.ensure(ctx, repo, repoFiles)
+	if err != nil {
+		logger.Warn("could not check policies", logging.Error(err))
+		repoReport.Error = fmt.Sprintf("checking policies: %v", err)
+		return nil, repoReport
+	}
+	deviations := evaluation.Deviations
+	applied := evaluation.Applied
+	failed := make(map[string]error)
+	maps.Copy(failed, evaluation.Errors)
+
+	if b.settings != nil {
+		settingsEvaluation, err := b.ensureSettings(ctx, repo, repoFiles)
 		if err != nil {
-			fmt.Printf("warning: could not list files for %s: %v\n", repo.Name, err)
-			continue
+			logger.Warn("could not check settings", logging.Error(err))
+			repoReport.Error = fmt.Sprintf("checking settings: %v", err)
+			return nil, repoReport
 		}
+		deviations = append(deviations, settingsEvaluation.Deviations...)
+		applied = append(applied, settingsEvaluation.Applied...)
+	}
 
-		deviations, err := b.policy.Ensure(ctx, repo, repoFiles)
+	if b.protection != nil {
+		protectionEvaluation, err := b.ensureProtection(ctx, repo, repoFiles, evaluation)
 		if err != nil {
-			fmt.Printf("warning: could not check policies for %s: %v\n", repo.Name, err)
-			continue
+			logger.Warn("could not check protection", logging.Error(err))
+			repoReport.Error = fmt.Sprintf("checking protection: %v", err)
+			return nil, repoReport
 		}
+		deviations = append(deviations, protectionEvaluation.Deviations...)
+		applied = append(applied, protectionEvaluation.Applied...)
+		maps.Copy(failed, protectionEvaluation.Errors)
+	}
+
+	reported := make(map[string]bool)
 
-		for _, deviation := range deviations {
-			result, err := b.remediation.Remediate(ctx, deviation)
-			if err != nil {
-				fmt.Printf("warning: could not remediate %s in %s: %v\n",
-					deviation.Policy.Name, deviation.Repository.Name, err)
-				results = append(results, service.RemediationResult{
-					Drift: deviation,
-					Error: err,
-				})
-				continue
+	for _, deviation := range deviations {
+		remediationStarted := b.now()
+		deviationCtx := logging.With(ctx, logging.Policy(deviation.Policy.Name), logging.Action(string(deviation.Action)), logging.Path(deviation.TargetPath))
+		result, err := b.remediate(deviationCtx, deviation)
+		if err != nil {
+			logging.FromContext(deviationCtx).Warn("could not remediate", logging.Error(err))
+			result = &service.RemediationResult{
+				Drift: deviation,
+				Error: err,
 			}
-			results = append(results, *result)
 		}
+		results = append(results, *result)
+
+		b.notify(deviationCtx, func(n notify.Notifier) error { return n.Remediated(deviationCtx, *result) })
+		b.metrics.DeviationFound(deviation.Policy.Name, string(deviation.Action))
+		if result.Error != nil {
+			b.metrics.Remediated(deviation.Policy.Name, "error")
+		} else {
+			b.metrics.Remediated(deviation.Policy.Name, result.Action)
+		}
+
+		if b.recording() {
+			entry := policyReport(*result)
+			entry.Duration = b.now().Sub(remediationStarted)
+			repoReport.Policies = append(repoReport.Policies, entry)
+		}
+		reported[deviation.Policy.Name] = true
 	}
 
-	return results, nil
+	// A policy which could not be evaluated, e.g. with an invalid source, errors without failing the others
+	for _, name := range slices.Sorted(maps.Keys(failed)) {
+		result := service.RemediationResult{
+			Drift: models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: name}},
+			Error: failed[name],
+		}
+		results = append(results, result)
+
+		policyCtx := logging.With(ctx, logging.Policy(name))
+		b.notify(policyCtx, func(n notify.Notifier) error { return n.Remediated(policyCtx, result) })
+		b.metrics.Remediated(name, "error")
+
+		if b.recording() {
+			repoReport.Policies = append(repoReport.Policies, policyReport(result))
+		}
+		reported[name] = true
+	}
+
+	// Only the policies which apply to the repository are compliant, the others are not applicable
+	if b.recording() {
+		committed := make(map[string]string, len(evaluation.Workflows))
+		for _, w := range evaluation.Workflows {
+			committed[w.Policy.Name] = w.Content
+		}
+		for _, name := range applied {
+			if !reported[name] {
+				repoReport.Policies = append(repoReport.Policies, report.Policy{Name: name, Status: report.StatusCompliant, Content: committed[name]})
+			}
+		}
+	}
+
+	return results, repoReport
+}
+
+func (b *GithubActionsBot) listFiles(ctx context.Context, repo models.Repository) (files []string, err error) {
+	ctx, span := tracing.Start(ctx, "ListFiles", tracing.Repo(repo.FullName))
+	defer func() { tracing.End(span, err) }()
+	return b.repos.ListFiles(ctx, repo.Name)
+}
+
+func (b *GithubActionsBot) ensure(ctx context.Context, repo models.Repository, files []string) (evaluation service.Evaluation, err error) {
+	ctx, span := tracing.Start(ctx, "Ensure", tracing.Repo(repo.FullName))
+	defer func() {
+		span.SetAttributes(attribute.Int("policybot.deviations", len(evaluation.Deviations)))
+		tracing.End(span, err)
+	}()
+	return b.policy.Ensure(ctx, repo, files)
+}
+
+func (b *GithubActionsBot) ensureSettings(ctx context.Context, repo models.Repository, files []string) (evaluation service.Evaluation, err error) {
+	ctx, span := tracing.Start(ctx, "EnsureSettings", tracing.Repo(repo.FullName))
+	defer func() {
+		span.SetAttributes(attribute.Int("policybot.deviations", len(evaluation.Deviations)))
+		tracing.End(span, err)
+	}()
+	return b.settings.Ensure(ctx, repo, files)
+}
+
+func (b *GithubActionsBot) ensureProtection(ctx context.Context, repo models.Repository, files []string, workflows service.Evaluation) (evaluation service.Evaluation, err error) {
+	ctx, span := tracing.Start(ctx, "EnsureProtection", tracing.Repo(repo.FullName))
+	defer func() {
+		span.SetAttributes(attribute.Int("policybot.deviations", len(evaluation.Deviations)))
+		tracing.End(span, err)
+	}()
+	return b.protection.Ensure(ctx, repo, files, workflows)
+}
+
+// validate checks the policy sources once per run and logs the invalid ones.
+func (b *GithubActionsBot) validate(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "Validate")
+	defer span.End()
+
+	invalid := b.policy.Validate(ctx)
+	span.SetAttributes(attribute.Int("policybot.invalid_policies", len(invalid)))
+	for _, name := range slices.Sorted(maps.Keys(invalid)) {
+		logging.FromContext(ctx).Error("invalid policy", logging.Policy(name), logging.Error(invalid[name]))
+	}
+}
+
+func (b *GithubActionsBot) remediate(ctx context.Context, deviation models.PolicyDeviation) (result *service.RemediationResult, err error) {
+	ctx, span := tracing.Start(ctx, "Remediate",
+		tracing.Repo(deviation.Repository.FullName),
+		tracing.Policy(deviation.Policy.Name),
+		tracing.Action(string(deviation.Action)),
+		tracing.Path(deviation.TargetPath),
+	)
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.String("policybot.result", result.Action))
+		}
+		tracing.End(span, err)
+	}()
+	// Settings and protection are changed through the API rather than a PR
+	if deviation.Protection != nil {
+		return b.protection.Apply(ctx, deviation)
+	}
+	if len(deviation.Settings) > 0 {
+		return b.settings.Apply(ctx, deviation)
+	}
+	if err := validateExpectedContent(deviation); err != nil {
+		return nil, err
+	}
+	return b.remediation.Remediate(ctx, deviation)
+}
+
+// validateExpectedContent checks the workflow expected in the repository of
+// deviation: Validate only checked the default source of each policy, not a
+// template rendered for the repository or a version it pins.
+func validateExpectedContent(deviation models.PolicyDeviation) error {
+	switch deviation.Action {
+	case models.PolicyActionCreate, models.PolicyActionUpdate, models.PolicyActionMigrate:
+	default:
+		return nil
+	}
+	if deviation.ExpectedContent == "" {
+		return nil
+	}
+	if err := workflow.Validate(deviation.ExpectedContent); err != nil {
+		return fmt.Errorf("validating expected content: %w", err)
+	}
+	return nil
+}
+
+func policyReport(result service.RemediationResult) report.Policy {
+	drift := result.Drift
+	entry := report.Policy{
+		Name:       drift.Policy.Name,
+		Action:     string(drift.Action),
+		PRURL:      result.PRURL,
+		Deviation:  &drift,
+		Content:    result.Content,
+		Comparison: drift.Comparison,
+	}
+
+	switch {
+	case drift.Action == models.PolicyActionInvalidConfig:
+		entry.Name = drift.TargetPath
+		entry.Status = report.StatusDrifted
+		entry.Message = drift.Reason
+	case drift.Action == models.PolicyActionUnpinned:
+		entry.Name = drift.TargetPath
+		entry.Status = report.StatusDrifted
+		entry.Message = "unpinned actions: " + strings.Join(drift.UnpinnedActions, ", ")
+	case drift.Action == models.PolicyActionExempt:
+		entry.Status = report.StatusExempt
+		entry.Message = fmt.Sprintf("%s (approved by %s until %s)", drift.Exemption.Reason, drift.Exemption.Approver, drift.Exemption.Expires.Format(time.DateOnly))
+	case result.Error != nil:
+		entry.Status = report.StatusErrored
+		entry.Message = result.Error.Error()
+	case result.Action == "skipped":
+		entry.Status = report.StatusSkipped
+	case result.Action == "reported":
+		entry.Status = report.StatusDrifted
+	default:
+		entry.Status = report.StatusRemediated
+	}
+
+	if len(drift.Settings) > 0 && entry.Message == "" {
+		kind := "settings"
+		if drift.Protection != nil {
+			kind = "protection"
+		}
+		entry.Message = kind + ": " + service.DescribeSettings(drift.Settings)
+	}
+
+	if result.AutoMergeReason != "" && entry.Message == "" {
+		entry.Message = "auto-merge not enabled: " + result.AutoMergeReason
+	}
+
+	return entry
 }
@@ -3,13 +3,24 @@ package orchestrator
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tracker-tv/github-policy-bots/internal/metrics"
+	"github.com/tracker-tv/github-policy-bots/internal/notify"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
 	"github.com/tracker-tv/github-policy-bots/internal/service"
 	serviceMocks "github.com/tracker-tv/github-policy-bots/internal/service/mocks"
+	"github.com/tracker-tv/github-policy-bots/internal/tracing"
 	"github.com/tracker-tv/github-policy-bots/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewGithubActionsBot(t *testing.T) {
@@ -29,6 +40,7 @@ func TestRun_Success(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repos := []models.Repository{
@@ -64,13 +76,13 @@ func TestRun_Success(t *testing.T) {
 		EXPECT().
 		Ensure(mock.Anything, repos[0], []string{"Dockerfile", "main.go"}).
 		Once().
-		Return([]models.PolicyDeviation{drift}, nil)
+		Return(service.Evaluation{Deviations: []models.PolicyDeviation{drift}}, nil)
 
 	policySvc.
 		EXPECT().
 		Ensure(mock.Anything, repos[1], []string{"README.md"}).
 		Once().
-		Return([]models.PolicyDeviation{}, nil)
+		Return(service.Evaluation{}, nil)
 
 	remediationSvc.
 		EXPECT().
@@ -96,6 +108,7 @@ func TestRun_SkipsArchivedRepos(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repos := []models.Repository{
@@ -119,7 +132,7 @@ func TestRun_SkipsArchivedRepos(t *testing.T) {
 		EXPECT().
 		Ensure(mock.Anything, repos[0], []string{"main.go"}).
 		Once().
-		Return([]models.PolicyDeviation{}, nil)
+		Return(service.Evaluation{}, nil)
 
 	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc)
 	results, err := bot.Run(ctx)
@@ -132,6 +145,7 @@ func TestRun_ListAllError(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repoSvc.
@@ -148,10 +162,78 @@ func TestRun_ListAllError(t *testing.T) {
 	assert.Contains(t, err.Error(), "API error")
 }
 
+func TestRun_InvalidPolicies(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repos := []models.Repository{{Name: "repo1", FullName: "org/repo1"}}
+	drift := models.PolicyDeviation{Repository: repos[0], Policy: models.PolicyWorkflow{Name: "dockerfile"}, Action: models.PolicyActionCreate}
+
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(map[string]error{"go": errors.New("invalid workflow")})
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return(repos, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod", "Dockerfile"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repos[0], []string{"go.mod", "Dockerfile"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{drift},
+		Applied:    []string{"dockerfile", "go"},
+		Errors:     map[string]error{"go": errors.New("invalid policy: invalid workflow")},
+	}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, drift).Once().Return(&service.RemediationResult{Drift: drift, Action: "created"}, nil)
+
+	var runReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithReport(&runReport))
+	results, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 2) {
+		return
+	}
+	assert.Equal(t, "created", results[0].Action)
+	assert.Equal(t, "go", results[1].Drift.Policy.Name)
+	assert.Equal(t, repos[0], results[1].Drift.Repository)
+	assert.EqualError(t, results[1].Error, "invalid policy: invalid workflow")
+	assert.Equal(t, []report.Policy{
+		{Name: "dockerfile", Status: report.StatusRemediated, Action: "create"},
+		{Name: "go", Status: report.StatusErrored, Message: "invalid policy: invalid workflow"},
+	}, summaries(runReport.Repositories[0].Policies))
+}
+
+func TestRun_InvalidRenderedContent(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	deviation := models.PolicyDeviation{
+		Repository:      repo,
+		Policy:          models.PolicyWorkflow{Name: "go", Template: true},
+		Action:          models.PolicyActionCreate,
+		ExpectedContent: "on: push\njobs:\n  test:\n    runs-on: ${{ matrix.os\n",
+	}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return([]models.Repository{repo}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{deviation}}, nil)
+
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc)
+	results, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, results, 1) {
+		return
+	}
+	assert.ErrorContains(t, results[0].Error, "validating expected content: invalid workflow: line 4: unclosed expression")
+	remediationSvc.AssertNotCalled(t, "Remediate", mock.Anything, mock.Anything)
+}
+
 func TestRun_ListFilesErrorContinues(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repos := []models.Repository{
@@ -187,7 +269,7 @@ func TestRun_ListFilesErrorContinues(t *testing.T) {
 		EXPECT().
 		Ensure(mock.Anything, repos[1], []string{"Dockerfile"}).
 		Once().
-		Return([]models.PolicyDeviation{drift}, nil)
+		Return(service.Evaluation{Deviations: []models.PolicyDeviation{drift}}, nil)
 
 	remediationSvc.
 		EXPECT().
@@ -211,6 +293,7 @@ func TestRun_EnsureErrorContinues(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repos := []models.Repository{
@@ -246,13 +329,13 @@ func TestRun_EnsureErrorContinues(t *testing.T) {
 		EXPECT().
 		Ensure(mock.Anything, repos[0], []string{"Dockerfile"}).
 		Once().
-		Return(nil, errors.New("policy check failed"))
+		Return(service.Evaluation{}, errors.New("policy check failed"))
 
 	policySvc.
 		EXPECT().
 		Ensure(mock.Anything, repos[1], []string{"Dockerfile"}).
 		Once().
-		Return([]models.PolicyDeviation{drift}, nil)
+		Return(service.Evaluation{Deviations: []models.PolicyDeviation{drift}}, nil)
 
 	remediationSvc.
 		EXPECT().
@@ -276,6 +359,7 @@ func TestRun_EmptyRepos(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repoSvc.
@@ -295,6 +379,7 @@ func TestRun_MultipleResultsAcrossRepos(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repos := []models.Repository{
@@ -340,13 +425,13 @@ func TestRun_MultipleResultsAcrossRepos(t *testing.T) {
 		EXPECT().
 		Ensure(mock.Anything, repos[0], []string{"Dockerfile", "main.go"}).
 		Once().
-		Return([]models.PolicyDeviation{drift1, drift2}, nil)
+		Return(service.Evaluation{Deviations: []models.PolicyDeviation{drift1, drift2}}, nil)
 
 	policySvc.
 		EXPECT().
 		Ensure(mock.Anything, repos[1], []string{"Dockerfile"}).
 		Once().
-		Return([]models.PolicyDeviation{drift3}, nil)
+		Return(service.Evaluation{Deviations: []models.PolicyDeviation{drift3}}, nil)
 
 	remediationSvc.
 		EXPECT().
@@ -377,6 +462,7 @@ func TestRun_RemediationErrorContinues(t *testing.T) {
 	ctx := context.Background()
 	repoSvc := serviceMocks.NewMockRepositoryService(t)
 	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
 	remediationSvc := serviceMocks.NewMockRemediationService(t)
 
 	repos := []models.Repository{
@@ -410,7 +496,7 @@ func TestRun_RemediationErrorContinues(t *testing.T) {
 		EXPECT().
 		Ensure(mock.Anything, repos[0], []string{"Dockerfile", "main.go"}).
 		Once().
-		Return([]models.PolicyDeviation{drift1, drift2}, nil)
+		Return(service.Evaluation{Deviations: []models.PolicyDeviation{drift1, drift2}}, nil)
 
 	remediationSvc.
 		EXPECT().
@@ -433,3 +519,541 @@ func TestRun_RemediationErrorContinues(t *testing.T) {
 	assert.Nil(t, results[1].Error)
 	assert.Equal(t, "created", results[1].Action)
 }
+
+func TestRun_WithReport(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repos := []models.Repository{
+		{Name: "repo1", FullName: "org/repo1"},
+		{Name: "repo2", FullName: "org/repo2"},
+		{Name: "repo3", FullName: "org/repo3"},
+	}
+
+	created := models.PolicyDeviation{Repository: repos[0], Policy: models.PolicyWorkflow{Name: "dockerfile"}, Action: models.PolicyActionCreate}
+	failed := models.PolicyDeviation{Repository: repos[0], Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionUpdate}
+	invalid := models.PolicyDeviation{Repository: repos[1], Action: models.PolicyActionInvalidConfig, TargetPath: ".github/policy-bot.yml", Reason: "unknown grouping"}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return(repos, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"Dockerfile"}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo2").Once().Return([]string{"Dockerfile"}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo3").Once().Return(nil, errors.New("empty repo"))
+
+	// Policies which do not apply to the repository, e.g. python, are not reported
+	policySvc.EXPECT().Ensure(mock.Anything, repos[0], []string{"Dockerfile"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{created, failed},
+		Applied:    []string{"dockerfile", "go", "node"},
+	}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repos[1], []string{"Dockerfile"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{invalid}}, nil)
+
+	remediationSvc.EXPECT().Remediate(mock.Anything, created).Once().Return(&service.RemediationResult{Drift: created, Action: "created", PRURL: "url1"}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, failed).Once().Return(nil, errors.New("boom"))
+	remediationSvc.EXPECT().Remediate(mock.Anything, invalid).Once().Return(&service.RemediationResult{Drift: invalid, Action: "skipped"}, nil)
+
+	var runReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithReport(&runReport))
+	_, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.False(t, runReport.StartedAt.IsZero())
+	assert.False(t, runReport.FinishedAt.IsZero())
+	assert.Len(t, runReport.Repositories, 3)
+
+	assert.Equal(t, []report.Policy{
+		{Name: "dockerfile", Status: report.StatusRemediated, Action: "create", PRURL: "url1"},
+		{Name: "go", Status: report.StatusErrored, Action: "update", Message: "boom"},
+		{Name: "node", Status: report.StatusCompliant},
+	}, summaries(runReport.Repositories[0].Policies))
+
+	assert.Equal(t, []report.Policy{
+		{Name: ".github/policy-bot.yml", Status: report.StatusDrifted, Action: "invalid-config", Message: "unknown grouping"},
+	}, summaries(runReport.Repositories[1].Policies))
+
+	assert.Equal(t, created, *runReport.Repositories[0].Policies[0].Deviation)
+	assert.Nil(t, runReport.Repositories[0].Policies[2].Deviation)
+
+	assert.Equal(t, "listing files: empty repo", runReport.Repositories[2].Error)
+	assert.Empty(t, runReport.Repositories[2].Policies)
+}
+
+func TestRun_WithMetrics(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repos := []models.Repository{
+		{Name: "repo1", FullName: "org/repo1"},
+		{Name: "repo2", FullName: "org/repo2"},
+		{Name: "archived", FullName: "org/archived", Archived: true},
+	}
+
+	created := models.PolicyDeviation{Repository: repos[0], Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionCreate}
+	failed := models.PolicyDeviation{Repository: repos[1], Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionUpdate}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return(repos, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo2").Once().Return([]string{"go.mod"}, nil)
+
+	policySvc.EXPECT().Ensure(mock.Anything, repos[0], []string{"go.mod"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{created}, Applied: []string{"go", "node"}}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repos[1], []string{"go.mod"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{failed}, Applied: []string{"go", "node"}}, nil)
+
+	remediationSvc.EXPECT().Remediate(mock.Anything, created).Once().Return(&service.RemediationResult{Drift: created, Action: "created"}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, failed).Once().Return(nil, errors.New("boom"))
+
+	m := metrics.New()
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithMetrics(m))
+	_, err := bot.Run(ctx)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+
+	assert.Contains(t, out, "policybot_repositories_scanned_total 2")
+	assert.Contains(t, out, `policybot_deviations_total{action="create",policy="go"} 1`)
+	assert.Contains(t, out, `policybot_deviations_total{action="update",policy="go"} 1`)
+	assert.Contains(t, out, `policybot_remediations_total{outcome="created",policy="go"} 1`)
+	assert.Contains(t, out, `policybot_remediations_total{outcome="error",policy="go"} 1`)
+	assert.Contains(t, out, `policybot_policy_results{policy="go",status="errored"} 1`)
+	assert.Contains(t, out, `policybot_policy_results{policy="go",status="remediated"} 1`)
+	assert.Contains(t, out, `policybot_policy_results{policy="node",status="compliant"} 2`)
+}
+
+func TestRun_RecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	deviation := models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionUpdate, TargetPath: ".github/workflows/go.yml"}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return([]models.Repository{repo}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{deviation}}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, deviation).Once().Return(nil, errors.New("boom"))
+
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc)
+	_, err := bot.Run(ctx)
+	assert.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	assert.Len(t, spans, 6)
+
+	assert.Equal(t, spans["Run"].SpanContext().SpanID(), spans["Validate"].Parent().SpanID())
+	assert.Equal(t, spans["Run"].SpanContext().SpanID(), spans["repository"].Parent().SpanID())
+	for _, name := range []string{"ListFiles", "Ensure", "Remediate"} {
+		assert.Equal(t, spans["repository"].SpanContext().SpanID(), spans[name].Parent().SpanID(), name)
+	}
+
+	assert.Contains(t, spans["repository"].Attributes(), tracing.Repo("org/repo1"))
+	assert.Contains(t, spans["Remediate"].Attributes(), tracing.Policy("go"))
+	assert.Contains(t, spans["Remediate"].Attributes(), tracing.Action("update"))
+	assert.Equal(t, codes.Error, spans["Remediate"].Status().Code)
+}
+
+type fakeNotifier struct {
+	remediations []service.RemediationResult
+	summaries    []notify.Summary
+	err          error
+}
+
+func (n *fakeNotifier) Remediated(_ context.Context, result service.RemediationResult) error {
+	n.remediations = append(n.remediations, result)
+	return n.err
+}
+
+func (n *fakeNotifier) RunFinished(_ context.Context, summary notify.Summary) error {
+	n.summaries = append(n.summaries, summary)
+	return n.err
+}
+
+func TestRun_WithNotifiers(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repos := []models.Repository{
+		{Name: "repo1", FullName: "org/repo1"},
+		{Name: "repo2", FullName: "org/repo2"},
+	}
+	created := models.PolicyDeviation{Repository: repos[0], Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionCreate}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return(repos, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo2").Once().Return([]string{"go.mod"}, nil)
+
+	policySvc.EXPECT().Ensure(mock.Anything, repos[0], []string{"go.mod"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{created}, Applied: []string{"go"}}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repos[1], []string{"go.mod"}).Once().Return(service.Evaluation{Applied: []string{"go"}}, nil)
+
+	remediationSvc.EXPECT().Remediate(mock.Anything, created).Once().Return(&service.RemediationResult{Drift: created, Action: "created"}, nil)
+
+	failing := &fakeNotifier{err: errors.New("webhook unreachable")}
+	n := &fakeNotifier{}
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithNotifiers(failing, n))
+	results, err := bot.Run(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	assert.Len(t, failing.summaries, 1)
+	assert.Equal(t, results, n.remediations)
+	if assert.Len(t, n.summaries, 1) {
+		summary := n.summaries[0]
+		assert.Equal(t, 2, summary.Repositories)
+		assert.Equal(t, 1, summary.Counts[report.StatusCompliant])
+		assert.Equal(t, 1, summary.Counts[report.StatusRemediated])
+		assert.Equal(t, results, summary.Results)
+		assert.Empty(t, summary.Error)
+	}
+}
+
+func TestRun_NotifiesFailedRun(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return(nil, errors.New("502 Bad Gateway"))
+
+	n := &fakeNotifier{}
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithNotifiers(n))
+	_, err := bot.Run(ctx)
+	assert.Error(t, err)
+
+	if assert.Len(t, n.summaries, 1) {
+		assert.Equal(t, "502 Bad Gateway", n.summaries[0].Error)
+	}
+}
+
+func TestRunRepository(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	deviation := models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionCreate}
+
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{Deviations: []models.PolicyDeviation{deviation}}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, deviation).Once().Return(&service.RemediationResult{Drift: deviation, Action: "created"}, nil)
+
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc)
+	results := bot.RunRepository(ctx, repo)
+
+	assert.Equal(t, []service.RemediationResult{{Drift: deviation, Action: "created"}}, results)
+}
+
+func TestRunRepository_WithReport(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	deviation := models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: "node"}, Action: models.PolicyActionCreate}
+
+	// The content of a compliant policy is the workflow committed in the repository
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod", "package.json"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod", "package.json"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{deviation},
+		Applied:    []string{"go", "node"},
+		Workflows: []service.RenderedWorkflow{
+			{Policy: models.PolicyWorkflow{Name: "go"}, TargetPath: ".github/workflows/go.yml", Content: "on: push\n"},
+		},
+	}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, deviation).Once().Return(&service.RemediationResult{Drift: deviation, Action: "created", Content: "on: pull_request\n"}, nil)
+
+	var repoReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithReport(&repoReport))
+	bot.RunRepository(ctx, repo)
+
+	assert.False(t, repoReport.StartedAt.IsZero())
+	assert.False(t, repoReport.FinishedAt.IsZero())
+	assert.Len(t, repoReport.Repositories, 1)
+	assert.Equal(t, "org/repo1", repoReport.Repositories[0].FullName)
+	assert.Equal(t, []report.Policy{
+		{Name: "node", Status: report.StatusRemediated, Action: "create", Content: "on: pull_request\n"},
+		{Name: "go", Status: report.StatusCompliant, Content: "on: push\n"},
+	}, summaries(repoReport.Repositories[0].Policies))
+}
+
+func TestRun_WithSettings(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+	settingsSvc := serviceMocks.NewMockSettingsService(t)
+
+	repos := []models.Repository{
+		{Name: "repo1", FullName: "org/repo1"},
+		{Name: "repo2", FullName: "org/repo2"},
+	}
+	changes := []models.SettingChange{{Setting: "has_wiki", Current: "true", Expected: "false"}}
+	applied := models.PolicyDeviation{Repository: repos[0], Policy: models.PolicyWorkflow{Name: "merge-settings"}, Action: models.PolicyActionSettings, Settings: changes}
+	reported := models.PolicyDeviation{Repository: repos[1], Policy: models.PolicyWorkflow{Name: "features"}, Action: models.PolicyActionSettings, Settings: changes}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return(repos, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo2").Once().Return([]string{"go.mod"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, mock.Anything, []string{"go.mod"}).Times(2).Return(service.Evaluation{Applied: []string{"go"}}, nil)
+
+	// Settings policies which do not match the repository, e.g. features in repo1, are not reported
+	settingsSvc.EXPECT().Ensure(mock.Anything, repos[0], []string{"go.mod"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{applied},
+		Applied:    []string{"merge-settings"},
+	}, nil)
+	settingsSvc.EXPECT().Ensure(mock.Anything, repos[1], []string{"go.mod"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{reported},
+		Applied:    []string{"features", "merge-settings"},
+	}, nil)
+	settingsSvc.EXPECT().Apply(mock.Anything, applied).Once().Return(&service.RemediationResult{Drift: applied, Action: "updated"}, nil)
+	settingsSvc.EXPECT().Apply(mock.Anything, reported).Once().Return(&service.RemediationResult{Drift: reported, Action: "reported"}, nil)
+
+	var runReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithSettings(settingsSvc), WithReport(&runReport))
+	results, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, []report.Policy{
+		{Name: "merge-settings", Status: report.StatusRemediated, Action: "settings", Message: "settings: has_wiki: true -> false"},
+		{Name: "go", Status: report.StatusCompliant},
+	}, summaries(runReport.Repositories[0].Policies))
+	assert.Equal(t, []report.Policy{
+		{Name: "features", Status: report.StatusDrifted, Action: "settings", Message: "settings: has_wiki: true -> false"},
+		{Name: "go", Status: report.StatusCompliant},
+		{Name: "merge-settings", Status: report.StatusCompliant},
+	}, summaries(runReport.Repositories[1].Policies))
+}
+
+func TestRun_SettingsErrorContinues(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+	settingsSvc := serviceMocks.NewMockSettingsService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return([]models.Repository{repo}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{}, nil)
+	settingsSvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{}, errors.New("getting repository settings: 403 Forbidden"))
+
+	var runReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithSettings(settingsSvc), WithReport(&runReport))
+	results, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, "checking settings: getting repository settings: 403 Forbidden", runReport.Repositories[0].Error)
+}
+
+func TestRun_WithProtection(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+	settingsSvc := serviceMocks.NewMockSettingsService(t)
+	protectionSvc := serviceMocks.NewMockProtectionService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	settings := models.PolicyDeviation{
+		Repository: repo,
+		Policy:     models.PolicyWorkflow{Name: "merge-settings"},
+		Action:     models.PolicyActionSettings,
+		Settings:   []models.SettingChange{{Setting: "has_wiki", Current: "true", Expected: "false"}},
+	}
+	protection := models.PolicyDeviation{
+		Repository: repo,
+		Policy:     models.PolicyWorkflow{Name: "default-branch"},
+		Action:     models.PolicyActionProtection,
+		Settings:   []models.SettingChange{{Setting: "linear_history", Current: "false", Expected: "true"}},
+		Protection: &models.BranchProtection{Branch: "main", LinearHistory: true},
+	}
+
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return([]models.Repository{repo}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	workflows := service.Evaluation{
+		Applied:   []string{"go"},
+		Workflows: []service.RenderedWorkflow{{Policy: models.PolicyWorkflow{Name: "go"}, TargetPath: ".github/workflows/go.yml", Content: "on: pull_request\n"}},
+	}
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(workflows, nil)
+	settingsSvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{settings},
+		Applied:    []string{"merge-settings"},
+	}, nil)
+	settingsSvc.EXPECT().Apply(mock.Anything, settings).Once().Return(&service.RemediationResult{Drift: settings, Action: "reported"}, nil)
+	// Protection deviations carry settings changes but are applied by the protection service
+	// Protection policies which do not match the repository, e.g. release-branches, are not reported
+	protectionSvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}, workflows).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{protection},
+		Applied:    []string{"default-branch", "linear-history", "required-checks"},
+		Errors:     map[string]error{"required-checks": errors.New("reading managed checks: .github/workflows/go.yml: yaml: invalid")},
+	}, nil)
+	protectionSvc.EXPECT().Apply(mock.Anything, protection).Once().Return(&service.RemediationResult{Drift: protection, Action: "updated"}, nil)
+
+	var runReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithSettings(settingsSvc), WithProtection(protectionSvc), WithReport(&runReport))
+	results, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, []report.Policy{
+		{Name: "merge-settings", Status: report.StatusDrifted, Action: "settings", Message: "settings: has_wiki: true -> false"},
+		{Name: "default-branch", Status: report.StatusRemediated, Action: "protection", Message: "protection: linear_history: false -> true"},
+		{Name: "required-checks", Status: report.StatusErrored, Message: "reading managed checks: .github/workflows/go.yml: yaml: invalid"},
+		{Name: "go", Status: report.StatusCompliant, Content: "on: pull_request\n"},
+		{Name: "linear-history", Status: report.StatusCompliant},
+	}, summaries(runReport.Repositories[0].Policies))
+}
+
+func TestRun_ProtectionErrorContinues(t *testing.T) {
+	ctx := context.Background()
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(nil)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+	protectionSvc := serviceMocks.NewMockProtectionService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	repoSvc.EXPECT().ListAll(mock.Anything).Once().Return([]models.Repository{repo}, nil)
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}).Once().Return(service.Evaluation{}, nil)
+	protectionSvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod"}, service.Evaluation{}).Once().Return(service.Evaluation{}, errors.New("getting protection of main: 403 Forbidden"))
+
+	var runReport report.Report
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithProtection(protectionSvc), WithReport(&runReport))
+	results, err := bot.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, "checking protection: getting protection of main: 403 Forbidden", runReport.Repositories[0].Error)
+}
+
+func TestRunRepository_InvalidPolicies(t *testing.T) {
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	repo := models.Repository{Name: "repo1", FullName: "org/repo1"}
+	drift := models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: "dockerfile"}, Action: models.PolicyActionCreate}
+
+	policySvc.EXPECT().Validate(mock.Anything).Once().Return(map[string]error{"go": errors.New("invalid workflow")})
+	repoSvc.EXPECT().ListFiles(mock.Anything, "repo1").Once().Return([]string{"go.mod", "Dockerfile"}, nil)
+	policySvc.EXPECT().Ensure(mock.Anything, repo, []string{"go.mod", "Dockerfile"}).Once().Return(service.Evaluation{
+		Deviations: []models.PolicyDeviation{drift},
+		Applied:    []string{"dockerfile", "go"},
+		Errors:     map[string]error{"go": errors.New("invalid policy: invalid workflow")},
+	}, nil)
+	remediationSvc.EXPECT().Remediate(mock.Anything, drift).Once().Return(&service.RemediationResult{Drift: drift, Action: "created"}, nil)
+
+	n := &fakeNotifier{}
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc, WithNotifiers(n))
+	results := bot.RunRepository(context.Background(), repo)
+
+	if !assert.Len(t, results, 2) {
+		return
+	}
+	assert.Equal(t, "created", results[0].Action)
+	assert.EqualError(t, results[1].Error, "invalid policy: invalid workflow")
+	assert.Equal(t, results, n.remediations)
+}
+
+func TestRunRepository_SkipsArchived(t *testing.T) {
+	repoSvc := serviceMocks.NewMockRepositoryService(t)
+	policySvc := serviceMocks.NewMockPolicyService(t)
+	remediationSvc := serviceMocks.NewMockRemediationService(t)
+
+	bot := NewGithubActionsBot(repoSvc, policySvc, remediationSvc)
+	results := bot.RunRepository(context.Background(), models.Repository{Name: "old", FullName: "org/old", Archived: true})
+
+	assert.Empty(t, results)
+}
+
+func TestPolicyReport_Exempt(t *testing.T) {
+	drift := models.PolicyDeviation{
+		Policy:    models.PolicyWorkflow{Name: "dockerfile"},
+		Action:    models.PolicyActionExempt,
+		Exemption: &models.Exemption{Reason: "vendor image", Approver: "platform", Expires: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	entry := policyReport(service.RemediationResult{Drift: drift, Action: "skipped"})
+
+	assert.Equal(t, report.StatusExempt, entry.Status)
+	assert.Equal(t, "vendor image (approved by platform until 2026-12-31)", entry.Message)
+}
+
+func TestPolicyReport_Unpinned(t *testing.T) {
+	drift := models.PolicyDeviation{
+		Action:          models.PolicyActionUnpinned,
+		TargetPath:      ".github/workflows/release.yml",
+		UnpinnedActions: []string{"actions/checkout@v4", "softprops/action-gh-release@v2"},
+	}
+
+	entry := policyReport(service.RemediationResult{Drift: drift, Action: "skipped"})
+
+	assert.Equal(t, ".github/workflows/release.yml", entry.Name)
+	assert.Equal(t, report.StatusDrifted, entry.Status)
+	assert.Equal(t, "unpinned actions: actions/checkout@v4, softprops/action-gh-release@v2", entry.Message)
+}
+
+func TestPolicyReport_SettingsExempt(t *testing.T) {
+	drift := models.PolicyDeviation{
+		Policy:    models.PolicyWorkflow{Name: "merge-settings"},
+		Action:    models.PolicyActionExempt,
+		Settings:  []models.SettingChange{{Setting: "has_wiki", Current: "true", Expected: "false"}},
+		Exemption: &models.Exemption{Reason: "wiki in use", Approver: "platform", Expires: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	entry := policyReport(service.RemediationResult{Drift: drift, Action: "skipped"})
+
+	assert.Equal(t, report.StatusExempt, entry.Status)
+	assert.Equal(t, "wiki in use (approved by platform until 2026-12-31)", entry.Message)
+}
+
+func TestPolicyReport_Comparison(t *testing.T) {
+	drift := models.PolicyDeviation{
+		Policy:     models.PolicyWorkflow{Name: "go", Comparison: models.ComparisonNormalized},
+		Action:     models.PolicyActionUpdate,
+		Comparison: models.ComparisonNormalized,
+	}
+
+	entry := policyReport(service.RemediationResult{Drift: drift, Action: "updated"})
+
+	assert.Equal(t, report.StatusRemediated, entry.Status)
+	assert.Equal(t, models.ComparisonNormalized, entry.Comparison)
+}
+
+// summaries drops the fields varying between runs or duplicating the deviation.
+func summaries(policies []report.Policy) []report.Policy {
+	out := make([]report.Policy, len(policies))
+	for i, p := range policies {
+		p.Duration = 0
+		p.Deviation = nil
+		out[i] = p
+	}
+	return out
+}
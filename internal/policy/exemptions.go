@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+const exemptionDateLayout = "2006-01-02"
+
+type exemptionJSON struct {
+	Repository string `json:"repository"`
+	Policy     string `json:"policy"`
+	Reason     string `json:"reason"`
+	Approver   string `json:"approver"`
+	Expires    string `json:"expires"` // YYYY-MM-DD
+}
+
+// ExemptionsFromJSON parses the central exemptions registry. Every field is
+// required, an exemption without an expiry date would never be reviewed again.
+func ExemptionsFromJSON(data []byte) ([]models.Exemption, error) {
+	var entries []exemptionJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	exemptions := make([]models.Exemption, 0, len(entries))
+	for i, e := range entries {
+		exemption, err := e.toModel()
+		if err != nil {
+			return nil, fmt.Errorf("exemption %d (%s/%s): %w", i, e.Repository, e.Policy, err)
+		}
+		exemptions = append(exemptions, exemption)
+	}
+	return exemptions, nil
+}
+
+func (e exemptionJSON) toModel() (models.Exemption, error) {
+	switch {
+	case e.Repository == "":
+		return models.Exemption{}, errors.New("repository is required")
+	case e.Policy == "":
+		return models.Exemption{}, errors.New("policy is required")
+	case e.Reason == "":
+		return models.Exemption{}, errors.New("reason is required")
+	case e.Approver == "":
+		return models.Exemption{}, errors.New("approver is required")
+	}
+
+	expires, err := time.Parse(exemptionDateLayout, e.Expires)
+	if err != nil {
+		return models.Exemption{}, fmt.Errorf("invalid expires date %q, expected YYYY-MM-DD", e.Expires)
+	}
+
+	return models.Exemption{
+		Repository: e.Repository,
+		Policy:     e.Policy,
+		Reason:     e.Reason,
+		Approver:   e.Approver,
+		Expires:    expires,
+	}, nil
+}
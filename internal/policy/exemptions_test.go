@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExemptionsFromJSON(t *testing.T) {
+	data := []byte(`[
+		{
+			"repository": "legacy-api",
+			"policy": "dockerfile",
+			"reason": "image built by the vendor",
+			"approver": "platform-team",
+			"expires": "2026-12-31"
+		}
+	]`)
+
+	exemptions, err := ExemptionsFromJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exemptions) != 1 {
+		t.Fatalf("expected 1 exemption, got %d", len(exemptions))
+	}
+
+	e := exemptions[0]
+	if e.Repository != "legacy-api" || e.Policy != "dockerfile" || e.Approver != "platform-team" {
+		t.Errorf("unexpected exemption: %+v", e)
+	}
+	if want := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC); !e.Expires.Equal(want) {
+		t.Errorf("Expires: expected %v, got %v", want, e.Expires)
+	}
+}
+
+func TestExemptionsFromJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "missing approver",
+			data:    `[{"repository": "r", "policy": "p", "reason": "x", "expires": "2026-12-31"}]`,
+			wantErr: "approver is required",
+		},
+		{
+			name:    "missing expiry",
+			data:    `[{"repository": "r", "policy": "p", "reason": "x", "approver": "a"}]`,
+			wantErr: "invalid expires date",
+		},
+		{
+			name:    "malformed expiry",
+			data:    `[{"repository": "r", "policy": "p", "reason": "x", "approver": "a", "expires": "31/12/2026"}]`,
+			wantErr: "invalid expires date",
+		},
+		{
+			name:    "invalid JSON",
+			data:    `{`,
+			wantErr: "unexpected end of JSON input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExemptionsFromJSON([]byte(tt.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
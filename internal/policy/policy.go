@@ -2,6 +2,7 @@ package policy
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/tracker-tv/github-policy-bots/models"
 )
@@ -11,5 +12,37 @@ func FromJSON(data []byte) ([]models.PolicyWorkflow, error) {
 	if err := json.Unmarshal(data, &workflows); err != nil {
 		return nil, err
 	}
+	for _, wf := range workflows {
+		if err := validate(wf); err != nil {
+			return nil, fmt.Errorf("policy %s: %w", wf.Name, err)
+		}
+	}
 	return workflows, nil
 }
+
+func validate(wf models.PolicyWorkflow) error {
+	switch wf.AutoMerge {
+	case "", models.MergeMethodMerge, models.MergeMethodSquash, models.MergeMethodRebase:
+	default:
+		return fmt.Errorf("unknown auto_merge method %q", wf.AutoMerge)
+	}
+	switch wf.Ensure {
+	case "", models.PolicyEnsurePresent, models.PolicyEnsureAbsent:
+	default:
+		return fmt.Errorf("unknown ensure mode %q", wf.Ensure)
+	}
+	switch wf.Severity {
+	case "", models.SeverityError, models.SeverityWarning, models.SeverityNote:
+	default:
+		return fmt.Errorf("unknown severity %q", wf.Severity)
+	}
+	switch wf.Comparison {
+	case "", models.ComparisonExact, models.ComparisonNormalized, models.ComparisonSemantic:
+	default:
+		return fmt.Errorf("unknown comparison %q", wf.Comparison)
+	}
+	if wf.OnlyIfManaged && wf.Ensure != models.PolicyEnsureAbsent {
+		return fmt.Errorf("only_if_managed requires ensure %q", models.PolicyEnsureAbsent)
+	}
+	return nil
+}
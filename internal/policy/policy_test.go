@@ -1,6 +1,10 @@
 package policy
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
 
 func TestFromJSON(t *testing.T) {
 	data := []byte(`[
@@ -60,3 +64,108 @@ func TestFromJSON(t *testing.T) {
 	}
 
 }
+
+func TestFromJSON_PullRequestOptions(t *testing.T) {
+	data := []byte(`[
+		{
+			"name": "dockerfile",
+			"match_file": "**/Dockerfile*",
+			"source": "https://example.com/workflow",
+			"pull_request": {
+				"labels": ["docker"],
+				"team_reviewers": ["platform"],
+				"draft": true,
+				"reviewers_from_codeowners": true
+			}
+		}
+	]`)
+
+	workflows, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pr := workflows[0].PullRequest
+	if len(pr.Labels) != 1 || pr.Labels[0] != "docker" {
+		t.Errorf("Labels: expected [docker], got %v", pr.Labels)
+	}
+	if len(pr.TeamReviewers) != 1 || pr.TeamReviewers[0] != "platform" {
+		t.Errorf("TeamReviewers: expected [platform], got %v", pr.TeamReviewers)
+	}
+	if !pr.Draft {
+		t.Error("Draft: expected true")
+	}
+	if !pr.ReviewersFromCodeowners {
+		t.Error("ReviewersFromCodeowners: expected true")
+	}
+}
+
+func TestFromJSON_AutoMerge(t *testing.T) {
+	workflows, err := FromJSON([]byte(`[{"name": "header", "match_file": "*", "source": "https://example.com", "auto_merge": "squash"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workflows[0].AutoMerge != models.MergeMethodSquash {
+		t.Errorf("AutoMerge: expected %q, got %q", models.MergeMethodSquash, workflows[0].AutoMerge)
+	}
+}
+
+func TestFromJSON_InvalidAutoMerge(t *testing.T) {
+	_, err := FromJSON([]byte(`[{"name": "header", "match_file": "*", "source": "https://example.com", "auto_merge": "fast-forward"}]`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown merge method")
+	}
+}
+
+func TestFromJSON_EnsureAbsent(t *testing.T) {
+	workflows, err := FromJSON([]byte(`[{"name": "docker-publish", "ensure": "absent", "only_if_managed": true}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workflows[0].Ensure != models.PolicyEnsureAbsent {
+		t.Errorf("Ensure: expected %q, got %q", models.PolicyEnsureAbsent, workflows[0].Ensure)
+	}
+	if !workflows[0].OnlyIfManaged {
+		t.Error("OnlyIfManaged: expected true")
+	}
+}
+
+func TestFromJSON_InvalidEnsure(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "unknown mode", data: `[{"name": "a", "ensure": "missing"}]`},
+		{name: "only_if_managed without absent", data: `[{"name": "a", "only_if_managed": true}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromJSON([]byte(tt.data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestFromJSON_InvalidSeverity(t *testing.T) {
+	_, err := FromJSON([]byte(`[{"name": "header", "match_file": "*", "source": "https://example.com", "severity": "critical"}]`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown severity")
+	}
+}
+
+func TestFromJSON_Comparison(t *testing.T) {
+	workflows, err := FromJSON([]byte(`[{"name": "go", "match_file": "go.mod", "source": "https://example.com", "comparison": "semantic"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workflows[0].Comparison != models.ComparisonSemantic {
+		t.Errorf("comparison = %q, want %q", workflows[0].Comparison, models.ComparisonSemantic)
+	}
+
+	_, err = FromJSON([]byte(`[{"name": "go", "match_file": "go.mod", "source": "https://example.com", "comparison": "fuzzy"}]`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown comparison")
+	}
+}
@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// ProtectionFromJSON parses the branch protection policies.
+func ProtectionFromJSON(data []byte) ([]models.PolicyProtection, error) {
+	var policies []models.PolicyProtection
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		if err := validateProtection(p); err != nil {
+			return nil, fmt.Errorf("protection policy %s: %w", p.Name, err)
+		}
+	}
+	return policies, nil
+}
+
+func validateProtection(p models.PolicyProtection) error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	switch p.Target {
+	case "", models.ProtectionTargetBranch, models.ProtectionTargetRuleset:
+	default:
+		return fmt.Errorf("unknown target %q", p.Target)
+	}
+	switch p.Enforcement {
+	case "":
+	case "active", "evaluate":
+		if p.Target != models.ProtectionTargetRuleset {
+			return errors.New("enforcement only applies to rulesets")
+		}
+	default:
+		return fmt.Errorf("unknown enforcement %q", p.Enforcement)
+	}
+	switch p.Severity {
+	case "", models.SeverityError, models.SeverityWarning, models.SeverityNote:
+	default:
+		return fmt.Errorf("unknown severity %q", p.Severity)
+	}
+	// The limits of the GitHub API
+	if p.RequiredApprovals < 0 || p.RequiredApprovals > 6 {
+		return fmt.Errorf("required_approvals must be between 0 and 6, got %d", p.RequiredApprovals)
+	}
+	for _, check := range p.RequiredChecks {
+		if check == "" {
+			return errors.New("required_checks cannot contain an empty name")
+		}
+	}
+	return nil
+}
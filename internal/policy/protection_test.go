@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestProtectionFromJSON(t *testing.T) {
+	data := []byte(`[
+		{
+			"name": "default-branch",
+			"target": "ruleset",
+			"required_approvals": 1,
+			"required_checks": ["lint"],
+			"managed_checks": true,
+			"linear_history": true,
+			"enforce_admins": true
+		}
+	]`)
+
+	policies, err := ProtectionFromJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 policy, got %d", len(policies))
+	}
+
+	p := policies[0]
+	if p.Name != "default-branch" || p.Target != models.ProtectionTargetRuleset || p.RequiredApprovals != 1 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+	if !p.ManagedChecks || !p.LinearHistory || !p.EnforceAdmins || p.Apply {
+		t.Errorf("unexpected flags: %+v", p)
+	}
+	if len(p.RequiredChecks) != 1 || p.RequiredChecks[0] != "lint" {
+		t.Errorf("RequiredChecks: expected [lint], got %v", p.RequiredChecks)
+	}
+}
+
+func TestProtectionFromJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			data:    `[{"required_approvals": 1}]`,
+			wantErr: "name is required",
+		},
+		{
+			name:    "unknown target",
+			data:    `[{"name": "p", "target": "tag"}]`,
+			wantErr: `unknown target "tag"`,
+		},
+		{
+			name:    "unknown enforcement",
+			data:    `[{"name": "p", "target": "ruleset", "enforcement": "disabled"}]`,
+			wantErr: `unknown enforcement "disabled"`,
+		},
+		{
+			name:    "enforcement of a branch protection",
+			data:    `[{"name": "p", "enforcement": "evaluate"}]`,
+			wantErr: "enforcement only applies to rulesets",
+		},
+		{
+			name:    "too many approvals",
+			data:    `[{"name": "p", "required_approvals": 7}]`,
+			wantErr: "required_approvals must be between 0 and 6, got 7",
+		},
+		{
+			name:    "empty check",
+			data:    `[{"name": "p", "required_checks": [""]}]`,
+			wantErr: "required_checks cannot contain an empty name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ProtectionFromJSON([]byte(tt.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// SettingsFromJSON parses the repository settings policies.
+func SettingsFromJSON(data []byte) ([]models.PolicySettings, error) {
+	var policies []models.PolicySettings
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		if err := validateSettings(p); err != nil {
+			return nil, fmt.Errorf("settings policy %s: %w", p.Name, err)
+		}
+	}
+	return policies, nil
+}
+
+func validateSettings(p models.PolicySettings) error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	switch p.Severity {
+	case "", models.SeverityError, models.SeverityWarning, models.SeverityNote:
+	default:
+		return fmt.Errorf("unknown severity %q", p.Severity)
+	}
+
+	s := p.Settings
+	if s == (models.RepositorySettings{}) {
+		return errors.New("no setting to enforce")
+	}
+	// GitHub refuses to disable every merge method
+	if isFalse(s.AllowMergeCommit) && isFalse(s.AllowSquashMerge) && isFalse(s.AllowRebaseMerge) {
+		return errors.New("at least one merge method must be allowed")
+	}
+	return nil
+}
+
+func isFalse(b *bool) bool {
+	return b != nil && !*b
+}
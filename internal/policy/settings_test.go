@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSettingsFromJSON(t *testing.T) {
+	data := []byte(`[
+		{
+			"name": "merge-settings",
+			"settings": {
+				"allow_merge_commit": false,
+				"allow_squash_merge": true,
+				"delete_branch_on_merge": true
+			},
+			"apply": true
+		}
+	]`)
+
+	policies, err := SettingsFromJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 policy, got %d", len(policies))
+	}
+
+	p := policies[0]
+	if p.Name != "merge-settings" || !p.Apply || p.MatchFile != "" {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+	s := p.Settings
+	if s.AllowMergeCommit == nil || *s.AllowMergeCommit {
+		t.Errorf("AllowMergeCommit: expected false, got %v", s.AllowMergeCommit)
+	}
+	if s.DeleteBranchOnMerge == nil || !*s.DeleteBranchOnMerge {
+		t.Errorf("DeleteBranchOnMerge: expected true, got %v", s.DeleteBranchOnMerge)
+	}
+	if s.HasWiki != nil {
+		t.Errorf("HasWiki: expected unset, got %v", *s.HasWiki)
+	}
+}
+
+func TestSettingsFromJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			data:    `[{"settings": {"has_wiki": false}}]`,
+			wantErr: "name is required",
+		},
+		{
+			name:    "no setting",
+			data:    `[{"name": "empty", "settings": {}}]`,
+			wantErr: "no setting to enforce",
+		},
+		{
+			name:    "every merge method disabled",
+			data:    `[{"name": "p", "settings": {"allow_merge_commit": false, "allow_squash_merge": false, "allow_rebase_merge": false}}]`,
+			wantErr: "at least one merge method must be allowed",
+		},
+		{
+			name:    "unknown severity",
+			data:    `[{"name": "p", "settings": {"has_wiki": false}, "severity": "fatal"}]`,
+			wantErr: `unknown severity "fatal"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SettingsFromJSON([]byte(tt.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
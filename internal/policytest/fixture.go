@@ -0,0 +1,84 @@
+package policytest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is a repository the policies are evaluated against, read from a directory:
+//
+//	repository.yaml  metadata of the repository, optional
+//	files/           files of the default branch, with their content
+//	golden/          expected outcome, written by the update mode
+type Fixture struct {
+	Name       string
+	Repository models.Repository
+	Files      map[string]string // Content by path
+}
+
+type metadata struct {
+	Name     string   `yaml:"name"`
+	Private  bool     `yaml:"private"`
+	Archived bool     `yaml:"archived"`
+	Topics   []string `yaml:"topics"`
+}
+
+// LoadFixture reads the fixture of dir. The repository is named after dir
+// unless repository.yaml sets another name.
+func LoadFixture(dir string) (Fixture, error) {
+	meta := metadata{Name: filepath.Base(dir)}
+	data, err := os.ReadFile(filepath.Join(dir, "repository.yaml"))
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &meta); err != nil {
+			return Fixture{}, fmt.Errorf("parsing %s: %w", filepath.Join(dir, "repository.yaml"), err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return Fixture{}, err
+	}
+
+	files := make(map[string]string)
+	root := filepath.Join(dir, "files")
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return Fixture{}, fmt.Errorf("reading files of fixture %s: %w", filepath.Base(dir), err)
+	}
+
+	return Fixture{
+		Name: filepath.Base(dir),
+		Repository: models.Repository{
+			Name:     meta.Name,
+			FullName: org + "/" + meta.Name,
+			Private:  meta.Private,
+			Archived: meta.Archived,
+			Topics:   meta.Topics,
+		},
+		Files: files,
+	}, nil
+}
+
+// isFixture reports whether dir holds a fixture.
+func isFixture(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, "files"))
+	return err == nil && info.IsDir()
+}
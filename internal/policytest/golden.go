@@ -0,0 +1,147 @@
+package policytest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Layout of the golden directory of a fixture.
+const (
+	outcomeFile = "outcome.yaml" // Outcome, without the contents
+	contentDir  = "content"      // Content the pull requests would commit, by path
+)
+
+// Diff is a golden file that does not match the outcome.
+type Diff struct {
+	File     string // Path relative to the golden directory
+	Expected string // Content of the golden file, empty when it is missing
+	Actual   string // Content of the outcome, empty when it is unexpected
+}
+
+func (d Diff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "golden/%s differs", d.File)
+	switch {
+	case d.Expected == "":
+		b.WriteString(" (missing, run with -update to create it)")
+	case d.Actual == "":
+		b.WriteString(" (no longer produced)")
+	}
+	b.WriteString(":\n")
+	for _, line := range diffLines(d.Expected, d.Actual) {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func readGolden(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	return files, err
+}
+
+// writeGolden replaces the golden directory with files.
+func writeGolden(dir string, files map[string]string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compare(expected, actual map[string]string) []Diff {
+	paths := slices.Sorted(maps.Keys(expected))
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	var diffs []Diff
+	for _, path := range paths {
+		if expected[path] != actual[path] {
+			diffs = append(diffs, Diff{File: path, Expected: expected[path], Actual: actual[path]})
+		}
+	}
+	return diffs
+}
+
+// diffLines returns the lines of expected and actual prefixed like a unified
+// diff: " " when kept, "-" when removed and "+" when added.
+func diffLines(expected, actual string) []string {
+	a := splitLines(expected)
+	b := splitLines(actual)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			lines = append(lines, "+"+b[j])
+			j++
+		default:
+			lines = append(lines, "-"+a[i])
+			i++
+		}
+	}
+	return lines
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,263 @@
+// Package policytest checks policies against fixture repositories offline: it
+// evaluates them like a run would and compares the deviations and the content
+// the pull requests would commit with golden files.
+package policytest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/github/githubtest"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/internal/workflow"
+	"github.com/tracker-tv/github-policy-bots/models"
+	"gopkg.in/yaml.v3"
+)
+
+// org owns the fixture repositories, like every repository the bot manages.
+const org = "tracker-tv"
+
+// Outcome is what the policies find in a fixture.
+type Outcome struct {
+	Error      string      `yaml:"error,omitempty"` // Why the policies could not be evaluated
+	Deviations []Deviation `yaml:"deviations"`
+
+	Contents map[string]string `yaml:"-"` // Content the pull requests would commit, by path
+}
+
+// Deviation is the part of a models.PolicyDeviation that does not depend on
+// where the policies are evaluated.
+type Deviation struct {
+	Policy        string   `yaml:"policy,omitempty"` // Empty for an invalid repository configuration
+	Action        string   `yaml:"action"`
+	Path          string   `yaml:"path"`
+	PreviousPaths []string `yaml:"previous_paths,omitempty"`
+	Overrides     []string `yaml:"overrides,omitempty"`
+	Reason        string   `yaml:"reason,omitempty"`
+}
+
+// Result is the outcome of a fixture compared with its golden files.
+type Result struct {
+	Fixture string
+	Outcome Outcome
+	Diffs   []Diff // Golden files that differ, empty when the fixture passes
+	Updated bool   // The golden files were rewritten
+}
+
+// Passed reports whether the outcome matches the golden files.
+func (r Result) Passed() bool {
+	return len(r.Diffs) == 0
+}
+
+// Harness evaluates policies against fixtures.
+type Harness struct {
+	policies []models.PolicyWorkflow
+	sources  map[string]string
+	update   bool
+}
+
+// Option configures optional behaviour of the harness.
+type Option func(*Harness)
+
+// WithSources reads the sources whose URL starts with prefix from dir, e.g.
+// "https://raw.githubusercontent.com/tracker-tv/github-actions-ttv/refs/heads/main/"
+// from a local checkout. Sources no prefix maps fail to load.
+func WithSources(prefix, dir string) Option {
+	return func(h *Harness) {
+		h.sources[prefix] = dir
+	}
+}
+
+// WithUpdate rewrites the golden files with the outcome instead of comparing them.
+func WithUpdate(update bool) Option {
+	return func(h *Harness) {
+		h.update = update
+	}
+}
+
+func New(policies []models.PolicyWorkflow, opts ...Option) *Harness {
+	h := &Harness{policies: policies, sources: make(map[string]string)}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Run checks every fixture of dir, or dir itself when it is a fixture.
+func (h *Harness) Run(ctx context.Context, dir string) ([]Result, error) {
+	if isFixture(dir) {
+		result, err := h.Check(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		return []Result{result}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() || !isFixture(path) {
+			continue
+		}
+		result, err := h.Check(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no fixture in %s", dir)
+	}
+	return results, nil
+}
+
+// Check evaluates the policies against the fixture of dir and compares the
+// outcome with its golden files, or rewrites them in update mode.
+func (h *Harness) Check(ctx context.Context, dir string) (Result, error) {
+	fixture, err := LoadFixture(dir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	outcome := h.Evaluate(ctx, fixture)
+	actual, err := goldenFiles(outcome)
+	if err != nil {
+		return Result{}, err
+	}
+
+	goldenDir := filepath.Join(dir, "golden")
+	result := Result{Fixture: fixture.Name, Outcome: outcome}
+	if h.update {
+		result.Updated = true
+		return result, writeGolden(goldenDir, actual)
+	}
+
+	expected, err := readGolden(goldenDir)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Diffs = compare(expected, actual)
+	return result, nil
+}
+
+// Evaluate runs the policies against fixture, on a fake GitHub holding its files.
+func (h *Harness) Evaluate(ctx context.Context, fixture Fixture) Outcome {
+	server := githubtest.Start(org)
+	defer server.Close()
+	server.AddRepo(githubtest.Repo{
+		Name:     fixture.Repository.Name,
+		Private:  fixture.Repository.Private,
+		Archived: fixture.Repository.Archived,
+		Topics:   fixture.Repository.Topics,
+		Files:    fixture.Files,
+	})
+
+	client := github.New("", org, github.WithBaseURL(server.BaseURL()))
+	sources := &http.Client{Transport: &sourceTransport{sources: h.sources}}
+	policySvc := service.NewPolicyService(h.policies, client, service.WithHTTPClient(sources))
+	files := slices.Sorted(maps.Keys(fixture.Files))
+
+	outcome := Outcome{Deviations: []Deviation{}, Contents: make(map[string]string)}
+	// The repository would report the invalid policies as errored, a test fails on them
+	if invalid := policySvc.Validate(ctx); len(invalid) > 0 {
+		var errs []string
+		for _, name := range slices.Sorted(maps.Keys(invalid)) {
+			errs = append(errs, fmt.Sprintf("policy %s: %v", name, invalid[name]))
+		}
+		outcome.Error = "validating policies: " + strings.Join(errs, "\n")
+		return outcome
+	}
+
+	evaluation, err := policySvc.Ensure(ctx, fixture.Repository, files)
+	if err != nil {
+		outcome.Error = err.Error()
+		return outcome
+	}
+
+	committed := make(map[string]bool)
+	invalidConfig := false
+	for _, d := range evaluation.Deviations {
+		outcome.Deviations = append(outcome.Deviations, Deviation{
+			Policy:        d.Policy.Name,
+			Action:        string(d.Action),
+			Path:          d.TargetPath,
+			PreviousPaths: d.PreviousPaths,
+			Overrides:     d.Overrides,
+			Reason:        d.Reason,
+		})
+		switch d.Action {
+		case models.PolicyActionInvalidConfig:
+			invalidConfig = true
+		case models.PolicyActionCreate, models.PolicyActionUpdate, models.PolicyActionMigrate:
+			committed[d.Policy.Name] = true
+		}
+	}
+
+	// Nothing is committed while the repository configuration is invalid
+	if invalidConfig || len(committed) == 0 {
+		return outcome
+	}
+	rendered, err := policySvc.Render(ctx, fixture.Repository, files)
+	if err != nil {
+		outcome.Error = err.Error()
+		return outcome
+	}
+	for _, r := range rendered {
+		if !committed[r.Policy.Name] {
+			continue
+		}
+		// The pull request of an invalid rendered workflow would not be opened
+		if err := workflow.Validate(r.Content); err != nil {
+			outcome.Error = fmt.Sprintf("%s: %v", r.TargetPath, err)
+			outcome.Contents = map[string]string{}
+			return outcome
+		}
+		outcome.Contents[r.TargetPath] = r.Content
+	}
+	return outcome
+}
+
+// Test checks every fixture of dir in a subtest of t.
+func (h *Harness) Test(t *testing.T, dir string) {
+	t.Helper()
+
+	results, err := h.Run(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		t.Run(result.Fixture, func(t *testing.T) {
+			for _, diff := range result.Diffs {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+// goldenFiles returns the golden files of outcome, by path relative to the golden directory.
+func goldenFiles(outcome Outcome) (map[string]string, error) {
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(outcome); err != nil {
+		return nil, err
+	}
+	files := map[string]string{outcomeFile: b.String()}
+	for path, content := range outcome.Contents {
+		files[contentDir+"/"+path] = content
+	}
+	return files, nil
+}
@@ -0,0 +1,167 @@
+package policytest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/policy"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func newHarness(t *testing.T, opts ...Option) *Harness {
+	data, err := os.ReadFile("testdata/policies.json")
+	assert.NoError(t, err)
+	policies, err := policy.FromJSON(data)
+	assert.NoError(t, err)
+
+	opts = append([]Option{WithSources("https://sources.example/", "testdata/sources")}, opts...)
+	return New(policies, opts...)
+}
+
+// copyFixture copies the fixture name of testdata to a temporary directory.
+func copyFixture(t *testing.T, name string) string {
+	dir := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.CopyFS(dir, os.DirFS(filepath.Join("testdata/fixtures", name))))
+	return dir
+}
+
+func TestHarness_Testdata(t *testing.T) {
+	newHarness(t).Test(t, "testdata/fixtures")
+}
+
+func TestCheck_ReportsDiffs(t *testing.T) {
+	dir := copyFixture(t, "outdated")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "files/.github/workflows/go.yml"), []byte("name: go\n"), 0o644))
+	assert.NoError(t, os.Remove(filepath.Join(dir, "files/go.mod")))
+
+	result, err := newHarness(t).Check(context.Background(), dir)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Passed())
+	if !assert.Len(t, result.Diffs, 2) {
+		return
+	}
+	assert.Equal(t, "content/.github/workflows/go.yml", result.Diffs[0].File)
+	assert.Empty(t, result.Diffs[0].Actual, "the policy no longer matches")
+	assert.Equal(t, "outcome.yaml", result.Diffs[1].File)
+	assert.Equal(t, "deviations: []\n", result.Diffs[1].Actual)
+}
+
+func TestCheck_MissingGolden(t *testing.T) {
+	dir := copyFixture(t, "api")
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "golden")))
+
+	result, err := newHarness(t).Check(context.Background(), dir)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Diffs, 3)
+	assert.Contains(t, result.Diffs[0].String(), "run with -update")
+}
+
+func TestCheck_Update(t *testing.T) {
+	dir := copyFixture(t, "api")
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "golden")))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "golden/content"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "golden/content/stale.yml"), []byte("stale\n"), 0o644))
+
+	result, err := newHarness(t, WithUpdate(true)).Check(context.Background(), dir)
+	assert.NoError(t, err)
+	assert.True(t, result.Updated)
+	assert.NoFileExists(t, filepath.Join(dir, "golden/content/stale.yml"))
+
+	result, err = newHarness(t).Check(context.Background(), dir)
+	assert.NoError(t, err)
+	assert.True(t, result.Passed())
+}
+
+func TestEvaluate(t *testing.T) {
+	fixture, err := LoadFixture("testdata/fixtures/api")
+	assert.NoError(t, err)
+
+	outcome := newHarness(t).Evaluate(context.Background(), fixture)
+
+	assert.Empty(t, outcome.Error)
+	assert.Equal(t, []Deviation{
+		{Policy: "go", Action: "create", Path: ".github/workflows/go.yml"},
+		{Policy: "lint", Action: "create", Path: ".github/workflows/lint.yml"},
+	}, outcome.Deviations)
+	assert.Contains(t, outcome.Contents[".github/workflows/go.yml"], `go-version: "1.25.4"`)
+}
+
+func TestEvaluate_SourceNotAvailableOffline(t *testing.T) {
+	fixture, err := LoadFixture("testdata/fixtures/api")
+	assert.NoError(t, err)
+	h := New([]models.PolicyWorkflow{{Name: "go", MatchFile: "go.mod", Source: "https://example.com/go.yml", Template: true}})
+
+	outcome := h.Evaluate(context.Background(), fixture)
+
+	assert.Contains(t, outcome.Error, "source https://example.com/go.yml is not available offline")
+	assert.Empty(t, outcome.Contents)
+}
+
+func TestEvaluate_MissingSource(t *testing.T) {
+	fixture, err := LoadFixture("testdata/fixtures/api")
+	assert.NoError(t, err)
+	h := New([]models.PolicyWorkflow{{Name: "go", MatchFile: "go.mod", Source: "https://sources.example/missing.yml", Template: true}},
+		WithSources("https://sources.example/", "testdata/sources"))
+
+	outcome := h.Evaluate(context.Background(), fixture)
+
+	assert.Contains(t, outcome.Error, "unexpected status code: 404")
+}
+
+func TestLoadFixture(t *testing.T) {
+	fixture, err := LoadFixture("testdata/fixtures/outdated")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "outdated", fixture.Name)
+	assert.Equal(t, models.Repository{Name: "legacy-api", FullName: "tracker-tv/legacy-api"}, fixture.Repository)
+	assert.Equal(t, map[string]string{
+		".github/workflows/go.yml": "name: go\non: push\n",
+		"go.mod":                   "module github.com/tracker-tv/outdated\n\ngo 1.24\n",
+	}, fixture.Files)
+}
+
+func TestRun_NoFixture(t *testing.T) {
+	_, err := newHarness(t).Run(context.Background(), t.TempDir())
+
+	assert.ErrorContains(t, err, "no fixture in")
+}
+
+func TestDiffLines(t *testing.T) {
+	lines := diffLines("a\nb\nc\n", "a\nc\nd\n")
+
+	assert.Equal(t, []string{" a", "-b", " c", "+d"}, lines)
+}
+
+func TestEvaluate_InvalidSource(t *testing.T) {
+	fixture, err := LoadFixture("testdata/fixtures/api")
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "lint.yml"), []byte("on: pull_request\njobs:\n  lint:\n    runs-on: ubuntu-latest\n"), 0o644))
+	h := New([]models.PolicyWorkflow{{Name: "lint", MatchFile: "**/*.go", Source: "https://sources.example/lint.yml"}},
+		WithSources("https://sources.example/", dir))
+
+	outcome := h.Evaluate(context.Background(), fixture)
+
+	assert.Equal(t, `validating policies: policy lint: https://sources.example/lint.yml: invalid workflow: line 3: job lint needs a list of "steps"`, outcome.Error)
+	assert.Empty(t, outcome.Deviations)
+}
+
+func TestEvaluate_InvalidRenderedWorkflow(t *testing.T) {
+	fixture, err := LoadFixture("testdata/fixtures/api")
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	source := "on: push\njobs:\n  test:\n    needs: [[ .ImageName ]]\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.yml"), []byte(source), 0o644))
+	h := New([]models.PolicyWorkflow{{Name: "go", MatchFile: "go.mod", Source: "https://sources.example/go.yml", Template: true}},
+		WithSources("https://sources.example/", dir))
+
+	outcome := h.Evaluate(context.Background(), fixture)
+
+	assert.Contains(t, outcome.Error, ".github/workflows/go.yml: invalid workflow: line 8: job test needs unknown job api")
+	assert.Empty(t, outcome.Contents)
+}
@@ -0,0 +1,52 @@
+package policytest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sourceTransport answers the requests for policy sources with local files, so
+// a harness never reaches the network.
+type sourceTransport struct {
+	sources map[string]string // Directory by URL prefix
+}
+
+func (t *sourceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+
+	// The longest prefix wins, so a checkout can override a broader mapping
+	var prefix string
+	for p := range t.sources {
+		if strings.HasPrefix(url, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		return nil, fmt.Errorf("source %s is not available offline, map its URL to a directory", url)
+	}
+
+	path := filepath.Join(t.sources[prefix], filepath.FromSlash(strings.TrimPrefix(url, prefix)))
+	content, err := os.ReadFile(path)
+	status := http.StatusOK
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		status, content = http.StatusNotFound, []byte("404: Not Found")
+	case err != nil:
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		Body:       io.NopCloser(bytes.NewReader(content)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,3 @@
+module github.com/tracker-tv/api
+
+go 1.25.4
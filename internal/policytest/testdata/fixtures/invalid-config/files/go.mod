@@ -0,0 +1 @@
+module github.com/tracker-tv/invalid
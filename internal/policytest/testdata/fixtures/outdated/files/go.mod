@@ -0,0 +1,3 @@
+module github.com/tracker-tv/outdated
+
+go 1.24
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// statusIcons are the cells of the dashboard matrix.
+var statusIcons = map[Status]string{
+	StatusCompliant:  "✅",
+	StatusDrifted:    "⚠️",
+	StatusExempt:     "💤",
+	StatusRemediated: "🔧",
+	StatusSkipped:    "⏳",
+	StatusErrored:    "❌",
+}
+
+// DashboardWriter writes the compliance matrix of a run, one row per
+// repository and one column per policy, with links to the open pull requests.
+type DashboardWriter struct{}
+
+func (DashboardWriter) Write(w io.Writer, r *Report) error {
+	var b strings.Builder
+
+	b.WriteString("## Policy compliance\n\n")
+	fmt.Fprintf(&b, "%d repositories evaluated on %s.\n\n", len(r.Repositories), r.FinishedAt.UTC().Format("2006-01-02 15:04 MST"))
+
+	counts := r.Counts()
+	var legend []string
+	for _, status := range Statuses {
+		legend = append(legend, fmt.Sprintf("%s %s: %d", statusIcons[status], status, counts[status]))
+	}
+	b.WriteString(strings.Join(legend, " · "))
+	b.WriteString("\n\n")
+
+	policies := dashboardPolicies(r)
+	b.WriteString("| Repository |")
+	for _, name := range policies {
+		fmt.Fprintf(&b, " %s |", name)
+	}
+	b.WriteString(" Notes |\n|---|")
+	b.WriteString(strings.Repeat(":-:|", len(policies)))
+	b.WriteString("---|\n")
+
+	for _, repo := range r.Repositories {
+		cells := make(map[string]string, len(repo.Policies))
+		var notes []string
+		if repo.Error != "" {
+			notes = append(notes, repo.Error)
+		}
+		for _, p := range repo.Policies {
+			switch {
+			case isInvalidConfig(p):
+				notes = append(notes, fmt.Sprintf("invalid %s: %s", p.Name, p.Message))
+				continue
+			case isUnpinned(p):
+				notes = append(notes, fmt.Sprintf("%s: %s", p.Name, p.Message))
+				continue
+			}
+			cells[p.Name] = dashboardCell(p)
+		}
+
+		fmt.Fprintf(&b, "| %s |", repo.FullName)
+		for _, name := range policies {
+			cell, ok := cells[name]
+			if !ok {
+				cell = "–"
+			}
+			fmt.Fprintf(&b, " %s |", cell)
+		}
+		fmt.Fprintf(&b, " %s |\n", escapeCell(strings.Join(notes, "; ")))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dashboardPolicies returns the names of the policies evaluated in r, sorted.
+func dashboardPolicies(r *Report) []string {
+	var names []string
+	for _, repo := range r.Repositories {
+		for _, p := range repo.Policies {
+			if !isInvalidConfig(p) && !isUnpinned(p) && !slices.Contains(names, p.Name) {
+				names = append(names, p.Name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func dashboardCell(p Policy) string {
+	icon := statusIcons[p.Status]
+	if p.PRURL != "" {
+		return fmt.Sprintf("[%s](%s)", icon, p.PRURL)
+	}
+	return icon
+}
+
+// isInvalidConfig reports whether p is the invalid repository configuration
+// rather than a policy, see models.PolicyActionInvalidConfig.
+func isInvalidConfig(p Policy) bool {
+	return p.Deviation != nil && p.Deviation.Action == models.PolicyActionInvalidConfig
+}
+
+// isUnpinned reports whether p is a workflow using unpinned actions rather
+// than a policy, see models.PolicyActionUnpinned.
+func isUnpinned(p Policy) bool {
+	return p.Deviation != nil && p.Deviation.Action == models.PolicyActionUnpinned
+}
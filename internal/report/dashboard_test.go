@@ -0,0 +1,64 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestDashboardWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := DashboardWriter{}.Write(&buf, sampleReport())
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "2 repositories evaluated on 2026-10-18 08:01 UTC.\n")
+	assert.Contains(t, out, "✅ compliant: 1 · ⚠️ drifted: 0 · 💤 exempt: 1 · 🔧 remediated: 1 · ⏳ skipped: 0 · ❌ errored: 1\n")
+	assert.Contains(t, out, "| Repository | dockerfile | go | node | Notes |\n|---|:-:|:-:|:-:|---|\n")
+	assert.Contains(t, out, "| org/repo1 | [🔧](https://github.com/org/repo1/pull/1) | ✅ | 💤 |  |\n")
+	assert.Contains(t, out, "| org/repo2 | – | – | – | listing files: empty repo |\n")
+}
+
+func TestDashboardWriter_InvalidConfig(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Report{Repositories: []Repository{{
+		FullName: "org/repo1",
+		Policies: []Policy{{
+			Name:      ".github/policy-bot.yml",
+			Status:    StatusDrifted,
+			Message:   "unknown policy | foo",
+			Deviation: &models.PolicyDeviation{Action: models.PolicyActionInvalidConfig},
+		}},
+	}}}
+
+	err := DashboardWriter{}.Write(&buf, r)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "| Repository | Notes |\n|---|---|\n")
+	assert.Contains(t, buf.String(), `| org/repo1 | invalid .github/policy-bot.yml: unknown policy \| foo |`)
+}
+
+func TestDashboardWriter_Unpinned(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Report{Repositories: []Repository{{
+		FullName: "org/repo1",
+		Policies: []Policy{
+			{Name: "go", Status: StatusCompliant},
+			{
+				Name:      ".github/workflows/release.yml",
+				Status:    StatusDrifted,
+				Message:   "unpinned actions: actions/checkout@v4",
+				Deviation: &models.PolicyDeviation{Action: models.PolicyActionUnpinned},
+			},
+		},
+	}}}
+
+	err := DashboardWriter{}.Write(&buf, r)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "| Repository | go | Notes |\n")
+	assert.Contains(t, buf.String(), "| org/repo1 | ✅ | .github/workflows/release.yml: unpinned actions: actions/checkout@v4 |")
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+type jsonInventory struct {
+	Actions []jsonActionUsage `json:"actions"`
+}
+
+type jsonActionUsage struct {
+	Action       string          `json:"action"`
+	Version      string          `json:"version"`
+	Tag          string          `json:"tag,omitempty"`
+	Repositories int             `json:"repositories"`
+	Uses         []jsonActionUse `json:"uses"`
+}
+
+type jsonActionUse struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+}
+
+// WriteInventoryJSON writes every action with the number of repositories
+// using it and the workflows it is used in.
+func WriteInventoryJSON(w io.Writer, inventory []models.ActionUsage) error {
+	out := jsonInventory{Actions: make([]jsonActionUsage, 0, len(inventory))}
+	for _, usage := range inventory {
+		ju := jsonActionUsage{
+			Action:  usage.Action,
+			Version: usage.Version,
+			Tag:     usage.Tag,
+			Uses:    make([]jsonActionUse, 0, len(usage.Uses)),
+		}
+		repos := make(map[string]bool)
+		for _, use := range usage.Uses {
+			repos[use.Repository] = true
+			ju.Uses = append(ju.Uses, jsonActionUse{Repository: use.Repository, Path: use.Path})
+		}
+		ju.Repositories = len(repos)
+		out.Actions = append(out.Actions, ju)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteInventoryCSV writes a row per workflow using an action, grouped by
+// action and version, for spreadsheets and grep.
+func WriteInventoryCSV(w io.Writer, inventory []models.ActionUsage) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"action", "version", "tag", "repository", "path"}); err != nil {
+		return err
+	}
+	for _, usage := range inventory {
+		for _, use := range usage.Uses {
+			if err := cw.Write([]string{usage.Action, usage.Version, usage.Tag, use.Repository, use.Path}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func sampleInventory() []models.ActionUsage {
+	return []models.ActionUsage{
+		{Action: "actions/checkout", Version: "11bd71901bbe5b1630ceea73d27597364c9af683", Tag: "v4.2.2", Uses: []models.ActionUse{
+			{Repository: "tracker-tv/web", Path: ".github/workflows/ci.yml"},
+		}},
+		{Action: "actions/checkout", Version: "v4", Uses: []models.ActionUse{
+			{Repository: "tracker-tv/api", Path: ".github/workflows/ci.yml"},
+			{Repository: "tracker-tv/api", Path: ".github/workflows/lint.yml"},
+			{Repository: "tracker-tv/web", Path: ".github/workflows/ci.yml"},
+		}},
+	}
+}
+
+func TestWriteInventoryCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteInventoryCSV(&buf, sampleInventory())
+
+	assert.NoError(t, err)
+	assert.Equal(t, `action,version,tag,repository,path
+actions/checkout,11bd71901bbe5b1630ceea73d27597364c9af683,v4.2.2,tracker-tv/web,.github/workflows/ci.yml
+actions/checkout,v4,,tracker-tv/api,.github/workflows/ci.yml
+actions/checkout,v4,,tracker-tv/api,.github/workflows/lint.yml
+actions/checkout,v4,,tracker-tv/web,.github/workflows/ci.yml
+`, buf.String())
+}
+
+func TestWriteInventoryJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteInventoryJSON(&buf, sampleInventory())
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"actions": [
+		{"action": "actions/checkout", "version": "11bd71901bbe5b1630ceea73d27597364c9af683", "tag": "v4.2.2", "repositories": 1, "uses": [
+			{"repository": "tracker-tv/web", "path": ".github/workflows/ci.yml"}
+		]},
+		{"action": "actions/checkout", "version": "v4", "repositories": 2, "uses": [
+			{"repository": "tracker-tv/api", "path": ".github/workflows/ci.yml"},
+			{"repository": "tracker-tv/api", "path": ".github/workflows/lint.yml"},
+			{"repository": "tracker-tv/web", "path": ".github/workflows/ci.yml"}
+		]}
+	]}`, buf.String())
+}
+
+func TestWriteInventoryJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteInventoryJSON(&buf, nil)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"actions": []}`, buf.String())
+}
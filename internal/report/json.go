@@ -0,0 +1,83 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonSchemaVersion is bumped on every breaking change of the JSON report.
+const jsonSchemaVersion = 1
+
+type jsonReport struct {
+	SchemaVersion   int              `json:"schema_version"`
+	StartedAt       time.Time        `json:"started_at"`
+	FinishedAt      time.Time        `json:"finished_at"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Summary         map[Status]int   `json:"summary"`
+	Repositories    []jsonRepository `json:"repositories"`
+}
+
+type jsonRepository struct {
+	Name            string       `json:"name"`
+	FullName        string       `json:"full_name"`
+	DurationSeconds float64      `json:"duration_seconds"`
+	Error           string       `json:"error,omitempty"`
+	Policies        []jsonPolicy `json:"policies"`
+}
+
+type jsonPolicy struct {
+	Name            string  `json:"name"`
+	Status          Status  `json:"status"`
+	Action          string  `json:"action,omitempty"`
+	PRURL           string  `json:"pr_url,omitempty"`
+	Message         string  `json:"message,omitempty"`
+	Comparison      string  `json:"comparison,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// JSONWriter writes the report with a stable schema, identified by schema_version.
+type JSONWriter struct{}
+
+func (JSONWriter) Write(w io.Writer, r *Report) error {
+	counts := r.Counts()
+	summary := make(map[Status]int, len(Statuses))
+	for _, status := range Statuses {
+		summary[status] = counts[status]
+	}
+
+	out := jsonReport{
+		SchemaVersion:   jsonSchemaVersion,
+		StartedAt:       r.StartedAt.UTC(),
+		FinishedAt:      r.FinishedAt.UTC(),
+		DurationSeconds: r.Duration().Seconds(),
+		Summary:         summary,
+		Repositories:    make([]jsonRepository, 0, len(r.Repositories)),
+	}
+
+	for _, repo := range r.Repositories {
+		jr := jsonRepository{
+			Name:            repo.Name,
+			FullName:        repo.FullName,
+			DurationSeconds: repo.Duration.Seconds(),
+			Error:           repo.Error,
+			Policies:        make([]jsonPolicy, 0, len(repo.Policies)),
+		}
+		for _, p := range repo.Policies {
+			jr.Policies = append(jr.Policies, jsonPolicy{
+				Name:            p.Name,
+				Status:          p.Status,
+				Action:          p.Action,
+				PRURL:           p.PRURL,
+				Message:         p.Message,
+				Comparison:      string(p.Comparison),
+				DurationSeconds: p.Duration.Seconds(),
+			})
+		}
+		out.Repositories = append(out.Repositories, jr)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := JSONWriter{}.Write(&buf, sampleReport())
+	assert.NoError(t, err)
+
+	var out map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+
+	assert.Equal(t, float64(1), out["schema_version"])
+	assert.Equal(t, "2026-10-18T08:00:00Z", out["started_at"])
+	assert.Equal(t, float64(90), out["duration_seconds"])
+	assert.Equal(t, map[string]any{
+		"compliant": float64(1), "drifted": float64(0), "exempt": float64(1),
+		"remediated": float64(1), "skipped": float64(0), "errored": float64(1),
+	}, out["summary"])
+
+	repos := out["repositories"].([]any)
+	assert.Len(t, repos, 2)
+
+	repo1 := repos[0].(map[string]any)
+	assert.Equal(t, "org/repo1", repo1["full_name"])
+	policy := repo1["policies"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"name":             "dockerfile",
+		"status":           "remediated",
+		"action":           "create",
+		"pr_url":           "https://github.com/org/repo1/pull/1",
+		"duration_seconds": float64(1),
+	}, policy)
+
+	repo2 := repos[1].(map[string]any)
+	assert.Equal(t, "listing files: empty repo", repo2["error"])
+	assert.Equal(t, []any{}, repo2["policies"])
+}
+
+func TestJSONWriter_Comparison(t *testing.T) {
+	r := &Report{Repositories: []Repository{{
+		Name:     "repo1",
+		FullName: "org/repo1",
+		Policies: []Policy{{Name: "go", Status: StatusRemediated, Action: "update", Comparison: models.ComparisonSemantic}},
+	}}}
+	var buf bytes.Buffer
+
+	assert.NoError(t, JSONWriter{}.Write(&buf, r))
+
+	assert.Contains(t, buf.String(), `"comparison": "semantic"`)
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Name    string           `xml:"name,attr"`
+	Tests   int              `xml:"tests,attr"`
+	Time    string           `xml:"time,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitWriter writes one test suite per repository and one test case per
+// policy. Any result other than compliant or exempt fails, as the repository
+// does not follow the policy until its PR is merged.
+type JUnitWriter struct{}
+
+func (JUnitWriter) Write(w io.Writer, r *Report) error {
+	out := junitTestSuites{
+		Name: "policy-bot",
+		Time: seconds(r.Duration().Seconds()),
+	}
+
+	for _, repo := range r.Repositories {
+		suite := junitTestSuite{
+			Name: repo.FullName,
+			Time: seconds(repo.Duration.Seconds()),
+		}
+
+		if repo.Error != "" {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      "evaluate",
+				ClassName: repo.FullName,
+				Time:      seconds(0),
+				Error:     &junitMessage{Message: repo.Error},
+			})
+			suite.Errors++
+		}
+
+		for _, p := range repo.Policies {
+			tc := junitTestCase{
+				Name:      p.Name,
+				ClassName: repo.FullName,
+				Time:      seconds(p.Duration.Seconds()),
+				SystemOut: p.PRURL,
+			}
+			message := string(p.Status)
+			if p.Message != "" {
+				message = fmt.Sprintf("%s: %s", p.Status, p.Message)
+			}
+
+			switch p.Status {
+			case StatusCompliant:
+			case StatusExempt:
+				tc.Skipped = &junitMessage{Message: message}
+				suite.Skipped++
+			case StatusErrored:
+				tc.Error = &junitMessage{Message: message}
+				suite.Errors++
+			default:
+				tc.Failure = &junitMessage{Message: message}
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		suite.Tests = len(suite.TestCases)
+		out.Tests += suite.Tests
+		out.Suites = append(out.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
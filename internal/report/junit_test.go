@@ -0,0 +1,38 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJUnitWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := JUnitWriter{}.Write(&buf, sampleReport())
+	assert.NoError(t, err)
+
+	var out junitTestSuites
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &out))
+
+	assert.Equal(t, 4, out.Tests)
+	assert.Equal(t, "90.000", out.Time)
+	assert.Len(t, out.Suites, 2)
+
+	repo1 := out.Suites[0]
+	assert.Equal(t, "org/repo1", repo1.Name)
+	assert.Equal(t, 3, repo1.Tests)
+	assert.Equal(t, 1, repo1.Failures)
+	assert.Equal(t, 1, repo1.Skipped)
+	assert.Equal(t, "remediated", repo1.TestCases[0].Failure.Message)
+	assert.Equal(t, "https://github.com/org/repo1/pull/1", repo1.TestCases[0].SystemOut)
+	assert.Nil(t, repo1.TestCases[1].Failure)
+	assert.Nil(t, repo1.TestCases[1].Skipped)
+	assert.Equal(t, "exempt: vendor | image", repo1.TestCases[2].Skipped.Message)
+
+	repo2 := out.Suites[1]
+	assert.Equal(t, 1, repo2.Errors)
+	assert.Equal(t, "listing files: empty repo", repo2.TestCases[0].Error.Message)
+}
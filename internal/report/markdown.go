@@ -0,0 +1,57 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MarkdownWriter writes a summary suitable for $GITHUB_STEP_SUMMARY: the
+// count per status, then every result that is not compliant.
+type MarkdownWriter struct{}
+
+func (MarkdownWriter) Write(w io.Writer, r *Report) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Policy Bot report\n\n")
+	fmt.Fprintf(&b, "%d repositories evaluated in %s.\n\n", len(r.Repositories), r.Duration().Round(time.Second))
+
+	counts := r.Counts()
+	b.WriteString("| Status | Count |\n|---|---|\n")
+	for _, status := range Statuses {
+		fmt.Fprintf(&b, "| %s | %d |\n", status, counts[status])
+	}
+
+	var rows []string
+	for _, repo := range r.Repositories {
+		if repo.Error != "" {
+			rows = append(rows, fmt.Sprintf("| %s | | %s | %s |", repo.FullName, StatusErrored, escapeCell(repo.Error)))
+		}
+		for _, p := range repo.Policies {
+			if p.Status == StatusCompliant {
+				continue
+			}
+			details := p.Message
+			if p.PRURL != "" {
+				details = strings.TrimSpace(fmt.Sprintf("%s %s", p.PRURL, details))
+			}
+			rows = append(rows, fmt.Sprintf("| %s | %s | %s | %s |", repo.FullName, p.Name, p.Status, escapeCell(details)))
+		}
+	}
+
+	if len(rows) > 0 {
+		b.WriteString("\n### Results needing attention\n\n")
+		b.WriteString("| Repository | Policy | Status | Details |\n|---|---|---|---|\n")
+		b.WriteString(strings.Join(rows, "\n"))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := MarkdownWriter{}.Write(&buf, sampleReport())
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "## Policy Bot report")
+	assert.Contains(t, out, "2 repositories evaluated in 1m30s.")
+	assert.Contains(t, out, "| remediated | 1 |")
+	assert.Contains(t, out, "| org/repo1 | dockerfile | remediated | https://github.com/org/repo1/pull/1 |")
+	assert.Contains(t, out, `| org/repo1 | node | exempt | vendor \| image |`)
+	assert.Contains(t, out, "| org/repo2 | | errored | listing files: empty repo |")
+	assert.NotContains(t, out, "| org/repo1 | go |")
+}
+
+func TestMarkdownWriter_AllCompliant(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Report{Repositories: []Repository{{FullName: "org/repo1", Policies: []Policy{{Name: "go", Status: StatusCompliant}}}}}
+
+	err := MarkdownWriter{}.Write(&buf, r)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), "Results needing attention")
+}
@@ -0,0 +1,95 @@
+// Package report describes the outcome of a run for every repository and
+// policy evaluated, and writes it in formats meant for humans and CI.
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+type Status string
+
+const (
+	StatusCompliant  Status = "compliant"  // The repository follows the policy
+	StatusDrifted    Status = "drifted"    // A deviation was found and left as is
+	StatusExempt     Status = "exempt"     // The policy is waived by an exemption
+	StatusRemediated Status = "remediated" // A PR was opened or updated
+	StatusSkipped    Status = "skipped"    // The open PR already fixes the deviation
+	StatusErrored    Status = "errored"
+)
+
+// Statuses lists every status in the order reports present them.
+var Statuses = []Status{StatusCompliant, StatusDrifted, StatusExempt, StatusRemediated, StatusSkipped, StatusErrored}
+
+type Report struct {
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	Repositories []Repository
+}
+
+type Repository struct {
+	Name     string
+	FullName string
+	Duration time.Duration
+	Error    string // Why the repository could not be evaluated
+	Policies []Policy
+}
+
+type Policy struct {
+	Name     string
+	Status   Status
+	Action   string // Deviation found, e.g. "create" or "update"
+	PRURL    string
+	Message  string
+	Duration time.Duration
+
+	Comparison models.Comparison // Comparison that found the workflow outdated, empty when it was missing
+
+	Deviation *models.PolicyDeviation // Deviation found, nil when compliant
+	Content   string                  // Content the policy expects at the target path, empty when it is removed
+}
+
+// Duration is the wall-clock time of the run.
+func (r *Report) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Counts returns the number of policy results per status. A repository that
+// could not be evaluated counts as one errored result.
+func (r *Report) Counts() map[Status]int {
+	counts := make(map[Status]int, len(Statuses))
+	for _, repo := range r.Repositories {
+		if repo.Error != "" {
+			counts[StatusErrored]++
+		}
+		for _, p := range repo.Policies {
+			counts[p.Status]++
+		}
+	}
+	return counts
+}
+
+// Writer renders a report in one format.
+type Writer interface {
+	Write(w io.Writer, r *Report) error
+}
+
+// WriterFor returns the writer of format: "json", "markdown", "junit", "sarif" or "dashboard".
+func WriterFor(format string) (Writer, error) {
+	switch format {
+	case "json":
+		return JSONWriter{}, nil
+	case "markdown":
+		return MarkdownWriter{}, nil
+	case "junit":
+		return JUnitWriter{}, nil
+	case "sarif":
+		return SARIFWriter{}, nil
+	case "dashboard":
+		return DashboardWriter{}, nil
+	}
+	return nil, fmt.Errorf("unknown report format %q", format)
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleReport() *Report {
+	started := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	return &Report{
+		StartedAt:  started,
+		FinishedAt: started.Add(90 * time.Second),
+		Repositories: []Repository{
+			{
+				Name:     "repo1",
+				FullName: "org/repo1",
+				Duration: 2 * time.Second,
+				Policies: []Policy{
+					{Name: "dockerfile", Status: StatusRemediated, Action: "create", PRURL: "https://github.com/org/repo1/pull/1", Duration: time.Second},
+					{Name: "go", Status: StatusCompliant},
+					{Name: "node", Status: StatusExempt, Action: "create", Message: "vendor | image"},
+				},
+			},
+			{
+				Name:     "repo2",
+				FullName: "org/repo2",
+				Error:    "listing files: empty repo",
+			},
+		},
+	}
+}
+
+func TestCounts(t *testing.T) {
+	counts := sampleReport().Counts()
+
+	assert.Equal(t, 1, counts[StatusRemediated])
+	assert.Equal(t, 1, counts[StatusCompliant])
+	assert.Equal(t, 1, counts[StatusExempt])
+	assert.Equal(t, 1, counts[StatusErrored])
+	assert.Equal(t, 0, counts[StatusDrifted])
+}
+
+func TestWriterFor(t *testing.T) {
+	for format, want := range map[string]Writer{"json": JSONWriter{}, "markdown": MarkdownWriter{}, "junit": JUnitWriter{}, "sarif": SARIFWriter{}, "dashboard": DashboardWriter{}} {
+		w, err := WriterFor(format)
+		assert.NoError(t, err)
+		assert.Equal(t, want, w)
+	}
+
+	_, err := WriterFor("html")
+	assert.Error(t, err)
+}
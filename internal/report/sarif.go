@@ -0,0 +1,324 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// configRuleID is the rule of invalid repository configurations.
+	configRuleID = "policy-bot-config"
+	// pinningRuleID is the rule of third-party actions not pinned to a commit SHA.
+	pinningRuleID = "policy-bot-pinned-actions"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level models.Severity `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        models.Severity    `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Fixes        []sarifFix         `json:"fixes,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   map[string]string  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification"`
+}
+
+// SARIFWriter writes every deviation of the run as a SARIF 2.1.0 result, for
+// code scanning of the repository hosting the policies. Locations are
+// prefixed with the full name of the repository they belong to.
+type SARIFWriter struct{}
+
+func (SARIFWriter) Write(w io.Writer, r *Report) error {
+	return writeSARIF(w, buildSARIF(r.Repositories, true))
+}
+
+// WriteRepositorySARIF writes the deviations of a single repository, with
+// locations relative to its root, for upload to its own code scanning.
+func WriteRepositorySARIF(w io.Writer, repo Repository) error {
+	return writeSARIF(w, buildSARIF([]Repository{repo}, false))
+}
+
+func writeSARIF(w io.Writer, log sarifLog) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func buildSARIF(repos []Repository, prefixRepository bool) sarifLog {
+	rules := make(map[string]sarifRule)
+	results := []sarifResult{}
+
+	for _, repo := range repos {
+		for _, p := range repo.Policies {
+			if p.Deviation == nil {
+				// Compliant policies only know their name, a deviation elsewhere gives the full rule
+				if _, ok := rules[p.Name]; !ok && p.Status == StatusCompliant {
+					rules[p.Name] = policyRule(models.PolicyWorkflow{Name: p.Name})
+				}
+				continue
+			}
+			// Code scanning alerts point at a file, repository settings have none
+			if len(p.Deviation.Settings) > 0 {
+				continue
+			}
+
+			result := sarifResultFor(p)
+			if prefixRepository {
+				prefixLocations(&result, repo.FullName)
+				result.Properties["repository"] = repo.FullName
+			}
+			rules[result.RuleID] = ruleFor(*p.Deviation)
+			results = append(results, result)
+		}
+	}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	driver := sarifDriver{
+		Name:           "policy-bot",
+		InformationURI: "https://github.com/tracker-tv/github-policy-bots",
+		Rules:          make([]sarifRule, 0, len(ids)),
+	}
+	for _, id := range ids {
+		driver.Rules = append(driver.Rules, rules[id])
+	}
+
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+func ruleFor(drift models.PolicyDeviation) sarifRule {
+	switch drift.Action {
+	case models.PolicyActionInvalidConfig:
+		return sarifRule{
+			ID:                   configRuleID,
+			Name:                 configRuleID,
+			ShortDescription:     sarifMessage{Text: fmt.Sprintf("%s must be valid", models.RepositoryConfigPath)},
+			DefaultConfiguration: sarifConfiguration{Level: models.SeverityError},
+		}
+	case models.PolicyActionUnpinned:
+		return sarifRule{
+			ID:                   pinningRuleID,
+			Name:                 pinningRuleID,
+			ShortDescription:     sarifMessage{Text: "Third-party actions are pinned to a commit SHA"},
+			DefaultConfiguration: sarifConfiguration{Level: models.SeverityWarning},
+		}
+	}
+	return policyRule(drift.Policy)
+}
+
+func policyRule(policy models.PolicyWorkflow) sarifRule {
+	return sarifRule{
+		ID:                   policy.Name,
+		Name:                 policy.Name,
+		ShortDescription:     sarifMessage{Text: fmt.Sprintf("Repository follows the %s policy", policy.Name)},
+		DefaultConfiguration: sarifConfiguration{Level: severity(policy)},
+	}
+}
+
+func severity(policy models.PolicyWorkflow) models.Severity {
+	if policy.Severity == "" {
+		return models.SeverityWarning
+	}
+	return policy.Severity
+}
+
+func sarifResultFor(p Policy) sarifResult {
+	drift := *p.Deviation
+
+	result := sarifResult{
+		RuleID:     drift.Policy.Name,
+		Level:      severity(drift.Policy),
+		Message:    sarifMessage{Text: deviationMessage(drift)},
+		Properties: map[string]string{"action": string(drift.Action)},
+	}
+	if p.PRURL != "" {
+		result.Properties["pullRequest"] = p.PRURL
+	}
+
+	location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: drift.TargetPath}}
+	if drift.CurrentContent != "" {
+		location.Region = &sarifRegion{StartLine: 1}
+	}
+	result.Locations = []sarifLocation{{PhysicalLocation: location}}
+
+	switch drift.Action {
+	case models.PolicyActionInvalidConfig:
+		result.RuleID = configRuleID
+		result.Level = models.SeverityError
+	case models.PolicyActionUnpinned:
+		result.RuleID = pinningRuleID
+		result.Level = models.SeverityWarning
+		location.Region = &sarifRegion{StartLine: lineOf(drift.CurrentContent, drift.UnpinnedActions[0])}
+		result.Locations = []sarifLocation{{PhysicalLocation: location}}
+	case models.PolicyActionExempt:
+		e := drift.Exemption
+		result.Suppressions = []sarifSuppression{{
+			Kind:          "external",
+			Status:        "accepted",
+			Justification: fmt.Sprintf("%s (approved by %s until %s)", e.Reason, e.Approver, e.Expires.Format(time.DateOnly)),
+		}}
+	case models.PolicyActionDelete:
+		// The region to delete is only known when the current content was read
+		if drift.CurrentContent != "" {
+			result.Fixes = []sarifFix{fileFix(drift, "Remove the workflow", nil)}
+		}
+	default:
+		if p.Content != "" {
+			result.Fixes = []sarifFix{fileFix(drift, fmt.Sprintf("Apply the %s policy", drift.Policy.Name), &sarifMessage{Text: p.Content})}
+		}
+	}
+
+	return result
+}
+
+func deviationMessage(drift models.PolicyDeviation) string {
+	switch drift.Action {
+	case models.PolicyActionCreate:
+		return fmt.Sprintf("Workflow %s required by the %s policy is missing.", drift.TargetPath, drift.Policy.Name)
+	case models.PolicyActionUpdate:
+		return fmt.Sprintf("Workflow %s differs from the %s policy.", drift.TargetPath, drift.Policy.Name)
+	case models.PolicyActionDelete:
+		return fmt.Sprintf("Workflow %s of the retired %s policy must be removed.", drift.TargetPath, drift.Policy.Name)
+	case models.PolicyActionMigrate:
+		return fmt.Sprintf("The %s policy was renamed, %s must move to %s.", drift.Policy.Name, strings.Join(drift.PreviousPaths, ", "), drift.TargetPath)
+	case models.PolicyActionInvalidConfig:
+		return fmt.Sprintf("Invalid repository configuration: %s", drift.Reason)
+	case models.PolicyActionExempt:
+		return fmt.Sprintf("Workflow %s is exempt from the %s policy.", drift.TargetPath, drift.Policy.Name)
+	case models.PolicyActionUnpinned:
+		return fmt.Sprintf("Workflow %s uses actions not pinned to a commit SHA: %s.", drift.TargetPath, strings.Join(drift.UnpinnedActions, ", "))
+	}
+	return fmt.Sprintf("Workflow %s deviates from the %s policy.", drift.TargetPath, drift.Policy.Name)
+}
+
+// fileFix replaces the whole target file with inserted, or removes it when inserted is nil.
+func fileFix(drift models.PolicyDeviation, description string, inserted *sarifMessage) sarifFix {
+	deleted := sarifRegion{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 1}
+	if drift.CurrentContent != "" {
+		lines := strings.Split(strings.TrimSuffix(drift.CurrentContent, "\n"), "\n")
+		deleted = sarifRegion{StartLine: 1, StartColumn: 1, EndLine: len(lines), EndColumn: len(lines[len(lines)-1]) + 1}
+	}
+
+	return sarifFix{
+		Description: sarifMessage{Text: description},
+		ArtifactChanges: []sarifArtifactChange{{
+			ArtifactLocation: sarifArtifactLocation{URI: drift.TargetPath},
+			Replacements:     []sarifReplacement{{DeletedRegion: deleted, InsertedContent: inserted}},
+		}},
+	}
+}
+
+func prefixLocations(result *sarifResult, fullName string) {
+	for i := range result.Locations {
+		loc := &result.Locations[i].PhysicalLocation.ArtifactLocation
+		loc.URI = fullName + "/" + loc.URI
+	}
+	for i := range result.Fixes {
+		for j := range result.Fixes[i].ArtifactChanges {
+			loc := &result.Fixes[i].ArtifactChanges[j].ArtifactLocation
+			loc.URI = fullName + "/" + loc.URI
+		}
+	}
+}
+
+// lineOf returns the 1-based line of the first occurrence of substr in content, 1 when missing.
+func lineOf(content, substr string) int {
+	i := strings.Index(content, substr)
+	if i < 0 {
+		return 1
+	}
+	return strings.Count(content[:i], "\n") + 1
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func sarifReport() *Report {
+	return &Report{Repositories: []Repository{
+		{
+			Name:     "repo1",
+			FullName: "org/repo1",
+			Policies: []Policy{
+				{
+					Name:   "dockerfile",
+					Status: StatusRemediated,
+					PRURL:  "https://github.com/org/repo1/pull/1",
+					Deviation: &models.PolicyDeviation{
+						Policy:         models.PolicyWorkflow{Name: "dockerfile", Severity: models.SeverityError},
+						Action:         models.PolicyActionUpdate,
+						TargetPath:     ".github/workflows/dockerfile.yml",
+						CurrentContent: "name: old\non: push\n",
+					},
+					Content: "name: new\n",
+				},
+				{
+					Name:   "go",
+					Status: StatusExempt,
+					Deviation: &models.PolicyDeviation{
+						Policy:     models.PolicyWorkflow{Name: "go"},
+						Action:     models.PolicyActionExempt,
+						TargetPath: ".github/workflows/go.yml",
+						Exemption:  &models.Exemption{Reason: "bazel", Approver: "platform", Expires: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				{Name: "node", Status: StatusCompliant},
+			},
+		},
+		{Name: "repo2", FullName: "org/repo2", Error: "listing files: empty repo"},
+	}}
+}
+
+func TestSARIFWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := SARIFWriter{}.Write(&buf, sarifReport())
+	assert.NoError(t, err)
+
+	var log sarifLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+
+	rules := log.Runs[0].Tool.Driver.Rules
+	assert.Equal(t, []string{"dockerfile", "go", "node"}, []string{rules[0].ID, rules[1].ID, rules[2].ID})
+	assert.Equal(t, models.SeverityError, rules[0].DefaultConfiguration.Level)
+	assert.Equal(t, models.SeverityWarning, rules[2].DefaultConfiguration.Level)
+
+	results := log.Runs[0].Results
+	assert.Len(t, results, 2)
+
+	updated := results[0]
+	assert.Equal(t, "dockerfile", updated.RuleID)
+	assert.Equal(t, models.SeverityError, updated.Level)
+	assert.Equal(t, "org/repo1/.github/workflows/dockerfile.yml", updated.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "org/repo1", updated.Properties["repository"])
+	assert.Equal(t, "https://github.com/org/repo1/pull/1", updated.Properties["pullRequest"])
+	assert.Len(t, updated.Fixes, 1)
+	replacement := updated.Fixes[0].ArtifactChanges[0].Replacements[0]
+	assert.Equal(t, sarifRegion{StartLine: 1, StartColumn: 1, EndLine: 2, EndColumn: 9}, replacement.DeletedRegion)
+	assert.Equal(t, "name: new\n", replacement.InsertedContent.Text)
+
+	exempt := results[1]
+	assert.Equal(t, "go", exempt.RuleID)
+	assert.Empty(t, exempt.Fixes)
+	assert.Equal(t, "bazel (approved by platform until 2026-12-31)", exempt.Suppressions[0].Justification)
+}
+
+func TestWriteRepositorySARIF(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteRepositorySARIF(&buf, sarifReport().Repositories[0])
+	assert.NoError(t, err)
+
+	var log sarifLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, ".github/workflows/dockerfile.yml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, ".github/workflows/dockerfile.yml", result.Fixes[0].ArtifactChanges[0].ArtifactLocation.URI)
+	assert.NotContains(t, result.Properties, "repository")
+}
+
+func TestWriteRepositorySARIF_NoDeviations(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteRepositorySARIF(&buf, Repository{FullName: "org/repo1", Policies: []Policy{{Name: "go", Status: StatusCompliant}}})
+	assert.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `"results": []`)
+}
+
+func TestWriteRepositorySARIF_SkipsSettings(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteRepositorySARIF(&buf, Repository{FullName: "org/repo1", Policies: []Policy{{
+		Name:   "merge-settings",
+		Status: StatusDrifted,
+		Deviation: &models.PolicyDeviation{
+			Policy:   models.PolicyWorkflow{Name: "merge-settings"},
+			Action:   models.PolicyActionSettings,
+			Settings: []models.SettingChange{{Setting: "has_wiki", Current: "true", Expected: "false"}},
+		},
+	}}})
+	assert.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `"results": []`)
+}
+
+func TestSARIFResult_CreateAndInvalidConfig(t *testing.T) {
+	created := sarifResultFor(Policy{
+		Deviation: &models.PolicyDeviation{
+			Policy:     models.PolicyWorkflow{Name: "dockerfile"},
+			Action:     models.PolicyActionCreate,
+			TargetPath: ".github/workflows/dockerfile.yml",
+		},
+		Content: "name: new\n",
+	})
+
+	assert.Nil(t, created.Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, sarifRegion{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 1}, created.Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion)
+
+	invalid := sarifResultFor(Policy{
+		Deviation: &models.PolicyDeviation{
+			Action:         models.PolicyActionInvalidConfig,
+			TargetPath:     ".github/policy-bot.yml",
+			CurrentContent: "grouping: weekly\n",
+			Reason:         `unknown grouping "weekly"`,
+		},
+	})
+
+	assert.Equal(t, configRuleID, invalid.RuleID)
+	assert.Equal(t, models.SeverityError, invalid.Level)
+	assert.Equal(t, `Invalid repository configuration: unknown grouping "weekly"`, invalid.Message.Text)
+	assert.Empty(t, invalid.Fixes)
+}
+
+func TestSARIFResult_Unpinned(t *testing.T) {
+	drift := models.PolicyDeviation{
+		Action:          models.PolicyActionUnpinned,
+		TargetPath:      ".github/workflows/release.yml",
+		CurrentContent:  "on: push\njobs:\n  release:\n    steps:\n      - uses: actions/checkout@v4\n      - uses: softprops/action-gh-release@v2\n",
+		UnpinnedActions: []string{"actions/checkout@v4", "softprops/action-gh-release@v2"},
+	}
+
+	result := sarifResultFor(Policy{Deviation: &drift})
+
+	assert.Equal(t, pinningRuleID, result.RuleID)
+	assert.Equal(t, models.SeverityWarning, result.Level)
+	assert.Equal(t, "Workflow .github/workflows/release.yml uses actions not pinned to a commit SHA: actions/checkout@v4, softprops/action-gh-release@v2.", result.Message.Text)
+	assert.Equal(t, &sarifRegion{StartLine: 5}, result.Locations[0].PhysicalLocation.Region)
+	assert.Empty(t, result.Fixes, "the bot does not manage the workflow")
+	assert.Equal(t, pinningRuleID, ruleFor(drift).ID)
+}
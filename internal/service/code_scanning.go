@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+type CodeScanningService interface {
+	Upload(ctx context.Context, repo models.Repository, sarif []byte) error
+}
+
+type codeScanningService struct {
+	gh github.Client
+}
+
+func NewCodeScanningService(gh github.Client) CodeScanningService {
+	return &codeScanningService{gh: gh}
+}
+
+// Upload sends sarif to the code scanning of repo, for the head of its default
+// branch. Uploading a log without results closes the alerts of previous runs.
+func (s *codeScanningService) Upload(ctx context.Context, repo models.Repository, sarif []byte) error {
+	ref, err := getDefaultBranch(ctx, s.gh, repo.Name)
+	if err != nil {
+		return fmt.Errorf("getting default branch: %w", err)
+	}
+
+	if err := s.gh.UploadSARIF(ctx, repo.Name, ref.GetObject().GetSHA(), ref.GetRef(), sarif); err != nil {
+		return fmt.Errorf("uploading SARIF: %w", err)
+	}
+	return nil
+}
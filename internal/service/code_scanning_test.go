@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestCodeScanningUpload_Success(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		GetBranch(mock.Anything, "my-repo", "main").
+		Once().
+		Return(nil, errors.New("not found"))
+	mockClient.
+		EXPECT().
+		GetBranch(mock.Anything, "my-repo", "master").
+		Once().
+		Return(&gh.Reference{
+			Ref:    gh.Ptr("refs/heads/master"),
+			Object: &gh.GitObject{SHA: gh.Ptr("abc123")},
+		}, nil)
+	mockClient.
+		EXPECT().
+		UploadSARIF(mock.Anything, "my-repo", "abc123", "refs/heads/master", []byte("{}")).
+		Once().
+		Return(nil)
+
+	svc := NewCodeScanningService(mockClient)
+	err := svc.Upload(ctx, models.Repository{Name: "my-repo"}, []byte("{}"))
+
+	assert.NoError(t, err)
+}
+
+func TestCodeScanningUpload_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		GetBranch(mock.Anything, "my-repo", "main").
+		Once().
+		Return(&gh.Reference{
+			Ref:    gh.Ptr("refs/heads/main"),
+			Object: &gh.GitObject{SHA: gh.Ptr("abc123")},
+		}, nil)
+	mockClient.
+		EXPECT().
+		UploadSARIF(mock.Anything, "my-repo", "abc123", "refs/heads/main", mock.Anything).
+		Once().
+		Return(errors.New("forbidden"))
+
+	svc := NewCodeScanningService(mockClient)
+	err := svc.Upload(ctx, models.Repository{Name: "my-repo"}, []byte("{}"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "uploading SARIF: forbidden")
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// codeownersLocations lists the paths GitHub looks up for a CODEOWNERS file, in order.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type codeownersRule struct {
+	pattern string
+	owners  []string
+}
+
+type codeowners []codeownersRule
+
+func parseCodeowners(content string) codeowners {
+	var rules codeowners
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		rules = append(rules, codeownersRule{
+			pattern: fields[0],
+			owners:  fields[1:],
+		})
+	}
+
+	return rules
+}
+
+// ownersFor returns the owners of the last rule matching path, as GitHub does.
+func (c codeowners) ownersFor(path string) ([]string, error) {
+	for i := len(c) - 1; i >= 0; i-- {
+		matched, err := matchCodeownersPattern(c[i].pattern, path)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			return c[i].owners, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchCodeownersPattern applies the gitignore-style rules used by CODEOWNERS:
+// patterns without a slash match at any depth, a leading slash anchors to the
+// repository root and a trailing slash matches everything below a directory.
+func matchCodeownersPattern(pattern, path string) (bool, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !anchored && !strings.HasPrefix(pattern, "**/") {
+		pattern = "**/" + pattern
+	}
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	matched, err := doublestar.Match(pattern, path)
+	if err != nil || matched {
+		return matched, err
+	}
+	// A pattern naming a directory also owns everything inside it.
+	return doublestar.Match(pattern+"/**", path)
+}
+
+// splitOwners separates @user owners from @org/team owners. Team entries are
+// returned as slugs, as expected by the reviewers API; e-mail owners are dropped.
+func splitOwners(owners []string) (users, teams []string) {
+	for _, owner := range owners {
+		if !strings.HasPrefix(owner, "@") {
+			continue
+		}
+		owner = strings.TrimPrefix(owner, "@")
+		if strings.Contains(owner, "/") {
+			teams = append(teams, teamSlug(owner))
+			continue
+		}
+		users = append(users, owner)
+	}
+	return users, teams
+}
+
+// teamSlug returns the slug of a team written as org/team or as a slug.
+func teamSlug(team string) string {
+	if _, slug, ok := strings.Cut(team, "/"); ok {
+		return slug
+	}
+	return team
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	content := `# Global owners
+*       @tracker-tv/maintainers
+
+/.github/ @tracker-tv/platform @alice # CI is owned by platform
+docs/   docs@tracker.tv
+`
+
+	rules := parseCodeowners(content)
+
+	assert.Len(t, rules, 3)
+	assert.Equal(t, "*", rules[0].pattern)
+	assert.Equal(t, []string{"@tracker-tv/platform", "@alice"}, rules[1].owners)
+	assert.Equal(t, []string{"docs@tracker.tv"}, rules[2].owners)
+}
+
+func TestCodeowners_OwnersFor(t *testing.T) {
+	rules := parseCodeowners(`*           @tracker-tv/maintainers
+*.yml       @bob
+/.github/   @tracker-tv/platform
+docs/       @carol
+`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "last matching rule wins", path: ".github/workflows/dockerfile.yml", want: []string{"@tracker-tv/platform"}},
+		{name: "extension at any depth", path: "deploy/values.yml", want: []string{"@bob"}},
+		{name: "unanchored directory", path: "src/docs/index.md", want: []string{"@carol"}},
+		{name: "fallback to global owners", path: "main.go", want: []string{"@tracker-tv/maintainers"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners, err := rules.ownersFor(tt.path)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, owners)
+		})
+	}
+}
+
+func TestCodeowners_OwnersFor_NoMatch(t *testing.T) {
+	rules := parseCodeowners("/src/ @alice\n")
+
+	owners, err := rules.ownersFor(".github/workflows/dockerfile.yml")
+
+	assert.NoError(t, err)
+	assert.Nil(t, owners)
+}
+
+func TestSplitOwners(t *testing.T) {
+	users, teams := splitOwners([]string{"@alice", "@tracker-tv/platform", "dev@tracker.tv", "@bob"})
+
+	assert.Equal(t, []string{"alice", "bob"}, users)
+	assert.Equal(t, []string{"platform"}, teams)
+}
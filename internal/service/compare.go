@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+	"gopkg.in/yaml.v3"
+)
+
+// comparisonOf returns the comparison of policy, exact when unset.
+func comparisonOf(policy models.PolicyWorkflow) models.Comparison {
+	if policy.Comparison == "" {
+		return models.ComparisonExact
+	}
+	return policy.Comparison
+}
+
+// sameContent reports whether the current content of a workflow matches the
+// expected one under comparison.
+func sameContent(comparison models.Comparison, current, expected string) bool {
+	if current == expected {
+		return true
+	}
+
+	switch comparison {
+	case models.ComparisonNormalized:
+		return normalize(current) == normalize(expected)
+	case models.ComparisonSemantic:
+		// The header is a comment, which the documents do not keep
+		if isManaged(current) != isManaged(expected) {
+			return false
+		}
+		currentDocs, err := parseDocuments(current)
+		if err != nil {
+			return normalize(current) == normalize(expected)
+		}
+		expectedDocs, err := parseDocuments(expected)
+		if err != nil {
+			return normalize(current) == normalize(expected)
+		}
+		return reflect.DeepEqual(currentDocs, expectedDocs)
+	}
+	return false
+}
+
+// normalize converts line endings to \n, strips the trailing whitespace of
+// every line and ends the content with a single newline.
+func normalize(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// parseDocuments decodes every YAML document of content. JSON is decoded as
+// the YAML it is a subset of.
+func parseDocuments(content string) ([]any, error) {
+	dec := yaml.NewDecoder(strings.NewReader(content))
+	var docs []any
+	for {
+		var doc any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
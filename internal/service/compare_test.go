@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestSameContent(t *testing.T) {
+	expected := wrapContent("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps: [{run: go test}]\n", "go")
+
+	tests := []struct {
+		name       string
+		current    string
+		exact      bool
+		normalized bool
+		semantic   bool
+	}{
+		{name: "identical", current: expected, exact: true, normalized: true, semantic: true},
+		{name: "crlf line endings", current: "# DO NOT EDIT: BEGIN\r\n" + expected[len("# DO NOT EDIT: BEGIN\n"):], normalized: true, semantic: true},
+		{name: "trailing newlines", current: expected + "\n\n", normalized: true, semantic: true},
+		{name: "trailing whitespace", current: expected[:len(expected)-1] + "  \n", normalized: true, semantic: true},
+		{
+			name:     "reindented with reordered keys",
+			current:  wrapContent("jobs:\n    test:\n        steps:\n            - run: go test\n        runs-on: ubuntu-latest\non: push\n", "go"),
+			semantic: true,
+		},
+		{name: "different value", current: wrapContent("on: pull_request\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps: [{run: go test}]\n", "go")},
+		{name: "header removed", current: "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps: [{run: go test}]\n"},
+		{name: "invalid yaml", current: wrapContent("on: [push\n", "go")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exact, sameContent(models.ComparisonExact, tt.current, expected), "exact")
+			assert.Equal(t, tt.normalized, sameContent(models.ComparisonNormalized, tt.current, expected), "normalized")
+			assert.Equal(t, tt.semantic, sameContent(models.ComparisonSemantic, tt.current, expected), "semantic")
+		})
+	}
+}
+
+func TestSameContent_SemanticJSON(t *testing.T) {
+	assert.True(t, sameContent(models.ComparisonSemantic, `{"b": [1, 2], "a": "x"}`, "{\n  \"a\": \"x\",\n  \"b\": [1, 2]\n}\n"))
+	assert.False(t, sameContent(models.ComparisonSemantic, `{"b": [2, 1], "a": "x"}`, `{"a": "x", "b": [1, 2]}`))
+}
+
+func TestSameContent_SemanticMultipleDocuments(t *testing.T) {
+	assert.True(t, sameContent(models.ComparisonSemantic, "a: 1\n---\nb: 2\n", "a:   1\n---\nb: 2"))
+	assert.False(t, sameContent(models.ComparisonSemantic, "a: 1\n---\nb: 2\n", "a: 1\n"))
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "a\n\nb\n", normalize("a \r\n\r\nb\t\n\n\n"))
+	assert.Equal(t, "\n", normalize(""))
+}
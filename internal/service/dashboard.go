@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+)
+
+const (
+	// dashboardMarker identifies the dashboard issue among the issues of the repository.
+	dashboardMarker = "<!-- policy-bot:dashboard -->"
+	// dashboardPartMarker identifies the comments continuing a dashboard too long for the issue body.
+	dashboardPartMarker = "<!-- policy-bot:dashboard-part -->"
+	// maxIssueBody is the size limit of issue and comment bodies on GitHub.
+	maxIssueBody = 65536
+)
+
+type DashboardService interface {
+	// Publish shows content in the dashboard issue, creating and pinning the
+	// issue on the first call, and returns the URL of the issue.
+	Publish(ctx context.Context, content string) (string, error)
+}
+
+type dashboardService struct {
+	gh    github.Client
+	repo  string
+	title string
+	limit int // Size limit of every body, markers included
+}
+
+// NewDashboardService publishes the dashboard to an issue titled title in repo.
+func NewDashboardService(gh github.Client, repo, title string) DashboardService {
+	return &dashboardService{gh: gh, repo: repo, title: title, limit: maxIssueBody}
+}
+
+func (s *dashboardService) Publish(ctx context.Context, content string) (string, error) {
+	parts := splitMarkdown(content, s.limit-len(dashboardPartMarker)-1)
+	body := dashboardMarker + "\n" + parts[0]
+
+	issue, err := s.find(ctx)
+	if err != nil {
+		return "", fmt.Errorf("finding dashboard issue: %w", err)
+	}
+
+	switch {
+	case issue == nil:
+		issue, err = s.gh.CreateIssue(ctx, s.repo, s.title, body, nil)
+		if err != nil {
+			return "", fmt.Errorf("creating dashboard issue: %w", err)
+		}
+		if err := s.gh.PinIssue(ctx, issue.GetNodeID()); err != nil {
+			logging.FromContext(ctx).Warn("could not pin dashboard issue", logging.Error(err))
+		}
+	case issue.GetBody() != body || issue.GetTitle() != s.title:
+		if _, err := s.gh.EditIssue(ctx, s.repo, issue.GetNumber(), s.title, body); err != nil {
+			return "", fmt.Errorf("updating dashboard issue: %w", err)
+		}
+	}
+
+	if err := s.syncComments(ctx, issue.GetNumber(), parts[1:]); err != nil {
+		return "", fmt.Errorf("updating dashboard comments: %w", err)
+	}
+	return issue.GetHTMLURL(), nil
+}
+
+// find returns the open dashboard issue, the oldest if there are several, or
+// nil. The issues of the bot are listed rather than searched: the search index
+// lags behind, and would miss the issue created by the previous run.
+func (s *dashboardService) find(ctx context.Context) (*gh.Issue, error) {
+	login, err := s.gh.AuthenticatedUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting authenticated user: %w", err)
+	}
+	issues, err := s.gh.ListIssues(ctx, s.repo, login)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *gh.Issue
+	for _, issue := range issues {
+		// The bot opens other issues, the marker tells the dashboard apart
+		if !strings.HasPrefix(issue.GetBody(), dashboardMarker) {
+			continue
+		}
+		if found == nil || issue.GetNumber() < found.GetNumber() {
+			found = issue
+		}
+	}
+	return found, nil
+}
+
+// syncComments makes the dashboard comments of the issue hold parts, editing
+// the existing ones in order, then creating or deleting the difference.
+func (s *dashboardService) syncComments(ctx context.Context, number int, parts []string) error {
+	comments, err := s.gh.ListIssueComments(ctx, s.repo, number)
+	if err != nil {
+		return err
+	}
+
+	var existing []*gh.IssueComment
+	for _, comment := range comments {
+		if strings.HasPrefix(comment.GetBody(), dashboardPartMarker) {
+			existing = append(existing, comment)
+		}
+	}
+
+	for i, part := range parts {
+		body := dashboardPartMarker + "\n" + part
+		if i >= len(existing) {
+			if err := s.gh.CreateIssueComment(ctx, s.repo, number, body); err != nil {
+				return err
+			}
+			continue
+		}
+		if existing[i].GetBody() != body {
+			if err := s.gh.EditIssueComment(ctx, s.repo, existing[i].GetID(), body); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, comment := range existing[min(len(parts), len(existing)):] {
+		if err := s.gh.DeleteIssueComment(ctx, s.repo, comment.GetID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitMarkdown cuts content at line boundaries into parts of at most limit
+// bytes. A table cut in two has its header repeated at the start of the next part.
+func splitMarkdown(content string, limit int) []string {
+	lines := strings.SplitAfter(strings.TrimRight(content, "\n"), "\n")
+
+	var parts []string
+	var part strings.Builder
+	header, headerLine := "", -1
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "|") {
+			header, headerLine = "", -1
+		} else if headerLine < 0 && i+1 < len(lines) {
+			header, headerLine = line+lines[i+1], i
+		}
+
+		if part.Len() > 0 && part.Len()+len(line) > limit {
+			parts = append(parts, strings.TrimRight(part.String(), "\n"))
+			part.Reset()
+			if headerLine >= 0 && i > headerLine+1 {
+				part.WriteString(header)
+			}
+		}
+		part.WriteString(line)
+	}
+	return append(parts, strings.TrimRight(part.String(), "\n"))
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+const botLogin = "policy-bot"
+
+func TestDashboardPublish_CreatesAndPins(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	body := dashboardMarker + "\n## Policy compliance"
+
+	mockClient.
+		EXPECT().
+		AuthenticatedUser(mock.Anything).
+		Once().
+		Return(botLogin, nil)
+	mockClient.
+		EXPECT().
+		ListIssues(mock.Anything, "policies", botLogin).
+		Once().
+		Return([]*gh.Issue{{Number: gh.Ptr(3), Body: gh.Ptr("Another issue of the bot, mentioning " + dashboardMarker)}}, nil)
+	mockClient.
+		EXPECT().
+		CreateIssue(mock.Anything, "policies", "Policy compliance", body, []string(nil)).
+		Once().
+		Return(&gh.Issue{Number: gh.Ptr(12), NodeID: gh.Ptr("I_kwDO12"), HTMLURL: gh.Ptr("https://github.com/org/policies/issues/12")}, nil)
+	mockClient.
+		EXPECT().
+		PinIssue(mock.Anything, "I_kwDO12").
+		Once().
+		Return(errors.New("3 issues already pinned"))
+	mockClient.
+		EXPECT().
+		ListIssueComments(mock.Anything, "policies", 12).
+		Once().
+		Return(nil, nil)
+
+	svc := NewDashboardService(mockClient, "policies", "Policy compliance")
+	url, err := svc.Publish(ctx, "## Policy compliance\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/policies/issues/12", url)
+}
+
+func TestDashboardPublish_UpToDate(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	body := dashboardMarker + "\n## Policy compliance"
+
+	mockClient.
+		EXPECT().
+		AuthenticatedUser(mock.Anything).
+		Once().
+		Return(botLogin, nil)
+	mockClient.
+		EXPECT().
+		ListIssues(mock.Anything, "policies", botLogin).
+		Once().
+		Return([]*gh.Issue{
+			{Number: gh.Ptr(14), Title: gh.Ptr("Policy compliance"), Body: gh.Ptr(body)},
+			{Number: gh.Ptr(12), Title: gh.Ptr("Policy compliance"), Body: gh.Ptr(body), HTMLURL: gh.Ptr("https://github.com/org/policies/issues/12")},
+		}, nil)
+	mockClient.
+		EXPECT().
+		ListIssueComments(mock.Anything, "policies", 12).
+		Once().
+		Return([]*gh.IssueComment{{ID: gh.Ptr(int64(1)), Body: gh.Ptr("Thanks!")}}, nil)
+
+	svc := NewDashboardService(mockClient, "policies", "Policy compliance")
+	url, err := svc.Publish(ctx, "## Policy compliance")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/policies/issues/12", url)
+}
+
+func TestDashboardPublish_SplitsIntoComments(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	header := "| Repository | go |\n|---|:-:|\n"
+	content := header + "| org/repo1 | ✅ |\n| org/repo2 | ✅ |\n| org/repo3 | ✅ |\n"
+
+	mockClient.
+		EXPECT().
+		AuthenticatedUser(mock.Anything).
+		Once().
+		Return(botLogin, nil)
+	mockClient.
+		EXPECT().
+		ListIssues(mock.Anything, "policies", botLogin).
+		Once().
+		Return([]*gh.Issue{{Number: gh.Ptr(12), Title: gh.Ptr("Old title"), Body: gh.Ptr(dashboardMarker + "\nold")}}, nil)
+	mockClient.
+		EXPECT().
+		EditIssue(mock.Anything, "policies", 12, "Policy compliance", dashboardMarker+"\n"+header+"| org/repo1 | ✅ |").
+		Once().
+		Return(&gh.Issue{}, nil)
+	mockClient.
+		EXPECT().
+		ListIssueComments(mock.Anything, "policies", 12).
+		Once().
+		Return([]*gh.IssueComment{
+			{ID: gh.Ptr(int64(1)), Body: gh.Ptr(dashboardPartMarker + "\n" + header + "| org/repo2 | ✅ |")},
+			{ID: gh.Ptr(int64(2)), Body: gh.Ptr("Why is repo2 drifted?")},
+		}, nil)
+	mockClient.
+		EXPECT().
+		CreateIssueComment(mock.Anything, "policies", 12, dashboardPartMarker+"\n"+header+"| org/repo3 | ✅ |").
+		Once().
+		Return(nil)
+
+	svc := NewDashboardService(mockClient, "policies", "Policy compliance").(*dashboardService)
+	svc.limit = len(dashboardPartMarker) + 1 + len(header) + len("| org/repo1 | ✅ |\n")
+	_, err := svc.Publish(ctx, content)
+
+	assert.NoError(t, err)
+}
+
+func TestDashboardPublish_DeletesExtraComments(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	body := dashboardMarker + "\n## Policy compliance"
+
+	mockClient.
+		EXPECT().
+		AuthenticatedUser(mock.Anything).
+		Once().
+		Return(botLogin, nil)
+	mockClient.
+		EXPECT().
+		ListIssues(mock.Anything, "policies", botLogin).
+		Once().
+		Return([]*gh.Issue{{Number: gh.Ptr(12), Title: gh.Ptr("Policy compliance"), Body: gh.Ptr(body)}}, nil)
+	mockClient.
+		EXPECT().
+		ListIssueComments(mock.Anything, "policies", 12).
+		Once().
+		Return([]*gh.IssueComment{
+			{ID: gh.Ptr(int64(1)), Body: gh.Ptr(dashboardPartMarker + "\n| org/repo2 | ✅ |")},
+			{ID: gh.Ptr(int64(2)), Body: gh.Ptr(dashboardPartMarker + "\n| org/repo3 | ✅ |")},
+		}, nil)
+	mockClient.EXPECT().DeleteIssueComment(mock.Anything, "policies", int64(1)).Once().Return(nil)
+	mockClient.EXPECT().DeleteIssueComment(mock.Anything, "policies", int64(2)).Once().Return(nil)
+
+	svc := NewDashboardService(mockClient, "policies", "Policy compliance")
+	_, err := svc.Publish(ctx, "## Policy compliance")
+
+	assert.NoError(t, err)
+}
+
+func TestDashboardPublish_ListError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		AuthenticatedUser(mock.Anything).
+		Once().
+		Return(botLogin, nil)
+	mockClient.
+		EXPECT().
+		ListIssues(mock.Anything, "policies", botLogin).
+		Once().
+		Return(nil, errors.New("rate limited"))
+
+	svc := NewDashboardService(mockClient, "policies", "Policy compliance")
+	_, err := svc.Publish(ctx, "## Policy compliance")
+
+	assert.EqualError(t, err, "finding dashboard issue: rate limited")
+}
+
+func TestDashboardPublish_AuthenticatedUserError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		AuthenticatedUser(mock.Anything).
+		Once().
+		Return("", errors.New("401 Bad credentials"))
+
+	svc := NewDashboardService(mockClient, "policies", "Policy compliance")
+	_, err := svc.Publish(ctx, "## Policy compliance")
+
+	assert.EqualError(t, err, "finding dashboard issue: getting authenticated user: 401 Bad credentials")
+}
+
+func TestSplitMarkdown(t *testing.T) {
+	t.Run("fits", func(t *testing.T) {
+		assert.Equal(t, []string{"a\nb"}, splitMarkdown("a\nb\n", 100))
+	})
+
+	t.Run("repeats table headers", func(t *testing.T) {
+		content := "intro\n| h |\n|---|\n| 1 |\n| 2 |\n| 3 |\noutro\n"
+
+		parts := splitMarkdown(content, len("intro\n| h |\n|---|\n| 1 |\n"))
+
+		assert.Equal(t, []string{"intro\n| h |\n|---|\n| 1 |", "| h |\n|---|\n| 2 |\n| 3 |", "outro"}, parts)
+		for _, part := range parts {
+			assert.LessOrEqual(t, len(part), len("intro\n| h |\n|---|\n| 1 |\n"))
+		}
+	})
+
+	t.Run("does not repeat headers outside tables", func(t *testing.T) {
+		parts := splitMarkdown("| h |\n|---|\n| 1 |\n"+strings.Repeat("x", 10)+"\n", 20)
+
+		assert.Equal(t, []string{"| h |\n|---|\n| 1 |", "xxxxxxxxxx"}, parts)
+	})
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// exemptionActive reports whether e still applies at now. The expiry date is
+// inclusive, the exemption ends when the following day starts (UTC).
+func exemptionActive(e models.Exemption, now time.Time) bool {
+	return now.Before(e.Expires.AddDate(0, 0, 1))
+}
+
+func activeExemption(exemptions []models.Exemption, repo models.Repository, policyName string, now time.Time) *models.Exemption {
+	for i, e := range exemptions {
+		if e.Repository == repo.Name && e.Policy == policyName && exemptionActive(e, now) {
+			return &exemptions[i]
+		}
+	}
+	return nil
+}
+
+// ExpiringExemptions returns the active exemptions ending within the given number of days from now.
+func ExpiringExemptions(exemptions []models.Exemption, now time.Time, days int) []models.Exemption {
+	var expiring []models.Exemption
+	limit := now.AddDate(0, 0, days)
+	for _, e := range exemptions {
+		if exemptionActive(e, now) && e.Expires.Before(limit) {
+			expiring = append(expiring, e)
+		}
+	}
+	return expiring
+}
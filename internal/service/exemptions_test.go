@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestActiveExemption(t *testing.T) {
+	exemptions := []models.Exemption{
+		{Repository: "my-repo", Policy: "dockerfile", Expires: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+	}
+	repo := models.Repository{Name: "my-repo"}
+
+	lastDay := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	assert.NotNil(t, activeExemption(exemptions, repo, "dockerfile", lastDay))
+
+	dayAfter := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, activeExemption(exemptions, repo, "dockerfile", dayAfter))
+
+	assert.Nil(t, activeExemption(exemptions, repo, "go", lastDay))
+	assert.Nil(t, activeExemption(exemptions, models.Repository{Name: "other"}, "dockerfile", lastDay))
+}
+
+func TestExpiringExemptions(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	soon := models.Exemption{Repository: "a", Policy: "dockerfile", Expires: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)}
+	later := models.Exemption{Repository: "b", Policy: "dockerfile", Expires: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)}
+	expired := models.Exemption{Repository: "c", Policy: "dockerfile", Expires: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+
+	expiring := ExpiringExemptions([]models.Exemption{soon, later, expired}, now, 14)
+
+	assert.Equal(t, []models.Exemption{soon}, expiring)
+}
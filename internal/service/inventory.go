@@ -0,0 +1,93 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// InventoryService lists the actions and reusable workflows used by the
+// workflows of repositories.
+type InventoryService interface {
+	// Collect returns every action used by the workflows of repos, sorted by
+	// action and version. A repository that cannot be read is logged and skipped.
+	Collect(ctx context.Context, repos []models.Repository) ([]models.ActionUsage, error)
+}
+
+type inventoryService struct {
+	gh    github.Client
+	repos RepositoryService
+}
+
+func NewInventoryService(gh github.Client) InventoryService {
+	return &inventoryService{gh: gh, repos: NewRepositoriesService(gh)}
+}
+
+func (s *inventoryService) Collect(ctx context.Context, repos []models.Repository) ([]models.ActionUsage, error) {
+	usages := make(map[actionRef][]models.ActionUse)
+
+	for _, repo := range repos {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		uses, err := s.repositoryUses(ctx, repo)
+		if err != nil {
+			logging.FromContext(ctx).Warn("could not inventory repository", logging.Repo(repo.FullName), logging.Error(err))
+			continue
+		}
+		for ref, paths := range uses {
+			for _, path := range paths {
+				usages[ref] = append(usages[ref], models.ActionUse{Repository: repo.FullName, Path: path})
+			}
+		}
+	}
+
+	inventory := make([]models.ActionUsage, 0, len(usages))
+	for ref, uses := range usages {
+		inventory = append(inventory, models.ActionUsage{Action: ref.Path, Version: ref.Ref, Tag: ref.Tag, Uses: uses})
+	}
+	slices.SortFunc(inventory, func(a, b models.ActionUsage) int {
+		return cmp.Or(cmp.Compare(a.Action, b.Action), cmp.Compare(a.Version, b.Version), cmp.Compare(a.Tag, b.Tag))
+	})
+	return inventory, nil
+}
+
+// repositoryUses returns the workflows of repo using each action, by path.
+func (s *inventoryService) repositoryUses(ctx context.Context, repo models.Repository) (map[actionRef][]string, error) {
+	files, err := s.repos.ListFiles(ctx, repo.Name)
+	if err != nil {
+		return nil, fmt.Errorf("listing files: %w", err)
+	}
+
+	uses := make(map[actionRef][]string)
+	for _, path := range files {
+		if !isWorkflowFile(path) {
+			continue
+		}
+
+		content, _, _, err := s.gh.GetContentsRaw(ctx, repo.Name, path)
+		if err != nil {
+			return nil, fmt.Errorf("getting workflow %s: %w", path, err)
+		}
+		workflow, err := content.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("decoding workflow content %s: %w", path, err)
+		}
+
+		for _, line := range strings.Split(workflow, "\n") {
+			ref, _, ok := parseUses(strings.TrimSuffix(line, "\r"))
+			// A workflow using an action in several steps is listed once
+			if ok && !slices.Contains(uses[ref], path) {
+				uses[ref] = append(uses[ref], path)
+			}
+		}
+	}
+	return uses, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func treeOf(paths ...string) *gh.Tree {
+	tree := &gh.Tree{}
+	for _, path := range paths {
+		tree.Entries = append(tree.Entries, &gh.TreeEntry{Path: gh.Ptr(path), Type: gh.Ptr("blob")})
+	}
+	return tree
+}
+
+func contentOf(content string) *gh.RepositoryContent {
+	return &gh.RepositoryContent{Content: gh.Ptr(base64.StdEncoding.EncodeToString([]byte(content))), Encoding: gh.Ptr("base64")}
+}
+
+func TestInventory_Collect(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetTree(mock.Anything, "api", "HEAD", true).Once().
+		Return(treeOf("go.mod", ".github/workflows/ci.yml", ".github/workflows/release.yaml", ".github/dependabot.yml"), &gh.Response{}, nil)
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "api", ".github/workflows/ci.yml").Once().
+		Return(contentOf("jobs:\n  test:\n    steps:\n      - uses: actions/checkout@v4\n      - uses: ./.github/actions/local\n  lint:\n    steps:\n      - uses: actions/checkout@v4\n"), nil, &gh.Response{}, nil)
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "api", ".github/workflows/release.yaml").Once().
+		Return(contentOf("jobs:\n  release:\n    uses: tracker-tv/github-actions-ttv/.github/workflows/release.yml@main\n"), nil, &gh.Response{}, nil)
+
+	mockClient.EXPECT().GetTree(mock.Anything, "empty", "HEAD", true).Once().
+		Return(nil, nil, errors.New("409 Git Repository is empty"))
+
+	mockClient.EXPECT().GetTree(mock.Anything, "web", "HEAD", true).Once().
+		Return(treeOf(".github/workflows/ci.yml"), &gh.Response{}, nil)
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "web", ".github/workflows/ci.yml").Once().
+		Return(contentOf("steps:\n  - uses: actions/checkout@v3\n  - uses: actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2\n  - uses: actions/checkout@v4\n"), nil, &gh.Response{}, nil)
+
+	repos := []models.Repository{
+		{Name: "api", FullName: "tracker-tv/api"},
+		{Name: "empty", FullName: "tracker-tv/empty"},
+		{Name: "web", FullName: "tracker-tv/web"},
+	}
+
+	inventory, err := NewInventoryService(mockClient).Collect(ctx, repos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ActionUsage{
+		{Action: "actions/checkout", Version: "11bd71901bbe5b1630ceea73d27597364c9af683", Tag: "v4.2.2", Uses: []models.ActionUse{
+			{Repository: "tracker-tv/web", Path: ".github/workflows/ci.yml"},
+		}},
+		{Action: "actions/checkout", Version: "v3", Uses: []models.ActionUse{
+			{Repository: "tracker-tv/web", Path: ".github/workflows/ci.yml"},
+		}},
+		{Action: "actions/checkout", Version: "v4", Uses: []models.ActionUse{
+			{Repository: "tracker-tv/api", Path: ".github/workflows/ci.yml"},
+			{Repository: "tracker-tv/web", Path: ".github/workflows/ci.yml"},
+		}},
+		{Action: "tracker-tv/github-actions-ttv/.github/workflows/release.yml", Version: "main", Uses: []models.ActionUse{
+			{Repository: "tracker-tv/api", Path: ".github/workflows/release.yaml"},
+		}},
+	}, inventory)
+}
+
+func TestInventory_Collect_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewInventoryService(githubMocks.NewMockClient(t)).Collect(ctx, []models.Repository{{Name: "api", FullName: "tracker-tv/api"}})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
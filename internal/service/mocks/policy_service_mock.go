@@ -8,6 +8,7 @@ import (
 	"context"
 
 	mock "github.com/stretchr/testify/mock"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
 	"github.com/tracker-tv/github-policy-bots/models"
 )
 
@@ -39,24 +40,22 @@ func (_m *MockPolicyService) EXPECT() *MockPolicyService_Expecter {
 }
 
 // Ensure provides a mock function for the type MockPolicyService
-func (_mock *MockPolicyService) Ensure(ctx context.Context, repo models.Repository, repoFiles []string) ([]models.PolicyDeviation, error) {
+func (_mock *MockPolicyService) Ensure(ctx context.Context, repo models.Repository, repoFiles []string) (service.Evaluation, error) {
 	ret := _mock.Called(ctx, repo, repoFiles)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Ensure")
 	}
 
-	var r0 []models.PolicyDeviation
+	var r0 service.Evaluation
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Repository, []string) ([]models.PolicyDeviation, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Repository, []string) (service.Evaluation, error)); ok {
 		return returnFunc(ctx, repo, repoFiles)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Repository, []string) []models.PolicyDeviation); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Repository, []string) service.Evaluation); ok {
 		r0 = returnFunc(ctx, repo, repoFiles)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PolicyDeviation)
-		}
+		r0 = ret.Get(0).(service.Evaluation)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Repository, []string) error); ok {
 		r1 = returnFunc(ctx, repo, repoFiles)
@@ -102,12 +101,185 @@ func (_c *MockPolicyService_Ensure_Call) Run(run func(ctx context.Context, repo
 	return _c
 }
 
-func (_c *MockPolicyService_Ensure_Call) Return(policyDrifts []models.PolicyDeviation, err error) *MockPolicyService_Ensure_Call {
-	_c.Call.Return(policyDrifts, err)
+func (_c *MockPolicyService_Ensure_Call) Return(evaluation service.Evaluation, err error) *MockPolicyService_Ensure_Call {
+	_c.Call.Return(evaluation, err)
+	return _c
+}
+
+func (_c *MockPolicyService_Ensure_Call) RunAndReturn(run func(ctx context.Context, repo models.Repository, repoFiles []string) (service.Evaluation, error)) *MockPolicyService_Ensure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Policies provides a mock function for the type MockPolicyService
+func (_mock *MockPolicyService) Policies() []models.PolicyWorkflow {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Policies")
+	}
+
+	var r0 []models.PolicyWorkflow
+	if returnFunc, ok := ret.Get(0).(func() []models.PolicyWorkflow); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PolicyWorkflow)
+		}
+	}
+	return r0
+}
+
+// MockPolicyService_Policies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Policies'
+type MockPolicyService_Policies_Call struct {
+	*mock.Call
+}
+
+// Policies is a helper method to define mock.On call
+func (_e *MockPolicyService_Expecter) Policies() *MockPolicyService_Policies_Call {
+	return &MockPolicyService_Policies_Call{Call: _e.mock.On("Policies")}
+}
+
+func (_c *MockPolicyService_Policies_Call) Run(run func()) *MockPolicyService_Policies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPolicyService_Policies_Call) Return(policyWorkflows []models.PolicyWorkflow) *MockPolicyService_Policies_Call {
+	_c.Call.Return(policyWorkflows)
+	return _c
+}
+
+func (_c *MockPolicyService_Policies_Call) RunAndReturn(run func() []models.PolicyWorkflow) *MockPolicyService_Policies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Render provides a mock function for the type MockPolicyService
+func (_mock *MockPolicyService) Render(ctx context.Context, repo models.Repository, repoFiles []string) ([]service.RenderedWorkflow, error) {
+	ret := _mock.Called(ctx, repo, repoFiles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 []service.RenderedWorkflow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Repository, []string) ([]service.RenderedWorkflow, error)); ok {
+		return returnFunc(ctx, repo, repoFiles)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Repository, []string) []service.RenderedWorkflow); ok {
+		r0 = returnFunc(ctx, repo, repoFiles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.RenderedWorkflow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Repository, []string) error); ok {
+		r1 = returnFunc(ctx, repo, repoFiles)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPolicyService_Render_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Render'
+type MockPolicyService_Render_Call struct {
+	*mock.Call
+}
+
+// Render is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo models.Repository
+//   - repoFiles []string
+func (_e *MockPolicyService_Expecter) Render(ctx interface{}, repo interface{}, repoFiles interface{}) *MockPolicyService_Render_Call {
+	return &MockPolicyService_Render_Call{Call: _e.mock.On("Render", ctx, repo, repoFiles)}
+}
+
+func (_c *MockPolicyService_Render_Call) Run(run func(ctx context.Context, repo models.Repository, repoFiles []string)) *MockPolicyService_Render_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Repository
+		if args[1] != nil {
+			arg1 = args[1].(models.Repository)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPolicyService_Render_Call) Return(renderedWorkflows []service.RenderedWorkflow, err error) *MockPolicyService_Render_Call {
+	_c.Call.Return(renderedWorkflows, err)
+	return _c
+}
+
+func (_c *MockPolicyService_Render_Call) RunAndReturn(run func(ctx context.Context, repo models.Repository, repoFiles []string) ([]service.RenderedWorkflow, error)) *MockPolicyService_Render_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function for the type MockPolicyService
+func (_mock *MockPolicyService) Validate(ctx context.Context) map[string]error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 map[string]error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]error)
+		}
+	}
+	return r0
+}
+
+// MockPolicyService_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockPolicyService_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPolicyService_Expecter) Validate(ctx interface{}) *MockPolicyService_Validate_Call {
+	return &MockPolicyService_Validate_Call{Call: _e.mock.On("Validate", ctx)}
+}
+
+func (_c *MockPolicyService_Validate_Call) Run(run func(ctx context.Context)) *MockPolicyService_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPolicyService_Validate_Call) Return(sToErr map[string]error) *MockPolicyService_Validate_Call {
+	_c.Call.Return(sToErr)
 	return _c
 }
 
-func (_c *MockPolicyService_Ensure_Call) RunAndReturn(run func(ctx context.Context, repo models.Repository, repoFiles []string) ([]models.PolicyDeviation, error)) *MockPolicyService_Ensure_Call {
+func (_c *MockPolicyService_Validate_Call) RunAndReturn(run func(ctx context.Context) map[string]error) *MockPolicyService_Validate_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+)
+
+// usesPattern matches the uses: line of a step or of a job calling a reusable
+// workflow, e.g. `      - uses: "actions/checkout@v4" # comment`.
+var usesPattern = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(["']?)([^\s"'#@]+)@([^\s"'#]+)(["']?)(\s+#.*)?$`)
+
+// commitSHAPattern matches a full commit SHA, the only immutable ref.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// actionRef is an action or reusable workflow referenced by a uses: line.
+type actionRef struct {
+	Owner, Repo string
+	Path        string // owner/repo, followed by the path of the action or workflow in the repository
+	Ref         string
+	Tag         string // Version in the comment of a ref pinned to a commit SHA, e.g. "v4"
+}
+
+func (r actionRef) String() string {
+	return r.Path + "@" + r.Ref
+}
+
+// parseUses returns the reference of a uses: line, false when line is not
+// one or references a local action or a Docker image.
+func parseUses(line string) (actionRef, []string, bool) {
+	match := usesPattern.FindStringSubmatch(line)
+	if match == nil || match[2] != match[5] || strings.HasPrefix(match[3], ".") || strings.Contains(match[3], "://") {
+		return actionRef{}, nil, false
+	}
+	parts := strings.Split(match[3], "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return actionRef{}, nil, false
+	}
+	ref := actionRef{Owner: parts[0], Repo: parts[1], Path: match[3], Ref: match[4]}
+	if commitSHAPattern.MatchString(ref.Ref) {
+		if comment := strings.Fields(strings.TrimPrefix(strings.TrimSpace(match[6]), "#")); len(comment) > 0 {
+			ref.Tag = comment[0]
+		}
+	}
+	return ref, match, true
+}
+
+// thirdParty reports whether ref must be pinned in the repositories of owner:
+// the actions of the owner itself are trusted at any ref.
+func (r actionRef) thirdParty(owner string) bool {
+	return !strings.EqualFold(r.Owner, owner) && !commitSHAPattern.MatchString(r.Ref)
+}
+
+// actionPinner rewrites the third-party references of workflows to the commit
+// SHA of their ref. Resolved refs are cached for the life of the pinner, which
+// the bot creates for every run.
+type actionPinner struct {
+	gh github.Client
+
+	mu       sync.Mutex
+	resolved map[string]string // Commit SHA by owner/repo@ref
+}
+
+func newActionPinner(gh github.Client) *actionPinner {
+	return &actionPinner{gh: gh, resolved: make(map[string]string)}
+}
+
+// Pin rewrites every third-party `uses: owner/action@ref` of content to
+// `uses: owner/action@<sha> # ref`. owner is the owner of the repository the
+// content is for, its own actions are left as is.
+func (p *actionPinner) Pin(ctx context.Context, owner, content string) (string, error) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		body, cr := strings.CutSuffix(line, "\r")
+		ref, match, ok := parseUses(body)
+		if !ok || !ref.thirdParty(owner) {
+			continue
+		}
+
+		sha, err := p.resolve(ctx, ref)
+		if err != nil {
+			return "", fmt.Errorf("resolving %s: %w", ref, err)
+		}
+		lines[i] = fmt.Sprintf("%s%s%s@%s%s # %s", match[1], match[2], ref.Path, sha, match[5], ref.Ref)
+		if cr {
+			lines[i] += "\r"
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (p *actionPinner) resolve(ctx context.Context, ref actionRef) (string, error) {
+	key := ref.Owner + "/" + ref.Repo + "@" + ref.Ref
+
+	p.mu.Lock()
+	sha, ok := p.resolved[key]
+	p.mu.Unlock()
+	if ok {
+		return sha, nil
+	}
+
+	sha, err := p.gh.ResolveRef(ctx, ref.Owner, ref.Repo, ref.Ref)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.resolved[key] = sha
+	p.mu.Unlock()
+	return sha, nil
+}
+
+// unpinnedActions returns the third-party references of content that are not
+// pinned to a commit SHA, in the order they appear.
+func unpinnedActions(owner, content string) []string {
+	var refs []string
+	for _, line := range strings.Split(content, "\n") {
+		ref, _, ok := parseUses(strings.TrimSuffix(line, "\r"))
+		if ok && ref.thirdParty(owner) {
+			refs = append(refs, ref.String())
+		}
+	}
+	return refs
+}
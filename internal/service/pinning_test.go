@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+)
+
+const (
+	checkoutSHA = "11bd71901bbe5b1630ceea73d27597364c9af683"
+	setupGoSHA  = "0aaccfd150d50ccaeb58ebd88d36e91967a5f35b"
+)
+
+func TestActionPinner_Pin(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.EXPECT().ResolveRef(mock.Anything, "actions", "checkout", "v4").Once().Return(checkoutSHA, nil)
+	mockClient.EXPECT().ResolveRef(mock.Anything, "actions", "setup-go", "v5.0.1").Once().Return(setupGoSHA, nil)
+
+	content := `jobs:
+  test:
+    steps:
+      - uses: actions/checkout@v4
+      - name: Set up Go
+        uses: "actions/setup-go@v5.0.1" # Go
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.20
+      - uses: tracker-tv/github-actions-ttv/setup@main
+      - uses: actions/cache@` + checkoutSHA + ` # v4.2.0
+  lint:
+    steps:
+      - uses: actions/checkout@v4` + "\r" + `
+  release:
+    uses: tracker-tv/github-actions-ttv/.github/workflows/release.yml@main
+`
+
+	pinned, err := newActionPinner(mockClient).Pin(context.Background(), "tracker-tv", content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `jobs:
+  test:
+    steps:
+      - uses: actions/checkout@`+checkoutSHA+` # v4
+      - name: Set up Go
+        uses: "actions/setup-go@`+setupGoSHA+`" # v5.0.1
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.20
+      - uses: tracker-tv/github-actions-ttv/setup@main
+      - uses: actions/cache@`+checkoutSHA+` # v4.2.0
+  lint:
+    steps:
+      - uses: actions/checkout@`+checkoutSHA+` # v4`+"\r"+`
+  release:
+    uses: tracker-tv/github-actions-ttv/.github/workflows/release.yml@main
+`, pinned)
+}
+
+func TestActionPinner_CachesAcrossWorkflows(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.EXPECT().ResolveRef(mock.Anything, "github", "codeql-action", "v3").Once().Return(checkoutSHA, nil)
+	pinner := newActionPinner(mockClient)
+
+	for _, content := range []string{
+		"- uses: github/codeql-action/init@v3\n",
+		"- uses: github/codeql-action/analyze@v3\n",
+	} {
+		_, err := pinner.Pin(context.Background(), "tracker-tv", content)
+		assert.NoError(t, err)
+	}
+}
+
+func TestActionPinner_ResolveError(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.EXPECT().ResolveRef(mock.Anything, "actions", "checkout", "v99").Twice().Return("", errors.New("404 Not Found"))
+	pinner := newActionPinner(mockClient)
+
+	_, err := pinner.Pin(context.Background(), "tracker-tv", "- uses: actions/checkout@v99\n")
+	assert.EqualError(t, err, "resolving actions/checkout@v99: 404 Not Found")
+
+	// Failures are not cached
+	_, err = pinner.Pin(context.Background(), "tracker-tv", "- uses: actions/checkout@v99\n")
+	assert.Error(t, err)
+}
+
+func TestUnpinnedActions(t *testing.T) {
+	content := "steps:\n" +
+		"  - uses: actions/checkout@v4\n" +
+		"  - uses: actions/cache@" + checkoutSHA + " # v4\n" +
+		"  - uses: Tracker-TV/actions/setup@main\n" +
+		"  - uses: ./local\n" +
+		"  - run: echo uses: fake/action@v1\n" +
+		"  - uses: 'docker/build-push-action@v6'\n"
+
+	assert.Equal(t, []string{"actions/checkout@v4", "docker/build-push-action@v6"}, unpinnedActions("tracker-tv", content))
+}
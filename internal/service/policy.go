@@ -5,85 +5,515 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/workflow"
 	"github.com/tracker-tv/github-policy-bots/models"
 )
 
 type PolicyService interface {
-	Ensure(ctx context.Context, repo models.Repository, repoFiles []string) ([]models.PolicyDeviation, error)
+	Ensure(ctx context.Context, repo models.Repository, repoFiles []string) (Evaluation, error)
+	Render(ctx context.Context, repo models.Repository, repoFiles []string) ([]RenderedWorkflow, error)
+	Policies() []models.PolicyWorkflow
+	Validate(ctx context.Context) map[string]error
+}
+
+// Evaluation is the outcome of the policies of a service for a repository.
+type Evaluation struct {
+	Deviations []models.PolicyDeviation
+	// Applied names the policies which apply to the repository, compliant or not.
+	// The others, e.g. not matching its files or opted out, are not applicable.
+	Applied []string
+	// Errors holds, by name, the applied policies which could not be evaluated,
+	// e.g. with an invalid source.
+	Errors map[string]error
+	// Workflows holds the workflows of the applied policies as committed on
+	// the default branch of the repository.
+	Workflows []RenderedWorkflow
+}
+
+// RenderedWorkflow is the content a policy expects in a repository, as it would be committed.
+type RenderedWorkflow struct {
+	Policy     models.PolicyWorkflow
+	TargetPath string
+	Content    string
 }
 
 type policyService struct {
-	workflows  []models.PolicyWorkflow
-	gh         github.Client
-	httpClient *http.Client
+	workflows    []models.PolicyWorkflow
+	only         []string
+	sourceChange *models.SourceChange
+	exemptions   []models.Exemption
+	gh           github.Client
+	httpClient   *http.Client
+	pinner       *actionPinner
+	invalid      map[string]error
+	now          func() time.Time
+}
+
+// PolicyOption configures optional behaviour of the policy service.
+type PolicyOption func(*policyService)
+
+// WithExemptions waives the deviations covered by an active exemption.
+func WithExemptions(exemptions []models.Exemption) PolicyOption {
+	return func(s *policyService) {
+		s.exemptions = exemptions
+	}
+}
+
+// OnlyPolicies restricts evaluation to the named policies. The repository
+// configuration is still validated against every policy.
+func OnlyPolicies(names ...string) PolicyOption {
+	return func(s *policyService) {
+		s.only = names
+	}
 }
 
-func NewPolicyService(workflows []models.PolicyWorkflow, gh github.Client) PolicyService {
-	return &policyService{
+// WithSourceChange records on every deviation the change to the policy sources that triggered the run.
+func WithSourceChange(change models.SourceChange) PolicyOption {
+	return func(s *policyService) {
+		s.sourceChange = &change
+	}
+}
+
+// WithHTTPClient fetches the policy sources with c instead of http.DefaultClient,
+// e.g. to read them from local files.
+func WithHTTPClient(c *http.Client) PolicyOption {
+	return func(s *policyService) {
+		s.httpClient = c
+	}
+}
+
+// WithActionPinning pins the third-party actions of the expected workflows to
+// the commit SHA of their ref, and reports the unpinned ones of the workflows
+// no policy manages.
+func WithActionPinning() PolicyOption {
+	return func(s *policyService) {
+		s.pinner = newActionPinner(s.gh)
+	}
+}
+
+func NewPolicyService(workflows []models.PolicyWorkflow, gh github.Client, opts ...PolicyOption) PolicyService {
+	s := &policyService{
 		workflows:  workflows,
 		gh:         gh,
 		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Policies returns the policies the service enforces.
+func (s *policyService) Policies() []models.PolicyWorkflow {
+	if s.only == nil {
+		return s.workflows
+	}
+	return slices.DeleteFunc(slices.Clone(s.workflows), func(p models.PolicyWorkflow) bool {
+		return !slices.Contains(s.only, p.Name)
+	})
+}
+
+// Validate fetches the source of every policy the service enforces and checks
+// it before any repository is remediated: a workflow must be valid, a template
+// must parse. Templates are checked as workflows once rendered for a repository.
+// It returns the error of every invalid policy by name, Ensure then reports
+// them as errors rather than evaluating them.
+func (s *policyService) Validate(ctx context.Context) map[string]error {
+	invalid := make(map[string]error)
+	for _, policy := range s.Policies() {
+		if policy.Ensure == models.PolicyEnsureAbsent {
+			continue
+		}
+
+		source, err := s.fetchExpectedContent(ctx, policy.Source)
+		if err != nil {
+			invalid[policy.Name] = fmt.Errorf("fetching %s: %w", policy.Source, err)
+			continue
+		}
+		if policy.Template {
+			_, err = parseTemplate(policy.Name, source)
+		} else {
+			err = workflow.Validate(source)
+		}
+		if err != nil {
+			invalid[policy.Name] = fmt.Errorf("%s: %w", policy.Source, err)
+		}
 	}
+	s.invalid = invalid
+	return invalid
 }
 
-func (s *policyService) Ensure(ctx context.Context, repo models.Repository, repoFiles []string) ([]models.PolicyDeviation, error) {
+func (s *policyService) Ensure(ctx context.Context, repo models.Repository, repoFiles []string) (Evaluation, error) {
+	cfg, invalid, err := s.repositoryConfig(ctx, repo, repoFiles)
+	if err != nil {
+		return Evaluation{}, err
+	}
+	if invalid != nil {
+		// Policies are not enforced until the owners fix the file, the bot
+		// would otherwise ignore an opt-out it cannot read.
+		logging.FromContext(ctx).Debug("invalid repository config", logging.Path(invalid.TargetPath), "reason", invalid.Reason)
+		return Evaluation{Deviations: []models.PolicyDeviation{*invalid}}, nil
+	}
+
+	var evaluation Evaluation
+
+	for _, policy := range s.Policies() {
+		override := cfg.Policies[policy.Name]
+		if override.OptOut {
+			logging.FromContext(ctx).Debug("policy opted out", logging.Policy(policy.Name), "justification", override.Justification)
+			continue
+		}
+
+		policy, overrides, err := applyRepositoryConfig(policy, cfg)
+		if err != nil {
+			return Evaluation{}, fmt.Errorf("applying repository config to %s: %w", policy.Name, err)
+		}
+
+		applies, err := s.appliesTo(repoFiles, policy)
+		if err != nil {
+			return Evaluation{}, fmt.Errorf("matching policy %s: %w", policy.Name, err)
+		}
+		if !applies {
+			continue
+		}
+		evaluation.Applied = append(evaluation.Applied, policy.Name)
+
+		exemption := activeExemption(s.exemptions, repo, policy.Name, s.now())
+		if err, ok := s.invalid[policy.Name]; ok && exemption == nil {
+			if evaluation.Errors == nil {
+				evaluation.Errors = make(map[string]error)
+			}
+			evaluation.Errors[policy.Name] = fmt.Errorf("invalid policy: %w", err)
+			continue
+		}
+
+		var deviation *models.PolicyDeviation
+		switch {
+		case policy.Ensure == models.PolicyEnsureAbsent:
+			deviation, err = s.ensureAbsent(ctx, repo, repoFiles, policy)
+		case exemption != nil:
+			// The source is not fetched, a broken one must not fail the repositories exempt from it
+			deviation = &models.PolicyDeviation{
+				Repository:     repo,
+				Policy:         policy,
+				TargetPath:     workflowPath(policy, repoFiles),
+				ExpectedSource: policy.Source,
+			}
+		default:
+			var committed *RenderedWorkflow
+			deviation, committed, err = s.ensurePresent(ctx, repo, repoFiles, policy, override.Parameters)
+			if committed != nil {
+				evaluation.Workflows = append(evaluation.Workflows, *committed)
+			}
+		}
+		if err != nil {
+			return Evaluation{}, err
+		}
+		if deviation == nil {
+			continue
+		}
+
+		deviation.Overrides = overrides
+		deviation.Grouping = cfg.Grouping
+		deviation.SourceChange = s.sourceChange
+		if exemption != nil {
+			deviation.Action = models.PolicyActionExempt
+			deviation.Exemption = exemption
+		}
+		logging.FromContext(ctx).Debug("deviation found",
+			logging.Policy(policy.Name), logging.Action(string(deviation.Action)), logging.Path(deviation.TargetPath),
+			"comparison", deviation.Comparison)
+		evaluation.Deviations = append(evaluation.Deviations, *deviation)
+	}
+
+	// A run restricted to some policies only reports on them
+	if s.pinner != nil && s.only == nil {
+		evaluation.Deviations = append(evaluation.Deviations, s.unpinnedWorkflows(ctx, repo, repoFiles)...)
+	}
+
+	return evaluation, nil
+}
+
+// appliesTo reports whether policy applies to a repository with repoFiles. A
+// retired policy without match_file applies to every repository.
+func (s *policyService) appliesTo(repoFiles []string, policy models.PolicyWorkflow) (bool, error) {
+	if policy.Ensure == models.PolicyEnsureAbsent && policy.MatchFile == "" {
+		return true, nil
+	}
+	return s.matchesPolicy(repoFiles, policy.MatchFile)
+}
+
+// unpinnedWorkflows reports the workflows of repo no policy manages that use
+// third-party actions without pinning them to a commit SHA.
+func (s *policyService) unpinnedWorkflows(ctx context.Context, repo models.Repository, repoFiles []string) []models.PolicyDeviation {
+	managed := make(map[string]bool)
+	for _, policy := range s.workflows {
+		managed[workflowPath(policy, repoFiles)] = true
+	}
+
 	var deviations []models.PolicyDeviation
+	for _, path := range repoFiles {
+		if managed[path] || !isWorkflowFile(path) {
+			continue
+		}
+
+		// A workflow which cannot be read is skipped, it is not worth failing the policies of the repository
+		content, _, _, err := s.gh.GetContentsRaw(ctx, repo.Name, path)
+		if err != nil {
+			logging.FromContext(ctx).Warn("could not get workflow", logging.Path(path), logging.Error(err))
+			continue
+		}
+		current, err := content.GetContent()
+		if err != nil {
+			logging.FromContext(ctx).Warn("could not decode workflow content", logging.Path(path), logging.Error(err))
+			continue
+		}
+		// Workflows of a former policy name are migrated, not reported
+		if isManaged(current) {
+			continue
+		}
+
+		if refs := unpinnedActions(repoOwner(repo), current); len(refs) > 0 {
+			logging.FromContext(ctx).Debug("unpinned actions", logging.Path(path), "actions", refs)
+			deviations = append(deviations, models.PolicyDeviation{
+				Repository:      repo,
+				Action:          models.PolicyActionUnpinned,
+				TargetPath:      path,
+				CurrentContent:  current,
+				UnpinnedActions: refs,
+			})
+		}
+	}
+	return deviations
+}
+
+// isWorkflowFile reports whether path is a workflow of .github/workflows.
+func isWorkflowFile(path string) bool {
+	name, ok := strings.CutPrefix(path, ".github/workflows/")
+	return ok && !strings.Contains(name, "/") && (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml"))
+}
+
+// repoOwner returns the owner of repo, the organization the bot manages.
+func repoOwner(repo models.Repository) string {
+	owner, _, _ := strings.Cut(repo.FullName, "/")
+	return owner
+}
+
+func (s *policyService) Render(ctx context.Context, repo models.Repository, repoFiles []string) ([]RenderedWorkflow, error) {
+	cfg, invalid, err := s.repositoryConfig(ctx, repo, repoFiles)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return nil, fmt.Errorf("invalid %s: %s", models.RepositoryConfigPath, invalid.Reason)
+	}
+
+	var rendered []RenderedWorkflow
 
 	for _, policy := range s.workflows {
+		override := cfg.Policies[policy.Name]
+		if policy.Ensure == models.PolicyEnsureAbsent || override.OptOut {
+			continue
+		}
+
+		policy, _, err := applyRepositoryConfig(policy, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("applying repository config to %s: %w", policy.Name, err)
+		}
+
 		matched, err := s.matchesPolicy(repoFiles, policy.MatchFile)
 		if err != nil {
 			return nil, fmt.Errorf("matching policy %s: %w", policy.Name, err)
 		}
-
 		if !matched {
 			continue
 		}
 
-		targetPath := fmt.Sprintf(".github/workflows/%s.yml", policy.Name)
-
-		content, _, resp, err := s.gh.GetContentsRaw(ctx, repo.Name, targetPath)
+		content, err := s.expectedContent(ctx, repo, repoFiles, policy, override.Parameters)
 		if err != nil {
-			if resp != nil && resp.StatusCode == http.StatusNotFound {
-				deviations = append(deviations, models.PolicyDeviation{
-					Repository:     repo,
-					Policy:         policy,
-					Action:         models.PolicyActionCreate,
-					TargetPath:     targetPath,
-					ExpectedSource: policy.Source,
-					CurrentContent: "",
-				})
-				continue
+			return nil, err
+		}
+
+		rendered = append(rendered, RenderedWorkflow{
+			Policy:     policy,
+			TargetPath: workflowPath(policy, repoFiles),
+			Content:    wrapContent(content, policy.Name),
+		})
+	}
+
+	return rendered, nil
+}
+
+// ensurePresent reports a deviation when the workflow of a policy matching the
+// repository is missing, outdated or still lives under a former policy name.
+// It also returns the workflow committed on the default branch, if any.
+func (s *policyService) ensurePresent(ctx context.Context, repo models.Repository, repoFiles []string, policy models.PolicyWorkflow, params map[string]string) (*models.PolicyDeviation, *RenderedWorkflow, error) {
+	targetPath := workflowPath(policy, repoFiles)
+	previousPaths := previousWorkflowPaths(policy, repoFiles)
+
+	content, _, resp, err := s.gh.GetContentsRaw(ctx, repo.Name, targetPath)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// The content is fetched now, templates rendered and actions pinned while
+			// the repository files are known, so that it is validated before any PR
+			expectedContent, err := s.expectedContent(ctx, repo, repoFiles, policy, params)
+			if err != nil {
+				return nil, nil, err
 			}
-			return nil, fmt.Errorf("getting workflow %s: %w", targetPath, err)
+			return &models.PolicyDeviation{
+				Repository:      repo,
+				Policy:          policy,
+				Action:          actionFor(models.PolicyActionCreate, previousPaths),
+				TargetPath:      targetPath,
+				ExpectedSource:  policy.Source,
+				ExpectedContent: expectedContent,
+				CurrentContent:  "",
+				PreviousPaths:   previousPaths,
+			}, nil, nil
 		}
+		return nil, nil, fmt.Errorf("getting workflow %s: %w", targetPath, err)
+	}
 
-		currentContent, err := content.GetContent()
+	currentContent, err := content.GetContent()
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding workflow content %s: %w", targetPath, err)
+	}
+	committed := &RenderedWorkflow{Policy: policy, TargetPath: targetPath, Content: currentContent}
+
+	expectedContent, err := s.expectedContent(ctx, repo, repoFiles, policy, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comparison := comparisonOf(policy)
+	if sameContent(comparison, currentContent, wrapContent(expectedContent, policy.Name)) && len(previousPaths) == 0 {
+		return nil, committed, nil
+	}
+
+	return &models.PolicyDeviation{
+		Repository:      repo,
+		Policy:          policy,
+		Action:          actionFor(models.PolicyActionUpdate, previousPaths),
+		TargetPath:      targetPath,
+		ExpectedSource:  policy.Source,
+		ExpectedContent: expectedContent,
+		CurrentContent:  currentContent,
+		PreviousPaths:   previousPaths,
+		Comparison:      comparison,
+	}, committed, nil
+}
+
+// expectedContent fetches the policy source and, for templated policies, renders
+// it for repo. The actions are pinned last, when pinning is enabled.
+func (s *policyService) expectedContent(ctx context.Context, repo models.Repository, repoFiles []string, policy models.PolicyWorkflow, params map[string]string) (string, error) {
+	content, err := s.fetchExpectedContent(ctx, policy.Source)
+	if err != nil {
+		return "", fmt.Errorf("fetching expected content for %s: %w", policy.Name, err)
+	}
+
+	if policy.Template {
+		data, err := s.templateData(ctx, repo, repoFiles, policy, params)
 		if err != nil {
-			return nil, fmt.Errorf("decoding workflow content %s: %w", targetPath, err)
+			return "", fmt.Errorf("building template data for %s: %w", policy.Name, err)
 		}
 
-		expectedContent, err := s.fetchExpectedContent(ctx, policy.Source)
+		content, err = renderTemplate(policy.Name, content, data)
 		if err != nil {
-			return nil, fmt.Errorf("fetching expected content for %s: %w", policy.Name, err)
+			return "", fmt.Errorf("rendering template for %s: %w", policy.Name, err)
 		}
+	}
 
-		wrappedExpectedContent := wrapContent(expectedContent, policy.Name)
-		if currentContent != wrappedExpectedContent {
-			deviations = append(deviations, models.PolicyDeviation{
-				Repository:     repo,
-				Policy:         policy,
-				Action:         models.PolicyActionUpdate,
-				TargetPath:     targetPath,
-				ExpectedSource: policy.Source,
-				CurrentContent: currentContent,
-			})
+	if s.pinner != nil {
+		content, err = s.pinner.Pin(ctx, repoOwner(repo), content)
+		if err != nil {
+			return "", fmt.Errorf("pinning actions for %s: %w", policy.Name, err)
 		}
 	}
+	return content, nil
+}
+
+// ensureAbsent reports a deviation when the workflow of a retired policy is
+// still present in the repository.
+func (s *policyService) ensureAbsent(ctx context.Context, repo models.Repository, repoFiles []string, policy models.PolicyWorkflow) (*models.PolicyDeviation, error) {
+	targetPath := workflowPath(policy, repoFiles)
+	if !slices.Contains(repoFiles, targetPath) {
+		return nil, nil
+	}
 
-	return deviations, nil
+	var currentContent string
+	if policy.OnlyIfManaged {
+		content, _, _, err := s.gh.GetContentsRaw(ctx, repo.Name, targetPath)
+		if err != nil {
+			return nil, fmt.Errorf("getting workflow %s: %w", targetPath, err)
+		}
+		currentContent, err = content.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("decoding workflow content %s: %w", targetPath, err)
+		}
+		if !isManaged(currentContent) {
+			return nil, nil
+		}
+	}
+
+	return &models.PolicyDeviation{
+		Repository:     repo,
+		Policy:         policy,
+		Action:         models.PolicyActionDelete,
+		TargetPath:     targetPath,
+		CurrentContent: currentContent,
+	}, nil
+}
+
+// workflowPath returns the workflow of policy in the repository: the .yaml file
+// when the repository already has one rather than the .yml file, the default.
+func workflowPath(policy models.PolicyWorkflow, repoFiles []string) string {
+	path := fmt.Sprintf(".github/workflows/%s.yml", policy.Name)
+	if yaml := strings.TrimSuffix(path, ".yml") + ".yaml"; !slices.Contains(repoFiles, path) && slices.Contains(repoFiles, yaml) {
+		return yaml
+	}
+	return path
+}
+
+// previousWorkflowPaths returns the workflows of former policy names still present in the repository.
+func previousWorkflowPaths(policy models.PolicyWorkflow, repoFiles []string) []string {
+	var paths []string
+	for _, name := range policy.PreviousNames {
+		path := workflowPath(models.PolicyWorkflow{Name: name}, repoFiles)
+		if slices.Contains(repoFiles, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// actionFor turns action into a migration when workflows of former policy names must be moved.
+func actionFor(action models.PolicyAction, previousPaths []string) models.PolicyAction {
+	if len(previousPaths) > 0 {
+		return models.PolicyActionMigrate
+	}
+	return action
+}
+
+func (s *policyService) matchingFiles(files []string, pattern string) ([]string, error) {
+	var matched []string
+	for _, file := range files {
+		ok, err := doublestar.Match(pattern, file)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, file)
+		}
+	}
+	return matched, nil
 }
 
 func (s *policyService) matchesPolicy(files []string, pattern string) (bool, error) {
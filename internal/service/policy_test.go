@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	gh "github.com/google/go-github/v80/github"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +18,15 @@ import (
 	"github.com/tracker-tv/github-policy-bots/models"
 )
 
+// sourceServer serves content as the source of a policy and returns its URL.
+func sourceServer(t *testing.T, content string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
 func TestNewPolicyService(t *testing.T) {
 	mockClient := githubMocks.NewMockClient(t)
 	workflows := []models.PolicyWorkflow{
@@ -39,10 +51,11 @@ func TestEnsure_NoMatch(t *testing.T) {
 	repoFiles := []string{"main.go", "go.mod", "README.md"}
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.NoError(t, err)
-	assert.Empty(t, violations)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Empty(t, evaluation.Applied)
 }
 
 func TestEnsure_MatchWithMissingWorkflow(t *testing.T) {
@@ -50,7 +63,7 @@ func TestEnsure_MatchWithMissingWorkflow(t *testing.T) {
 	mockClient := githubMocks.NewMockClient(t)
 
 	workflows := []models.PolicyWorkflow{
-		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: "http://example.com/wf.yml"},
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n")},
 	}
 
 	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
@@ -63,14 +76,14 @@ func TestEnsure_MatchWithMissingWorkflow(t *testing.T) {
 		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.NoError(t, err)
-	assert.Len(t, violations, 1)
-	assert.Equal(t, models.PolicyActionCreate, violations[0].Action)
-	assert.Equal(t, ".github/workflows/dockerfile.yml", violations[0].TargetPath)
-	assert.Equal(t, "dockerfile", violations[0].Policy.Name)
-	assert.Empty(t, violations[0].CurrentContent)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionCreate, evaluation.Deviations[0].Action)
+	assert.Equal(t, ".github/workflows/dockerfile.yml", evaluation.Deviations[0].TargetPath)
+	assert.Equal(t, "dockerfile", evaluation.Deviations[0].Policy.Name)
+	assert.Empty(t, evaluation.Deviations[0].CurrentContent)
 }
 
 func TestEnsure_MatchWithOutdatedWorkflow(t *testing.T) {
@@ -103,13 +116,13 @@ func TestEnsure_MatchWithOutdatedWorkflow(t *testing.T) {
 		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.NoError(t, err)
-	assert.Len(t, violations, 1)
-	assert.Equal(t, models.PolicyActionUpdate, violations[0].Action)
-	assert.Equal(t, ".github/workflows/dockerfile.yml", violations[0].TargetPath)
-	assert.Equal(t, "old workflow content", violations[0].CurrentContent)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionUpdate, evaluation.Deviations[0].Action)
+	assert.Equal(t, ".github/workflows/dockerfile.yml", evaluation.Deviations[0].TargetPath)
+	assert.Equal(t, "old workflow content", evaluation.Deviations[0].CurrentContent)
 }
 
 func TestEnsure_MatchWithUpToDateWorkflow(t *testing.T) {
@@ -145,10 +158,88 @@ func TestEnsure_MatchWithUpToDateWorkflow(t *testing.T) {
 		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Equal(t, []string{"dockerfile"}, evaluation.Applied)
+}
+
+func TestEnsure_MatchWithYAMLExtension(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	rawContent := "current workflow content"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(rawContent))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: server.URL},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile", ".github/workflows/dockerfile.yaml"}
+
+	content := &gh.RepositoryContent{
+		Content:  gh.Ptr(base64.StdEncoding.EncodeToString([]byte(wrapContent(rawContent, "dockerfile")))),
+		Encoding: gh.Ptr("base64"),
+	}
+
+	// The existing .yaml workflow is the managed one, no .yml workflow is created next to it
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/dockerfile.yaml").
+		Once().
+		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+}
+
+func TestEnsure_Workflows(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	const source = "on: pull_request\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make test\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(source))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", MatchFile: "go.mod", Source: server.URL},
+		{Name: "lint", MatchFile: "go.mod", Source: server.URL},
+	}
+	committed := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make test\n"
+	content := &gh.RepositoryContent{
+		Content:  gh.Ptr(base64.StdEncoding.EncodeToString([]byte(committed))),
+		Encoding: gh.Ptr("base64"),
+	}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/go.yml").
+		Once().
+		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/lint.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"go.mod", ".github/workflows/go.yml"})
 
 	assert.NoError(t, err)
-	assert.Empty(t, violations)
+	assert.Len(t, evaluation.Deviations, 2)
+	// The workflow to create is not on the default branch yet, the outdated one is as committed
+	assert.Equal(t, []RenderedWorkflow{{Policy: workflows[0], TargetPath: ".github/workflows/go.yml", Content: committed}}, evaluation.Workflows)
 }
 
 func TestEnsure_MultiplePolcies(t *testing.T) {
@@ -185,12 +276,14 @@ func TestEnsure_MultiplePolcies(t *testing.T) {
 		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.NoError(t, err)
-	assert.Len(t, violations, 2)
-	assert.Equal(t, "dockerfile", violations[0].Policy.Name)
-	assert.Equal(t, "go-lint", violations[1].Policy.Name)
+	assert.Len(t, evaluation.Deviations, 2)
+	assert.Equal(t, "dockerfile", evaluation.Deviations[0].Policy.Name)
+	assert.Equal(t, "go-lint", evaluation.Deviations[1].Policy.Name)
+	// python does not match any file of the repository
+	assert.Equal(t, []string{"dockerfile", "go-lint"}, evaluation.Applied)
 }
 
 func TestEnsure_NestedFileMatch(t *testing.T) {
@@ -198,7 +291,7 @@ func TestEnsure_NestedFileMatch(t *testing.T) {
 	mockClient := githubMocks.NewMockClient(t)
 
 	workflows := []models.PolicyWorkflow{
-		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: "http://example.com/wf.yml"},
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n")},
 	}
 
 	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
@@ -211,11 +304,11 @@ func TestEnsure_NestedFileMatch(t *testing.T) {
 		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.NoError(t, err)
-	assert.Len(t, violations, 1)
-	assert.Equal(t, models.PolicyActionCreate, violations[0].Action)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionCreate, evaluation.Deviations[0].Action)
 }
 
 func TestEnsure_GetContentsRawError(t *testing.T) {
@@ -236,10 +329,10 @@ func TestEnsure_GetContentsRawError(t *testing.T) {
 		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusInternalServerError}}, errors.New("internal server error"))
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.Error(t, err)
-	assert.Nil(t, violations)
+	assert.Nil(t, evaluation.Deviations)
 	assert.Contains(t, err.Error(), "getting workflow")
 }
 
@@ -272,10 +365,10 @@ func TestEnsure_FetchExpectedContentError(t *testing.T) {
 		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.Error(t, err)
-	assert.Nil(t, violations)
+	assert.Nil(t, evaluation.Deviations)
 	assert.Contains(t, err.Error(), "fetching expected content")
 }
 
@@ -284,17 +377,17 @@ func TestEnsure_EmptyRepoFiles(t *testing.T) {
 	mockClient := githubMocks.NewMockClient(t)
 
 	workflows := []models.PolicyWorkflow{
-		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: "http://example.com/wf.yml"},
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n")},
 	}
 
 	repo := models.Repository{Name: "empty-repo", FullName: "org/empty-repo"}
 	var repoFiles []string
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
 
 	assert.NoError(t, err)
-	assert.Empty(t, violations)
+	assert.Empty(t, evaluation.Deviations)
 }
 
 func TestEnsure_NoWorkflows(t *testing.T) {
@@ -307,8 +400,746 @@ func TestEnsure_NoWorkflows(t *testing.T) {
 	repoFiles := []string{"Dockerfile", "main.go"}
 
 	svc := NewPolicyService(workflows, mockClient)
-	violations, err := svc.Ensure(ctx, repo, repoFiles)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+}
+
+func TestEnsure_AbsentPolicy_FilePresent(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile", ".github/workflows/docker-publish.yml"}
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionDelete, evaluation.Deviations[0].Action)
+	assert.Equal(t, ".github/workflows/docker-publish.yml", evaluation.Deviations[0].TargetPath)
+	assert.Empty(t, evaluation.Deviations[0].ExpectedSource)
+}
+
+func TestEnsure_AbsentPolicy_YAMLExtension(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile", ".github/workflows/docker-publish.yaml"}
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, ".github/workflows/docker-publish.yaml", evaluation.Deviations[0].TargetPath)
+}
+
+func TestEnsure_AbsentPolicy_FileMissing(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile", ".github/workflows/dockerfile.yml"}
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Equal(t, []string{"docker-publish"}, evaluation.Applied)
+}
+
+func TestEnsure_AbsentPolicy_MatchFileNotMatched(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-publish", MatchFile: "**/Dockerfile*", Ensure: models.PolicyEnsureAbsent},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{".github/workflows/docker-publish.yml"}
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+}
+
+func TestEnsure_AbsentPolicy_OnlyIfManaged(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "managed file is removed", content: wrapContent("name: publish\n", "docker-publish"), want: 1},
+		{name: "hand-written file is kept", content: "name: publish\n", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := githubMocks.NewMockClient(t)
+
+			workflows := []models.PolicyWorkflow{
+				{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent, OnlyIfManaged: true},
+			}
+
+			repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+			repoFiles := []string{".github/workflows/docker-publish.yml"}
+
+			content := &gh.RepositoryContent{
+				Content:  gh.Ptr(base64.StdEncoding.EncodeToString([]byte(tt.content))),
+				Encoding: gh.Ptr("base64"),
+			}
+
+			mockClient.
+				EXPECT().
+				GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/docker-publish.yml").
+				Once().
+				Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+			svc := NewPolicyService(workflows, mockClient)
+			evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+			assert.NoError(t, err)
+			assert.Len(t, evaluation.Deviations, tt.want)
+		})
+	}
+}
+
+func TestEnsure_RenamedPolicy_MigratesMissingWorkflow(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-build", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n"), PreviousNames: []string{"dockerfile", "docker"}},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile", ".github/workflows/dockerfile.yml"}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/docker-build.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionMigrate, evaluation.Deviations[0].Action)
+	assert.Equal(t, ".github/workflows/docker-build.yml", evaluation.Deviations[0].TargetPath)
+	assert.Equal(t, []string{".github/workflows/dockerfile.yml"}, evaluation.Deviations[0].PreviousPaths)
+}
+
+func TestEnsure_RenamedPolicy_UpToDateWithLeftover(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	rawContent := "current workflow content"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(rawContent))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-build", MatchFile: "**/Dockerfile*", Source: server.URL, PreviousNames: []string{"dockerfile"}},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile", ".github/workflows/dockerfile.yml", ".github/workflows/docker-build.yml"}
+
+	content := &gh.RepositoryContent{
+		Content:  gh.Ptr(base64.StdEncoding.EncodeToString([]byte(wrapContent(rawContent, "docker-build")))),
+		Encoding: gh.Ptr("base64"),
+	}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/docker-build.yml").
+		Once().
+		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionMigrate, evaluation.Deviations[0].Action)
+	assert.Equal(t, []string{".github/workflows/dockerfile.yml"}, evaluation.Deviations[0].PreviousPaths)
+}
+
+func TestEnsure_RenamedPolicy_NoLeftover(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "docker-build", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n"), PreviousNames: []string{"dockerfile"}},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile"}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/docker-build.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionCreate, evaluation.Deviations[0].Action)
+	assert.Empty(t, evaluation.Deviations[0].PreviousPaths)
+}
+
+func TestEnsure_TemplatedPolicy_MissingWorkflow(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("image: [[ .ImageName ]]"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: server.URL, Template: true},
+	}
+
+	repo := models.Repository{Name: "My-Repo", FullName: "org/My-Repo"}
+	repoFiles := []string{"Dockerfile"}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "My-Repo", ".github/workflows/dockerfile.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionCreate, evaluation.Deviations[0].Action)
+	assert.Equal(t, "image: my-repo", evaluation.Deviations[0].ExpectedContent)
+}
+
+func TestEnsure_TemplatedPolicy_UpToDateWorkflow(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("image: [[ .ImageName ]]"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: server.URL, Template: true},
+	}
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	repoFiles := []string{"Dockerfile"}
+
+	encodedContent := base64.StdEncoding.EncodeToString([]byte(wrapContent("image: my-repo", "dockerfile")))
+	content := &gh.RepositoryContent{
+		Content:  gh.Ptr(encodedContent),
+		Encoding: gh.Ptr("base64"),
+	}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml").
+		Once().
+		Return(content, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+}
+
+func TestEnsure_TemplatedPolicy_RenderError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("go-version: [[ .GoVersion ]]"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", MatchFile: "**/*.go", Source: server.URL, Template: true},
+	}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/go.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient)
+	_, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"main.go"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rendering template for go")
+}
+
+func TestRender(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("image: [[ .ImageName ]]"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: server.URL, Template: true},
+		{Name: "go", MatchFile: "**/*.go", Source: server.URL},
+		{Name: "legacy", Ensure: models.PolicyEnsureAbsent},
+	}
+
+	svc := NewPolicyService(workflows, mockClient)
+	rendered, err := svc.Render(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile"})
+
+	assert.NoError(t, err)
+	assert.Len(t, rendered, 1)
+	assert.Equal(t, ".github/workflows/dockerfile.yml", rendered[0].TargetPath)
+	assert.Equal(t, wrapContent("image: my-repo", "dockerfile"), rendered[0].Content)
+}
+
+func TestEnsure_RepositoryConfig_OptOut(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: "http://example.com/wf.yml"},
+	}
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/policy-bot.yml", "HEAD").
+		Once().
+		Return("policies:\n  dockerfile:\n    opt_out: true\n    justification: built by buildpacks\n", "sha", nil)
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile", ".github/policy-bot.yml"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Empty(t, evaluation.Applied, "an opted-out policy does not apply")
+}
+
+func TestEnsure_RepositoryConfig_Invalid(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: "http://example.com/wf.yml"},
+	}
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/policy-bot.yml", "HEAD").
+		Once().
+		Return("policies:\n  dockerfile:\n    opt_out: true\n", "sha", nil)
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile", ".github/policy-bot.yml"})
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionInvalidConfig, evaluation.Deviations[0].Action)
+	assert.Contains(t, evaluation.Deviations[0].Reason, "opt_out requires a justification")
+}
+
+func TestEnsure_RepositoryConfig_Overrides(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("registry: [[ .Params.registry ]]"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: server.URL, Template: true},
+	}
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/policy-bot.yml", "HEAD").
+		Once().
+		Return("grouping: single\nreviewers: [alice]\npolicies:\n  dockerfile:\n    parameters:\n      registry: ghcr.io\n", "sha", nil)
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile", ".github/policy-bot.yml"})
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, "registry: ghcr.io", evaluation.Deviations[0].ExpectedContent)
+	assert.Equal(t, models.PRGroupingSingle, evaluation.Deviations[0].Grouping)
+	assert.Equal(t, []string{"alice"}, evaluation.Deviations[0].Policy.PullRequest.Reviewers)
+	assert.Contains(t, evaluation.Deviations[0].Overrides, "parameter `registry` = `ghcr.io`")
+}
+
+func TestEnsure_ExemptPolicy(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	// A broken source must not fail the repositories exempt from it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the source of an exempt policy is fetched")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: server.URL},
+		{Name: "go", MatchFile: "**/*.go", Source: server.URL},
+	}
+	exemptions := []models.Exemption{
+		{Repository: "my-repo", Policy: "dockerfile", Reason: "vendor image", Approver: "platform", Expires: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{Repository: "my-repo", Policy: "go", Reason: "not a go module", Approver: "platform", Expires: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	svc := NewPolicyService(workflows, mockClient, WithExemptions(exemptions)).(*policyService)
+	svc.now = func() time.Time { return time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC) }
+
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile"})
+
+	assert.NoError(t, err)
+	// The exemption of a policy which does not match the repository is not reported
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionExempt, evaluation.Deviations[0].Action)
+	assert.Equal(t, ".github/workflows/dockerfile.yml", evaluation.Deviations[0].TargetPath)
+	assert.Equal(t, "vendor image", evaluation.Deviations[0].Exemption.Reason)
+}
+
+func TestEnsure_ExpiredExemption(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n")},
+	}
+	exemptions := []models.Exemption{
+		{Repository: "my-repo", Policy: "dockerfile", Expires: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient, WithExemptions(exemptions)).(*policyService)
+	svc.now = func() time.Time { return time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC) }
+
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile"})
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionCreate, evaluation.Deviations[0].Action)
+	assert.Nil(t, evaluation.Deviations[0].Exemption)
+}
+
+func TestEnsure_OnlyPolicies(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", MatchFile: "**/Dockerfile*", Source: sourceServer(t, "on: push\n")},
+		{Name: "go", MatchFile: "go.mod", Source: sourceServer(t, "on: push\n")},
+	}
+	change := models.SourceChange{Repository: "org/actions", Base: "abc", Head: "def"}
+
+	// The configuration of the policy left out is still valid
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/policy-bot.yml", "HEAD").
+		Once().
+		Return("policies:\n  go:\n    opt_out: true\n    justification: not a service\n", "sha", nil)
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	svc := NewPolicyService(workflows, mockClient, OnlyPolicies("dockerfile"), WithSourceChange(change))
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile", "go.mod", ".github/policy-bot.yml"})
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, "dockerfile", evaluation.Deviations[0].Policy.Name)
+	assert.Equal(t, &change, evaluation.Deviations[0].SourceChange)
+	assert.Equal(t, []models.PolicyWorkflow{workflows[0]}, svc.Policies())
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRender_WithHTTPClient(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://sources.example/go.yml", req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("local workflow"))}, nil
+	})}
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", MatchFile: "go.mod", Source: "https://sources.example/go.yml"},
+	}
+
+	svc := NewPolicyService(workflows, mockClient, WithHTTPClient(client))
+	rendered, err := svc.Render(ctx, models.Repository{Name: "my-repo"}, []string{"go.mod"})
+
+	assert.NoError(t, err)
+	assert.Len(t, rendered, 1)
+	assert.Contains(t, rendered[0].Content, "local workflow")
+}
+
+func TestEnsure_Comparison(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n"))
+	}))
+	defer server.Close()
+
+	// The workflow was committed with CRLF line endings and re-indented
+	current := strings.ReplaceAll(wrapContent("on: push\njobs:\n    test:\n        runs-on: ubuntu-latest\n", "go"), "\n", "\r\n")
+
+	tests := []struct {
+		comparison models.Comparison
+		deviation  bool
+		reported   models.Comparison
+	}{
+		{comparison: "", deviation: true, reported: models.ComparisonExact},
+		{comparison: models.ComparisonNormalized, deviation: true, reported: models.ComparisonNormalized},
+		{comparison: models.ComparisonSemantic, deviation: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.reported), func(t *testing.T) {
+			mockClient := githubMocks.NewMockClient(t)
+			mockClient.
+				EXPECT().
+				GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/go.yml").
+				Once().
+				Return(&gh.RepositoryContent{Content: gh.Ptr(current)}, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+			workflows := []models.PolicyWorkflow{
+				{Name: "go", MatchFile: "go.mod", Source: server.URL, Comparison: tt.comparison},
+			}
+			svc := NewPolicyService(workflows, mockClient)
+			evaluation, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, []string{"go.mod"})
+
+			assert.NoError(t, err)
+			if !tt.deviation {
+				assert.Empty(t, evaluation.Deviations)
+				return
+			}
+			if assert.Len(t, evaluation.Deviations, 1) {
+				assert.Equal(t, models.PolicyActionUpdate, evaluation.Deviations[0].Action)
+				assert.Equal(t, tt.reported, evaluation.Deviations[0].Comparison)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	sources := map[string]string{
+		"/go.yml":       "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n",
+		"/docker.yml":   "on: push\njobs:\n  build:\n    needs: test\n    runs-on: ubuntu-latest\n    steps:\n      - run: docker build .\n",
+		"/template.yml": "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: go [[ .GoVersion }}\n",
+		"/rendered.yml": "image: [[ .ImageName ]]\n",
+		"/retired.yml":  "not a workflow",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := sources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", Source: server.URL + "/go.yml"},
+		{Name: "docker", Source: server.URL + "/docker.yml"},
+		{Name: "broken-template", Source: server.URL + "/template.yml", Template: true},
+		{Name: "template", Source: server.URL + "/rendered.yml", Template: true},
+		{Name: "retired", Source: server.URL + "/retired.yml", Ensure: models.PolicyEnsureAbsent},
+		{Name: "missing", Source: server.URL + "/missing.yml"},
+	}
+	svc := NewPolicyService(workflows, githubMocks.NewMockClient(t))
+
+	invalid := svc.Validate(context.Background())
+
+	assert.Len(t, invalid, 3, "templates are checked once rendered, retired policies are not fetched")
+	assert.EqualError(t, invalid["docker"], server.URL+"/docker.yml: invalid workflow: line 4: job build needs unknown job test")
+	assert.ErrorContains(t, invalid["broken-template"], server.URL+"/template.yml: ")
+	assert.EqualError(t, invalid["missing"], "fetching "+server.URL+"/missing.yml: unexpected status code: 404")
+}
+
+func TestEnsure_InvalidPolicy(t *testing.T) {
+	const valid = "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken.yml" {
+			w.Write([]byte("on: push\njobs:\n  lint:\n    runs-on: ubuntu-latest\n"))
+			return
+		}
+		w.Write([]byte(valid))
+	}))
+	defer server.Close()
+
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.
+		EXPECT().
+		GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/go.yml").
+		Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", MatchFile: "go.mod", Source: server.URL + "/go.yml"},
+		{Name: "lint", MatchFile: "go.mod", Source: server.URL + "/broken.yml"},
+		{Name: "docker", MatchFile: "Dockerfile", Source: server.URL + "/broken.yml"},
+	}
+	svc := NewPolicyService(workflows, mockClient)
+	assert.Len(t, svc.Validate(context.Background()), 2)
+
+	evaluation, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, []string{"go.mod"})
+
+	assert.NoError(t, err)
+	if assert.Len(t, evaluation.Deviations, 1) {
+		assert.Equal(t, "go", evaluation.Deviations[0].Policy.Name)
+	}
+	assert.Equal(t, []string{"go", "lint"}, evaluation.Applied)
+	// Only the invalid policies which apply to the repository are reported
+	assert.Len(t, evaluation.Errors, 1)
+	assert.ErrorContains(t, evaluation.Errors["lint"], `invalid workflow: line 3: job lint needs a list of "steps"`)
+}
+
+func TestValidate_OnlyPolicies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", Source: server.URL},
+		{Name: "unreachable", Source: "http://127.0.0.1:0/unreachable.yml"},
+	}
+	svc := NewPolicyService(workflows, githubMocks.NewMockClient(t), OnlyPolicies("go"))
+
+	assert.Empty(t, svc.Validate(context.Background()))
+}
+
+func TestEnsure_ActionPinning(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("steps:\n  - uses: actions/checkout@v4\n"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", MatchFile: "go.mod", Source: server.URL},
+	}
+	repo := models.Repository{Name: "my-repo", FullName: "tracker-tv/my-repo"}
+	repoFiles := []string{"go.mod", ".github/workflows/release.yml", ".github/workflows/old-go.yml", ".github/workflows/ci.yml", ".github/workflows/broken.yml", ".github/workflows/nested/x.yml", ".github/dependabot.yml"}
+
+	rawContent := func(content string) *gh.RepositoryContent {
+		return &gh.RepositoryContent{Content: gh.Ptr(base64.StdEncoding.EncodeToString([]byte(content))), Encoding: gh.Ptr("base64")}
+	}
+	ok := &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/go.yml").Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+	mockClient.EXPECT().ResolveRef(mock.Anything, "actions", "checkout", "v4").Once().Return(checkoutSHA, nil)
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/release.yml").Once().
+		Return(rawContent("steps:\n  - uses: actions/checkout@v4\n  - uses: softprops/action-gh-release@v2\n"), nil, ok, nil)
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/old-go.yml").Once().
+		Return(rawContent(wrapContent("steps:\n  - uses: actions/checkout@v4\n", "old-go")), nil, ok, nil)
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/ci.yml").Once().
+		Return(rawContent("steps:\n  - uses: actions/checkout@"+checkoutSHA+" # v4\n"), nil, ok, nil)
+	// A workflow which cannot be read is skipped, the others are still reported
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/broken.yml").Once().
+		Return(nil, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusInternalServerError}}, errors.New("server error"))
+
+	svc := NewPolicyService(workflows, mockClient, WithActionPinning())
+	evaluation, err := svc.Ensure(ctx, repo, repoFiles)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, evaluation.Deviations, 2) {
+		return
+	}
+	assert.Equal(t, models.PolicyActionCreate, evaluation.Deviations[0].Action)
+	assert.Equal(t, "steps:\n  - uses: actions/checkout@"+checkoutSHA+" # v4\n", evaluation.Deviations[0].ExpectedContent, "the pinned content is computed by Ensure")
+	assert.Equal(t, models.PolicyActionUnpinned, evaluation.Deviations[1].Action)
+	assert.Equal(t, ".github/workflows/release.yml", evaluation.Deviations[1].TargetPath)
+	assert.Equal(t, []string{"actions/checkout@v4", "softprops/action-gh-release@v2"}, evaluation.Deviations[1].UnpinnedActions)
+}
+
+func TestEnsure_ActionPinning_UpToDate(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("steps:\n  - uses: actions/checkout@v4\n"))
+	}))
+	defer server.Close()
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", MatchFile: "go.mod", Source: server.URL},
+	}
+	repo := models.Repository{Name: "my-repo", FullName: "tracker-tv/my-repo"}
+
+	pinned := wrapContent("steps:\n  - uses: actions/checkout@"+checkoutSHA+" # v4\n", "go")
+	mockClient.EXPECT().GetContentsRaw(mock.Anything, "my-repo", ".github/workflows/go.yml").Once().
+		Return(&gh.RepositoryContent{Content: gh.Ptr(base64.StdEncoding.EncodeToString([]byte(pinned))), Encoding: gh.Ptr("base64")}, nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+	mockClient.EXPECT().ResolveRef(mock.Anything, "actions", "checkout", "v4").Once().Return(checkoutSHA, nil)
+
+	svc := NewPolicyService(workflows, mockClient, WithActionPinning(), OnlyPolicies("go"))
+	evaluation, err := svc.Ensure(ctx, repo, []string{"go.mod", ".github/workflows/go.yml", ".github/workflows/release.yml"})
 
 	assert.NoError(t, err)
-	assert.Empty(t, violations)
+	assert.Empty(t, evaluation.Deviations, "the pinned workflow is up to date, and runs restricted to some policies report no unpinned actions")
 }
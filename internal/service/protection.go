@@ -0,0 +1,544 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/workflow"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// defaultBranchCondition is how a ruleset targets the default branch, whatever its name.
+const defaultBranchCondition = "~DEFAULT_BRANCH"
+
+// ProtectionService enforces the protection policies on the default branch
+// of repositories, with a classic branch protection or a ruleset.
+type ProtectionService interface {
+	// Ensure returns a deviation per protection policy the default branch does
+	// not follow, among the policies matching the repository files. workflows
+	// is the evaluation of the workflow policies for the repository, the
+	// policies with managed_checks require the checks of its workflows.
+	Ensure(ctx context.Context, repo models.Repository, repoFiles []string, workflows Evaluation) (Evaluation, error)
+	// Apply raises the protection of a deviation to its policy when the policy
+	// allows it, and only reports it otherwise.
+	Apply(ctx context.Context, drift models.PolicyDeviation) (*RemediationResult, error)
+}
+
+type protectionService struct {
+	policies   []models.PolicyProtection
+	exemptions []models.Exemption
+	gh         github.Client
+	now        func() time.Time
+}
+
+// ProtectionOption configures optional behaviour of the protection service.
+type ProtectionOption func(*protectionService)
+
+// WithProtectionExemptions waives the deviations covered by an active exemption.
+func WithProtectionExemptions(exemptions []models.Exemption) ProtectionOption {
+	return func(s *protectionService) {
+		s.exemptions = exemptions
+	}
+}
+
+func NewProtectionService(policies []models.PolicyProtection, gh github.Client, opts ...ProtectionOption) ProtectionService {
+	s := &protectionService{policies: policies, gh: gh, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *protectionService) Ensure(ctx context.Context, repo models.Repository, repoFiles []string, workflows Evaluation) (Evaluation, error) {
+	var evaluation Evaluation
+	var policies []models.PolicyProtection
+	for _, policy := range s.policies {
+		matched, err := matchesFiles(policy.MatchFile, repoFiles)
+		if err != nil {
+			return Evaluation{}, fmt.Errorf("matching %s: %w", policy.Name, err)
+		}
+		if matched {
+			policies = append(policies, policy)
+			evaluation.Applied = append(evaluation.Applied, policy.Name)
+		}
+	}
+	if len(policies) == 0 {
+		return evaluation, nil
+	}
+
+	repository, err := s.gh.GetRepository(ctx, repo.Name)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("getting repository: %w", err)
+	}
+	branch := repository.GetDefaultBranch()
+
+	var managed []string
+	var managedErr error
+	if slices.ContainsFunc(policies, func(p models.PolicyProtection) bool { return p.ManagedChecks }) {
+		managed, managedErr = managedChecks(workflows)
+	}
+
+	for _, policy := range policies {
+		// Without every managed check the policy would pass a branch missing some
+		if policy.ManagedChecks && managedErr != nil {
+			if evaluation.Errors == nil {
+				evaluation.Errors = make(map[string]error)
+			}
+			evaluation.Errors[policy.Name] = fmt.Errorf("reading managed checks: %w", managedErr)
+			continue
+		}
+
+		expected := expectedProtection(policy, branch, managed)
+		current, err := s.currentProtection(ctx, repo, policy, branch)
+		if err != nil {
+			return Evaluation{}, err
+		}
+
+		changes := diffProtection(expected, current)
+		if len(changes) == 0 {
+			continue
+		}
+
+		deviation := models.PolicyDeviation{
+			Repository: repo,
+			Policy:     models.PolicyWorkflow{Name: policy.Name, Severity: policy.Severity},
+			Action:     models.PolicyActionProtection,
+			Settings:   changes,
+			Protection: &expected,
+		}
+		if exemption := activeExemption(s.exemptions, repo, policy.Name, s.now()); exemption != nil {
+			deviation.Action = models.PolicyActionExempt
+			deviation.Exemption = exemption
+		}
+		logging.FromContext(ctx).Debug("protection deviation found", logging.Policy(policy.Name), logging.Action(string(deviation.Action)), "branch", branch)
+		evaluation.Deviations = append(evaluation.Deviations, deviation)
+	}
+	return evaluation, nil
+}
+
+// managedChecks returns the checks the workflows of the policies report on
+// the pull requests of the repository. They are unknown while its
+// configuration is invalid or a workflow policy could not be evaluated.
+func managedChecks(workflows Evaluation) ([]string, error) {
+	for _, d := range workflows.Deviations {
+		if d.Action == models.PolicyActionInvalidConfig {
+			return nil, fmt.Errorf("invalid %s: %s", d.TargetPath, d.Reason)
+		}
+	}
+	if len(workflows.Errors) > 0 {
+		name := slices.Sorted(maps.Keys(workflows.Errors))[0]
+		return nil, fmt.Errorf("policy %s: %w", name, workflows.Errors[name])
+	}
+
+	var checks []string
+	for _, w := range workflows.Workflows {
+		names, err := workflow.CheckNames(w.Content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", w.TargetPath, err)
+		}
+		checks = append(checks, names...)
+	}
+	return checks, nil
+}
+
+// currentProtection returns the protection of branch as policy describes it,
+// the zero protection when the branch is not protected.
+func (s *protectionService) currentProtection(ctx context.Context, repo models.Repository, policy models.PolicyProtection, branch string) (models.BranchProtection, error) {
+	if policy.Target == models.ProtectionTargetRuleset {
+		ruleset, err := s.gh.GetRulesetByName(ctx, repo.Name, policy.Name)
+		if err != nil {
+			return models.BranchProtection{}, fmt.Errorf("getting ruleset %s: %w", policy.Name, err)
+		}
+		return rulesetProtection(ruleset, branch, rulesetEnforcement(policy)), nil
+	}
+
+	protection, err := s.gh.GetBranchProtection(ctx, repo.Name, branch)
+	if err != nil {
+		return models.BranchProtection{}, fmt.Errorf("getting protection of %s: %w", branch, err)
+	}
+	return branchProtection(protection, branch), nil
+}
+
+func (s *protectionService) Apply(ctx context.Context, drift models.PolicyDeviation) (*RemediationResult, error) {
+	if drift.Action != models.PolicyActionProtection {
+		return &RemediationResult{Drift: drift, Action: "skipped"}, nil
+	}
+
+	i := slices.IndexFunc(s.policies, func(p models.PolicyProtection) bool { return p.Name == drift.Policy.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown protection policy %s", drift.Policy.Name)
+	}
+	policy := s.policies[i]
+	if !policy.Apply {
+		return &RemediationResult{Drift: drift, Action: "reported"}, nil
+	}
+
+	var err error
+	if policy.Target == models.ProtectionTargetRuleset {
+		err = s.applyRuleset(ctx, drift.Repository, policy, *drift.Protection)
+	} else {
+		err = s.applyBranchProtection(ctx, drift.Repository, *drift.Protection)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RemediationResult{Drift: drift, Action: "updated"}, nil
+}
+
+// applyBranchProtection raises the classic protection of the branch to
+// expected, keeping the settings policies do not describe.
+func (s *protectionService) applyBranchProtection(ctx context.Context, repo models.Repository, expected models.BranchProtection) error {
+	// The protection is read again, it may have changed since Ensure
+	current, err := s.gh.GetBranchProtection(ctx, repo.Name, expected.Branch)
+	if err != nil {
+		return fmt.Errorf("getting protection of %s: %w", expected.Branch, err)
+	}
+
+	req := protectionRequest(current)
+	if expected.RequiredApprovals > 0 {
+		if req.RequiredPullRequestReviews == nil {
+			req.RequiredPullRequestReviews = &gh.PullRequestReviewsEnforcementRequest{}
+		}
+		req.RequiredPullRequestReviews.RequiredApprovingReviewCount = max(req.RequiredPullRequestReviews.RequiredApprovingReviewCount, expected.RequiredApprovals)
+	}
+	if len(expected.RequiredChecks) > 0 {
+		if req.RequiredStatusChecks == nil {
+			req.RequiredStatusChecks = &gh.RequiredStatusChecks{}
+		}
+		req.RequiredStatusChecks.Checks = withChecks(req.RequiredStatusChecks, expected.RequiredChecks)
+		req.RequiredStatusChecks.Contexts = nil
+	}
+	if expected.LinearHistory {
+		req.RequireLinearHistory = gh.Ptr(true)
+	}
+	if expected.EnforceAdmins {
+		req.EnforceAdmins = true
+	}
+
+	if err := s.gh.UpdateBranchProtection(ctx, repo.Name, expected.Branch, req); err != nil {
+		return fmt.Errorf("updating protection of %s: %w", expected.Branch, err)
+	}
+	return nil
+}
+
+// applyRuleset raises the ruleset of policy to expected, creating it when the
+// repository has none, and keeps the rules policies do not describe. The
+// enforcement is only raised, a stricter one is kept.
+func (s *protectionService) applyRuleset(ctx context.Context, repo models.Repository, policy models.PolicyProtection, expected models.BranchProtection) error {
+	name := policy.Name
+	current, err := s.gh.GetRulesetByName(ctx, repo.Name, name)
+	if err != nil {
+		return fmt.Errorf("getting ruleset %s: %w", name, err)
+	}
+
+	ruleset := gh.RepositoryRuleset{
+		Name:        name,
+		Target:      gh.Ptr(gh.RulesetTargetBranch),
+		Enforcement: rulesetEnforcement(policy),
+		Conditions: &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{
+			Include: []string{defaultBranchCondition},
+			Exclude: []string{},
+		}},
+		Rules: &gh.RepositoryRulesetRules{},
+	}
+	if current != nil {
+		ruleset.ID = current.ID
+		ruleset.BypassActors = current.BypassActors
+		if enforces(current.Enforcement, ruleset.Enforcement) {
+			ruleset.Enforcement = current.Enforcement
+		}
+		if current.Rules != nil {
+			ruleset.Rules = current.Rules
+		}
+		if refs := current.GetConditions().GetRefName(); refs != nil {
+			ruleset.Conditions.RefName = refs
+			if !targetsBranch(refs, expected.Branch) {
+				refs.Include = append(refs.Include, defaultBranchCondition)
+			}
+		}
+	}
+
+	rules := ruleset.Rules
+	if expected.RequiredApprovals > 0 {
+		if rules.PullRequest == nil {
+			rules.PullRequest = &gh.PullRequestRuleParameters{AllowedMergeMethods: []gh.PullRequestMergeMethod{
+				gh.PullRequestMergeMethodMerge, gh.PullRequestMergeMethodSquash, gh.PullRequestMergeMethodRebase,
+			}}
+		}
+		rules.PullRequest.RequiredApprovingReviewCount = max(rules.PullRequest.RequiredApprovingReviewCount, expected.RequiredApprovals)
+	}
+	if len(expected.RequiredChecks) > 0 {
+		if rules.RequiredStatusChecks == nil {
+			rules.RequiredStatusChecks = &gh.RequiredStatusChecksRuleParameters{}
+		}
+		for _, check := range expected.RequiredChecks {
+			if !slices.ContainsFunc(rules.RequiredStatusChecks.RequiredStatusChecks, func(c *gh.RuleStatusCheck) bool { return c.Context == check }) {
+				rules.RequiredStatusChecks.RequiredStatusChecks = append(rules.RequiredStatusChecks.RequiredStatusChecks, &gh.RuleStatusCheck{Context: check})
+			}
+		}
+	}
+	if expected.LinearHistory {
+		rules.RequiredLinearHistory = &gh.EmptyRuleParameters{}
+	}
+	if expected.EnforceAdmins {
+		ruleset.BypassActors = nil
+	}
+
+	if current == nil {
+		err = s.gh.CreateRuleset(ctx, repo.Name, ruleset)
+	} else {
+		err = s.gh.UpdateRuleset(ctx, repo.Name, ruleset)
+	}
+	if err != nil {
+		return fmt.Errorf("updating ruleset %s: %w", name, err)
+	}
+	return nil
+}
+
+// expectedProtection is the protection policy requires on branch, with the
+// checks of the managed workflows when the policy asks for them.
+func expectedProtection(policy models.PolicyProtection, branch string, managed []string) models.BranchProtection {
+	checks := slices.Clone(policy.RequiredChecks)
+	if policy.ManagedChecks {
+		checks = append(checks, managed...)
+	}
+	slices.Sort(checks)
+
+	return models.BranchProtection{
+		Branch:            branch,
+		RequiredApprovals: policy.RequiredApprovals,
+		RequiredChecks:    slices.Compact(checks),
+		LinearHistory:     policy.LinearHistory,
+		EnforceAdmins:     policy.EnforceAdmins,
+	}
+}
+
+// branchProtection reads a classic branch protection, p is nil when the branch is not protected.
+func branchProtection(p *gh.Protection, branch string) models.BranchProtection {
+	current := models.BranchProtection{Branch: branch}
+	if p == nil {
+		return current
+	}
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		current.RequiredApprovals = reviews.RequiredApprovingReviewCount
+	}
+	if checks := p.RequiredStatusChecks; checks != nil {
+		current.RequiredChecks = checkNames(checks)
+	}
+	current.LinearHistory = p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled
+	current.EnforceAdmins = p.EnforceAdmins != nil && p.EnforceAdmins.Enabled
+	return current
+}
+
+// rulesetEnforcement is the enforcement policy requires of its ruleset.
+func rulesetEnforcement(policy models.PolicyProtection) gh.RulesetEnforcement {
+	if policy.Enforcement == "" {
+		return gh.RulesetEnforcementActive
+	}
+	return gh.RulesetEnforcement(policy.Enforcement)
+}
+
+// enforces reports whether a ruleset enforced as current is at least as strict as expected.
+func enforces(current, expected gh.RulesetEnforcement) bool {
+	rank := map[gh.RulesetEnforcement]int{gh.RulesetEnforcementEvaluate: 1, gh.RulesetEnforcementActive: 2}
+	return rank[current] >= rank[expected]
+}
+
+// rulesetProtection reads a ruleset, which only protects branch while it is
+// enforced as the policy requires and targets it. Administrators are bound by a
+// ruleset nobody can bypass.
+func rulesetProtection(r *gh.RepositoryRuleset, branch string, enforcement gh.RulesetEnforcement) models.BranchProtection {
+	current := models.BranchProtection{Branch: branch}
+	if r == nil || !enforces(r.Enforcement, enforcement) || !targetsBranch(r.GetConditions().GetRefName(), branch) {
+		return current
+	}
+	if rules := r.Rules; rules != nil {
+		if rules.PullRequest != nil {
+			current.RequiredApprovals = rules.PullRequest.RequiredApprovingReviewCount
+		}
+		if rules.RequiredStatusChecks != nil {
+			for _, check := range rules.RequiredStatusChecks.RequiredStatusChecks {
+				current.RequiredChecks = append(current.RequiredChecks, check.Context)
+			}
+		}
+		current.LinearHistory = rules.RequiredLinearHistory != nil
+	}
+	current.EnforceAdmins = len(r.BypassActors) == 0
+	return current
+}
+
+// targetsBranch reports whether the ref conditions of a ruleset include branch.
+func targetsBranch(refs *gh.RepositoryRulesetRefConditionParameters, branch string) bool {
+	if refs == nil {
+		return false
+	}
+	for _, include := range refs.Include {
+		if include == defaultBranchCondition || include == "~ALL" || include == "refs/heads/"+branch {
+			return true
+		}
+	}
+	return false
+}
+
+// diffProtection returns what current lacks to be as strict as expected.
+func diffProtection(expected, current models.BranchProtection) []models.SettingChange {
+	var changes []models.SettingChange
+	if current.RequiredApprovals < expected.RequiredApprovals {
+		changes = append(changes, models.SettingChange{
+			Setting:  "required_approvals",
+			Current:  strconv.Itoa(current.RequiredApprovals),
+			Expected: strconv.Itoa(expected.RequiredApprovals),
+		})
+	}
+
+	var missing []string
+	for _, check := range expected.RequiredChecks {
+		if !slices.Contains(current.RequiredChecks, check) {
+			missing = append(missing, check)
+		}
+	}
+	if len(missing) > 0 {
+		changes = append(changes, models.SettingChange{
+			Setting:  "required_checks",
+			Current:  checkList(current.RequiredChecks),
+			Expected: checkList(append(slices.Clone(current.RequiredChecks), missing...)),
+		})
+	}
+
+	if expected.LinearHistory && !current.LinearHistory {
+		changes = append(changes, models.SettingChange{Setting: "linear_history", Current: "false", Expected: "true"})
+	}
+	if expected.EnforceAdmins && !current.EnforceAdmins {
+		changes = append(changes, models.SettingChange{Setting: "enforce_admins", Current: "false", Expected: "true"})
+	}
+	return changes
+}
+
+func checkList(checks []string) string {
+	if len(checks) == 0 {
+		return "none"
+	}
+	return "[" + strings.Join(checks, ", ") + "]"
+}
+
+// checkNames returns the required checks of a classic protection, listed in
+// checks or in the deprecated contexts.
+func checkNames(checks *gh.RequiredStatusChecks) []string {
+	var names []string
+	if checks.Checks != nil {
+		for _, check := range *checks.Checks {
+			names = append(names, check.Context)
+		}
+	} else if checks.Contexts != nil {
+		names = append(names, *checks.Contexts...)
+	}
+	return names
+}
+
+// withChecks returns the checks of current with the missing ones of expected,
+// keeping the app each existing check is bound to.
+func withChecks(current *gh.RequiredStatusChecks, expected []string) *[]*gh.RequiredStatusCheck {
+	var checks []*gh.RequiredStatusCheck
+	if current.Checks != nil {
+		checks = append(checks, *current.Checks...)
+	} else if current.Contexts != nil {
+		for _, name := range *current.Contexts {
+			checks = append(checks, &gh.RequiredStatusCheck{Context: name})
+		}
+	}
+	for _, check := range expected {
+		if !slices.ContainsFunc(checks, func(c *gh.RequiredStatusCheck) bool { return c.Context == check }) {
+			checks = append(checks, &gh.RequiredStatusCheck{Context: check})
+		}
+	}
+	return &checks
+}
+
+// protectionRequest returns the request keeping a classic protection as is,
+// the update endpoint replacing every setting.
+func protectionRequest(p *gh.Protection) *gh.ProtectionRequest {
+	req := &gh.ProtectionRequest{}
+	if p == nil {
+		return req
+	}
+
+	if checks := p.RequiredStatusChecks; checks != nil {
+		req.RequiredStatusChecks = &gh.RequiredStatusChecks{Strict: checks.Strict, Checks: checks.Checks, Contexts: checks.Contexts}
+	}
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		req.RequiredPullRequestReviews = &gh.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      gh.Ptr(reviews.RequireLastPushApproval),
+		}
+		if d := reviews.DismissalRestrictions; d != nil {
+			req.RequiredPullRequestReviews.DismissalRestrictionsRequest = &gh.DismissalRestrictionsRequest{
+				Users: gh.Ptr(userLogins(d.Users)), Teams: gh.Ptr(teamSlugs(d.Teams)), Apps: gh.Ptr(appSlugs(d.Apps)),
+			}
+		}
+		if b := reviews.BypassPullRequestAllowances; b != nil {
+			req.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &gh.BypassPullRequestAllowancesRequest{
+				Users: userLogins(b.Users), Teams: teamSlugs(b.Teams), Apps: appSlugs(b.Apps),
+			}
+		}
+	}
+	if r := p.Restrictions; r != nil {
+		req.Restrictions = &gh.BranchRestrictionsRequest{Users: userLogins(r.Users), Teams: teamSlugs(r.Teams), Apps: appSlugs(r.Apps)}
+	}
+	req.EnforceAdmins = p.EnforceAdmins != nil && p.EnforceAdmins.Enabled
+	if p.RequireLinearHistory != nil {
+		req.RequireLinearHistory = gh.Ptr(p.RequireLinearHistory.Enabled)
+	}
+	if p.AllowForcePushes != nil {
+		req.AllowForcePushes = gh.Ptr(p.AllowForcePushes.Enabled)
+	}
+	if p.AllowDeletions != nil {
+		req.AllowDeletions = gh.Ptr(p.AllowDeletions.Enabled)
+	}
+	if p.RequiredConversationResolution != nil {
+		req.RequiredConversationResolution = gh.Ptr(p.RequiredConversationResolution.Enabled)
+	}
+	if p.BlockCreations != nil {
+		req.BlockCreations = gh.Ptr(p.BlockCreations.GetEnabled())
+	}
+	if p.LockBranch != nil {
+		req.LockBranch = gh.Ptr(p.LockBranch.GetEnabled())
+	}
+	if p.AllowForkSyncing != nil {
+		req.AllowForkSyncing = gh.Ptr(p.AllowForkSyncing.GetEnabled())
+	}
+	return req
+}
+
+func userLogins(users []*gh.User) []string {
+	logins := make([]string, 0, len(users))
+	for _, u := range users {
+		logins = append(logins, u.GetLogin())
+	}
+	return logins
+}
+
+func teamSlugs(teams []*gh.Team) []string {
+	slugs := make([]string, 0, len(teams))
+	for _, t := range teams {
+		slugs = append(slugs, t.GetSlug())
+	}
+	return slugs
+}
+
+func appSlugs(apps []*gh.App) []string {
+	slugs := make([]string, 0, len(apps))
+	for _, a := range apps {
+		slugs = append(slugs, a.GetSlug())
+	}
+	return slugs
+}
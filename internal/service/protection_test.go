@@ -0,0 +1,450 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func defaultBranchPolicy(target models.ProtectionTarget, apply bool) models.PolicyProtection {
+	return models.PolicyProtection{
+		Name:              "default-branch",
+		Target:            target,
+		RequiredApprovals: 1,
+		RequiredChecks:    []string{"lint"},
+		LinearHistory:     true,
+		EnforceAdmins:     true,
+		Apply:             apply,
+	}
+}
+
+func TestProtectionEnsure_BranchProtection(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	policy := defaultBranchPolicy("", true)
+	policy.ManagedChecks = true
+
+	// Only the workflows running on pull requests report checks on them
+	workflows := Evaluation{
+		Applied: []string{"go", "release"},
+		Workflows: []RenderedWorkflow{
+			{TargetPath: ".github/workflows/go.yml", Content: "on: pull_request\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: go test ./...\n"},
+			{TargetPath: ".github/workflows/release.yml", Content: "on: push\njobs:\n  release:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make release\n"},
+		},
+	}
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(&gh.Protection{
+		RequiredPullRequestReviews: &gh.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+		RequiredStatusChecks:       &gh.RequiredStatusChecks{Checks: &[]*gh.RequiredStatusCheck{{Context: "lint"}}},
+		EnforceAdmins:              &gh.AdminEnforcement{Enabled: false},
+	}, nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{policy}, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, []string{"go.mod"}, workflows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PolicyDeviation{{
+		Repository: repo,
+		Policy:     models.PolicyWorkflow{Name: "default-branch"},
+		Action:     models.PolicyActionProtection,
+		// Two approvals are stricter than the policy
+		Settings: []models.SettingChange{
+			{Setting: "required_checks", Current: "[lint]", Expected: "[lint, test]"},
+			{Setting: "linear_history", Current: "false", Expected: "true"},
+			{Setting: "enforce_admins", Current: "false", Expected: "true"},
+		},
+		Protection: &models.BranchProtection{
+			Branch:            "main",
+			RequiredApprovals: 1,
+			RequiredChecks:    []string{"lint", "test"},
+			LinearHistory:     true,
+			EnforceAdmins:     true,
+		},
+	}}, evaluation.Deviations)
+	assert.Equal(t, []string{"default-branch"}, evaluation.Applied)
+}
+
+func TestProtectionEnsure_NotProtected(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(nil, nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", true)}, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil, Evaluation{})
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, []models.SettingChange{
+		{Setting: "required_approvals", Current: "0", Expected: "1"},
+		{Setting: "required_checks", Current: "none", Expected: "[lint]"},
+		{Setting: "linear_history", Current: "false", Expected: "true"},
+		{Setting: "enforce_admins", Current: "false", Expected: "true"},
+	}, evaluation.Deviations[0].Settings)
+}
+
+func TestProtectionEnsure_Ruleset(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetRulesetByName(mock.Anything, "my-repo", "default-branch").Once().Return(&gh.RepositoryRuleset{
+		ID:          gh.Ptr(int64(7)),
+		Name:        "default-branch",
+		Enforcement: gh.RulesetEnforcementActive,
+		Conditions:  &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}}},
+		Rules: &gh.RepositoryRulesetRules{
+			PullRequest:           &gh.PullRequestRuleParameters{RequiredApprovingReviewCount: 1},
+			RequiredStatusChecks:  &gh.RequiredStatusChecksRuleParameters{RequiredStatusChecks: []*gh.RuleStatusCheck{{Context: "lint"}}},
+			RequiredLinearHistory: &gh.EmptyRuleParameters{},
+		},
+	}, nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy(models.ProtectionTargetRuleset, true)}, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil, Evaluation{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+}
+
+func TestProtectionEnsure_RulesetNotEnforced(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetRulesetByName(mock.Anything, "my-repo", "default-branch").Once().Return(&gh.RepositoryRuleset{
+		Name:        "default-branch",
+		Enforcement: gh.RulesetEnforcementEvaluate,
+		Conditions:  &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}}},
+		Rules:       &gh.RepositoryRulesetRules{RequiredLinearHistory: &gh.EmptyRuleParameters{}},
+	}, nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy(models.ProtectionTargetRuleset, true)}, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil, Evaluation{})
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Len(t, evaluation.Deviations[0].Settings, 4)
+}
+
+func TestProtectionEnsure_RulesetEvaluated(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	policy := defaultBranchPolicy(models.ProtectionTargetRuleset, true)
+	policy.Enforcement = "evaluate"
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetRulesetByName(mock.Anything, "my-repo", "default-branch").Once().Return(&gh.RepositoryRuleset{
+		Name:        "default-branch",
+		Enforcement: gh.RulesetEnforcementEvaluate,
+		Conditions:  &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}}},
+		Rules: &gh.RepositoryRulesetRules{
+			PullRequest:           &gh.PullRequestRuleParameters{RequiredApprovingReviewCount: 1},
+			RequiredStatusChecks:  &gh.RequiredStatusChecksRuleParameters{RequiredStatusChecks: []*gh.RuleStatusCheck{{Context: "lint"}}},
+			RequiredLinearHistory: &gh.EmptyRuleParameters{},
+		},
+	}, nil)
+
+	// A policy in evaluate mode is followed by a ruleset in evaluate mode
+	svc := NewProtectionService([]models.PolicyProtection{policy}, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil, Evaluation{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+}
+
+func TestProtectionEnsure_ManagedChecksUnavailable(t *testing.T) {
+	tests := []struct {
+		name      string
+		workflows Evaluation
+		expected  string
+	}{
+		{
+			name:      "invalid policy",
+			workflows: Evaluation{Applied: []string{"go"}, Errors: map[string]error{"go": errors.New("invalid policy: 404")}},
+			expected:  "reading managed checks: policy go: invalid policy: 404",
+		},
+		{
+			name: "invalid configuration",
+			workflows: Evaluation{Deviations: []models.PolicyDeviation{{
+				Action: models.PolicyActionInvalidConfig, TargetPath: ".github/policy-bot.yml", Reason: "unknown policy",
+			}}},
+			expected: "reading managed checks: invalid .github/policy-bot.yml: unknown policy",
+		},
+		{
+			name:      "invalid workflow",
+			workflows: Evaluation{Workflows: []RenderedWorkflow{{TargetPath: ".github/workflows/go.yml", Content: "- not a workflow\n"}}},
+			expected:  "reading managed checks: .github/workflows/go.yml: the workflow is not a mapping",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := githubMocks.NewMockClient(t)
+			managed := defaultBranchPolicy("", true)
+			managed.ManagedChecks = true
+			listed := defaultBranchPolicy("", true)
+			listed.Name = "listed-checks"
+
+			mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+			mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(nil, nil)
+
+			// The policy without managed checks is still evaluated
+			svc := NewProtectionService([]models.PolicyProtection{managed, listed}, mockClient)
+			evaluation, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, nil, tt.workflows)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"default-branch", "listed-checks"}, evaluation.Applied)
+			if assert.Len(t, evaluation.Deviations, 1) {
+				assert.Equal(t, "listed-checks", evaluation.Deviations[0].Policy.Name)
+			}
+			assert.EqualError(t, evaluation.Errors["default-branch"], tt.expected)
+		})
+	}
+}
+
+func TestProtectionEnsure_Exempt(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	exemptions := []models.Exemption{
+		{Repository: "my-repo", Policy: "default-branch", Reason: "single maintainer", Approver: "platform", Expires: time.Now().AddDate(0, 1, 0)},
+	}
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(nil, nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", true)}, mockClient, WithProtectionExemptions(exemptions))
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil, Evaluation{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PolicyActionExempt, evaluation.Deviations[0].Action)
+	assert.Equal(t, &exemptions[0], evaluation.Deviations[0].Exemption)
+}
+
+func TestProtectionEnsure_NoMatchingPolicy(t *testing.T) {
+	policy := defaultBranchPolicy("", true)
+	policy.MatchFile = "**/Dockerfile*"
+
+	// The protection is not fetched when no policy applies
+	svc := NewProtectionService([]models.PolicyProtection{policy}, githubMocks.NewMockClient(t))
+	evaluation, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, []string{"go.mod"}, Evaluation{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Empty(t, evaluation.Applied)
+}
+
+func TestProtectionEnsure_Error(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{DefaultBranch: gh.Ptr("main")}, nil)
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(nil, errors.New("403 Forbidden"))
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", true)}, mockClient)
+	_, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, nil, Evaluation{})
+
+	assert.EqualError(t, err, "getting protection of main: 403 Forbidden")
+}
+
+func protectionDeviation() models.PolicyDeviation {
+	return models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:     models.PolicyWorkflow{Name: "default-branch"},
+		Action:     models.PolicyActionProtection,
+		Settings:   []models.SettingChange{{Setting: "required_checks", Current: "[build]", Expected: "[build, lint]"}},
+		Protection: &models.BranchProtection{
+			Branch:            "main",
+			RequiredApprovals: 1,
+			RequiredChecks:    []string{"lint"},
+			LinearHistory:     true,
+			EnforceAdmins:     true,
+		},
+	}
+}
+
+func TestProtectionApply_BranchProtection(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(&gh.Protection{
+		RequiredStatusChecks: &gh.RequiredStatusChecks{Strict: true, Checks: &[]*gh.RequiredStatusCheck{{Context: "build", AppID: gh.Ptr(int64(15368))}}},
+		RequiredPullRequestReviews: &gh.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 2,
+			DismissStaleReviews:          true,
+		},
+		Restrictions:     &gh.BranchRestrictions{Teams: []*gh.Team{{Slug: gh.Ptr("platform")}}},
+		AllowForcePushes: &gh.AllowForcePushes{Enabled: false},
+	}, nil)
+	mockClient.EXPECT().UpdateBranchProtection(mock.Anything, "my-repo", "main", &gh.ProtectionRequest{
+		RequiredStatusChecks: &gh.RequiredStatusChecks{Strict: true, Checks: &[]*gh.RequiredStatusCheck{
+			{Context: "build", AppID: gh.Ptr(int64(15368))},
+			{Context: "lint"},
+		}},
+		// The stricter review count and the settings policies do not describe are kept
+		RequiredPullRequestReviews: &gh.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: 2,
+			DismissStaleReviews:          true,
+			RequireLastPushApproval:      gh.Ptr(false),
+		},
+		Restrictions:         &gh.BranchRestrictionsRequest{Users: []string{}, Teams: []string{"platform"}, Apps: []string{}},
+		EnforceAdmins:        true,
+		RequireLinearHistory: gh.Ptr(true),
+		AllowForcePushes:     gh.Ptr(false),
+	}).Once().Return(nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", true)}, mockClient)
+	result, err := svc.Apply(ctx, protectionDeviation())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", result.Action)
+}
+
+func TestProtectionApply_LockedBranch(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(&gh.Protection{
+		Restrictions:     &gh.BranchRestrictions{},
+		BlockCreations:   &gh.BlockCreations{Enabled: gh.Ptr(true)},
+		LockBranch:       &gh.LockBranch{Enabled: gh.Ptr(true)},
+		AllowForkSyncing: &gh.AllowForkSyncing{Enabled: gh.Ptr(true)},
+	}, nil)
+	// A locked branch stays locked
+	mockClient.EXPECT().UpdateBranchProtection(mock.Anything, "my-repo", "main", &gh.ProtectionRequest{
+		RequiredStatusChecks: &gh.RequiredStatusChecks{Checks: &[]*gh.RequiredStatusCheck{{Context: "lint"}}},
+		RequiredPullRequestReviews: &gh.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: 1,
+		},
+		Restrictions:         &gh.BranchRestrictionsRequest{Users: []string{}, Teams: []string{}, Apps: []string{}},
+		EnforceAdmins:        true,
+		RequireLinearHistory: gh.Ptr(true),
+		BlockCreations:       gh.Ptr(true),
+		LockBranch:           gh.Ptr(true),
+		AllowForkSyncing:     gh.Ptr(true),
+	}).Once().Return(nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", true)}, mockClient)
+	_, err := svc.Apply(ctx, protectionDeviation())
+
+	assert.NoError(t, err)
+}
+
+func TestProtectionApply_CreatesRuleset(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRulesetByName(mock.Anything, "my-repo", "default-branch").Once().Return(nil, nil)
+	mockClient.EXPECT().CreateRuleset(mock.Anything, "my-repo", gh.RepositoryRuleset{
+		Name:        "default-branch",
+		Target:      gh.Ptr(gh.RulesetTargetBranch),
+		Enforcement: gh.RulesetEnforcementActive,
+		Conditions: &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{
+			Include: []string{"~DEFAULT_BRANCH"},
+			Exclude: []string{},
+		}},
+		Rules: &gh.RepositoryRulesetRules{
+			PullRequest: &gh.PullRequestRuleParameters{
+				AllowedMergeMethods:          []gh.PullRequestMergeMethod{gh.PullRequestMergeMethodMerge, gh.PullRequestMergeMethodSquash, gh.PullRequestMergeMethodRebase},
+				RequiredApprovingReviewCount: 1,
+			},
+			RequiredStatusChecks:  &gh.RequiredStatusChecksRuleParameters{RequiredStatusChecks: []*gh.RuleStatusCheck{{Context: "lint"}}},
+			RequiredLinearHistory: &gh.EmptyRuleParameters{},
+		},
+	}).Once().Return(nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy(models.ProtectionTargetRuleset, true)}, mockClient)
+	result, err := svc.Apply(ctx, protectionDeviation())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", result.Action)
+}
+
+func TestProtectionApply_UpdatesRuleset(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRulesetByName(mock.Anything, "my-repo", "default-branch").Once().Return(&gh.RepositoryRuleset{
+		ID:           gh.Ptr(int64(7)),
+		Name:         "default-branch",
+		Enforcement:  gh.RulesetEnforcementDisabled,
+		BypassActors: []*gh.BypassActor{{ActorID: gh.Ptr(int64(5)), ActorType: gh.Ptr(gh.BypassActorTypeRepositoryRole)}},
+		Conditions:   &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{Include: []string{"refs/heads/release"}, Exclude: []string{}}},
+		Rules: &gh.RepositoryRulesetRules{
+			Deletion:    &gh.EmptyRuleParameters{},
+			PullRequest: &gh.PullRequestRuleParameters{RequiredApprovingReviewCount: 2, RequireCodeOwnerReview: true},
+		},
+	}, nil)
+	mockClient.EXPECT().UpdateRuleset(mock.Anything, "my-repo", gh.RepositoryRuleset{
+		ID:          gh.Ptr(int64(7)),
+		Name:        "default-branch",
+		Target:      gh.Ptr(gh.RulesetTargetBranch),
+		Enforcement: gh.RulesetEnforcementActive,
+		Conditions: &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{
+			Include: []string{"refs/heads/release", "~DEFAULT_BRANCH"},
+			Exclude: []string{},
+		}},
+		Rules: &gh.RepositoryRulesetRules{
+			Deletion:              &gh.EmptyRuleParameters{},
+			PullRequest:           &gh.PullRequestRuleParameters{RequiredApprovingReviewCount: 2, RequireCodeOwnerReview: true},
+			RequiredStatusChecks:  &gh.RequiredStatusChecksRuleParameters{RequiredStatusChecks: []*gh.RuleStatusCheck{{Context: "lint"}}},
+			RequiredLinearHistory: &gh.EmptyRuleParameters{},
+		},
+	}).Once().Return(nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy(models.ProtectionTargetRuleset, true)}, mockClient)
+	_, err := svc.Apply(ctx, protectionDeviation())
+
+	assert.NoError(t, err)
+}
+
+func TestProtectionApply_KeepsStricterEnforcement(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	policy := defaultBranchPolicy(models.ProtectionTargetRuleset, true)
+	policy.Enforcement = "evaluate"
+
+	current := &gh.RepositoryRuleset{
+		ID:          gh.Ptr(int64(7)),
+		Name:        "default-branch",
+		Enforcement: gh.RulesetEnforcementActive,
+		Conditions:  &gh.RepositoryRulesetConditions{RefName: &gh.RepositoryRulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}}},
+		Rules:       &gh.RepositoryRulesetRules{RequiredLinearHistory: &gh.EmptyRuleParameters{}},
+	}
+	mockClient.EXPECT().GetRulesetByName(mock.Anything, "my-repo", "default-branch").Once().Return(current, nil)
+	mockClient.EXPECT().UpdateRuleset(mock.Anything, "my-repo", mock.MatchedBy(func(r gh.RepositoryRuleset) bool {
+		return r.Enforcement == gh.RulesetEnforcementActive
+	})).Once().Return(nil)
+
+	svc := NewProtectionService([]models.PolicyProtection{policy}, mockClient)
+	_, err := svc.Apply(ctx, protectionDeviation())
+
+	assert.NoError(t, err)
+}
+
+func TestProtectionApply_ReportOnly(t *testing.T) {
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", false)}, githubMocks.NewMockClient(t))
+	result, err := svc.Apply(context.Background(), protectionDeviation())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "reported", result.Action)
+}
+
+func TestProtectionApply_Error(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.EXPECT().GetBranchProtection(mock.Anything, "my-repo", "main").Once().Return(nil, nil)
+	mockClient.EXPECT().UpdateBranchProtection(mock.Anything, "my-repo", "main", mock.Anything).Once().Return(errors.New("422 Validation Failed"))
+
+	svc := NewProtectionService([]models.PolicyProtection{defaultBranchPolicy("", true)}, mockClient)
+	_, err := svc.Apply(context.Background(), protectionDeviation())
+
+	assert.EqualError(t, err, "updating protection of main: 422 Validation Failed")
+}
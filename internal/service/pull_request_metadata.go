@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+const labelColor = "ededed"
+
+func (s *remediationService) applyPullRequestMetadata(ctx context.Context, drift models.PolicyDeviation, number int, opts models.PullRequestOptions) error {
+	repo := drift.Repository.Name
+
+	if len(opts.Labels) > 0 {
+		for _, label := range opts.Labels {
+			if err := s.ensureLabel(ctx, repo, label); err != nil {
+				return fmt.Errorf("ensuring label %s: %w", label, err)
+			}
+		}
+		if err := s.gh.AddLabelsToIssue(ctx, repo, number, opts.Labels); err != nil {
+			return fmt.Errorf("adding labels: %w", err)
+		}
+	}
+
+	if len(opts.Assignees) > 0 {
+		if err := s.gh.AddAssignees(ctx, repo, number, opts.Assignees); err != nil {
+			return fmt.Errorf("adding assignees: %w", err)
+		}
+	}
+
+	// Teams may be configured as org/team, the reviewers API only takes slugs
+	reviewers, teamReviewers := opts.Reviewers, []string(nil)
+	for _, team := range opts.TeamReviewers {
+		teamReviewers = mergeUnique(teamReviewers, []string{teamSlug(team)})
+	}
+	if opts.ReviewersFromCodeowners {
+		owners, err := s.codeownersFor(ctx, repo, drift.TargetPath)
+		if err != nil {
+			return fmt.Errorf("resolving CODEOWNERS: %w", err)
+		}
+		users, teams := splitOwners(owners)
+		reviewers = mergeUnique(reviewers, users)
+		teamReviewers = mergeUnique(teamReviewers, teams)
+	}
+
+	if len(reviewers) > 0 || len(teamReviewers) > 0 {
+		if err := s.gh.RequestReviewers(ctx, repo, number, reviewers, teamReviewers); err != nil {
+			return fmt.Errorf("requesting reviewers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *remediationService) ensureLabel(ctx context.Context, repo, name string) error {
+	_, resp, err := s.gh.GetLabel(ctx, repo, name)
+	if err == nil {
+		return nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+	_, err = s.gh.CreateLabel(ctx, repo, name, labelColor)
+	return err
+}
+
+// codeownersFor returns the owners of path according to the first CODEOWNERS
+// file found on the default branch, or nil when the repository has none.
+func (s *remediationService) codeownersFor(ctx context.Context, repo, path string) ([]string, error) {
+	for _, location := range codeownersLocations {
+		content, _, err := s.gh.GetFileContent(ctx, repo, location, "HEAD")
+		if err != nil {
+			continue
+		}
+		return parseCodeowners(content).ownersFor(path)
+	}
+	return nil, nil
+}
+
+// mergePullRequestOptions combines the service-wide defaults with the
+// options of a single policy. Lists are merged, flags are enabled by either side.
+func mergePullRequestOptions(defaults, policy models.PullRequestOptions) models.PullRequestOptions {
+	return models.PullRequestOptions{
+		Labels:                  mergeUnique(defaults.Labels, policy.Labels),
+		Assignees:               mergeUnique(defaults.Assignees, policy.Assignees),
+		Reviewers:               mergeUnique(defaults.Reviewers, policy.Reviewers),
+		TeamReviewers:           mergeUnique(defaults.TeamReviewers, policy.TeamReviewers),
+		Draft:                   defaults.Draft || policy.Draft,
+		ReviewersFromCodeowners: defaults.ReviewersFromCodeowners || policy.ReviewersFromCodeowners,
+	}
+}
+
+func mergeUnique(a, b []string) []string {
+	var merged []string
+	for _, v := range append(slices.Clone(a), b...) {
+		if v != "" && !slices.Contains(merged, v) {
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestRemediate_CreateNewPR_AppliesMetadata(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("workflow content"))
+	}))
+	defer server.Close()
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy: models.PolicyWorkflow{
+			Name: "dockerfile",
+			PullRequest: models.PullRequestOptions{
+				Labels:    []string{"docker"},
+				Reviewers: []string{"alice"},
+				Draft:     true,
+			},
+		},
+		Action:         models.PolicyActionCreate,
+		TargetPath:     ".github/workflows/dockerfile.yml",
+		ExpectedSource: server.URL,
+	}
+
+	mockClient.EXPECT().FindPullRequestByBranch(mock.Anything, "my-repo", "chore/dockerfile").Once().Return(nil, nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "main").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateBranch(mock.Anything, "my-repo", "chore/dockerfile", "base-sha-123").Once().Return(nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "chore/dockerfile").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateOrUpdateFile(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml", "chore/dockerfile", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
+
+	// Draft flag comes from the policy
+	mockClient.
+		EXPECT().
+		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", true).
+		Once().
+		Return(&gh.PullRequest{
+			Number:  gh.Ptr(42),
+			HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/42"),
+		}, nil)
+
+	// "policy-bot" label exists, "docker" label must be created
+	mockClient.
+		EXPECT().
+		GetLabel(mock.Anything, "my-repo", "policy-bot").
+		Once().
+		Return(&gh.Label{Name: gh.Ptr("policy-bot")}, &gh.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+	mockClient.
+		EXPECT().
+		GetLabel(mock.Anything, "my-repo", "docker").
+		Once().
+		Return(nil, &gh.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found"))
+	mockClient.
+		EXPECT().
+		CreateLabel(mock.Anything, "my-repo", "docker", labelColor).
+		Once().
+		Return(&gh.Label{Name: gh.Ptr("docker")}, nil)
+	mockClient.
+		EXPECT().
+		AddLabelsToIssue(mock.Anything, "my-repo", 42, []string{"policy-bot", "docker"}).
+		Once().
+		Return(nil)
+
+	mockClient.
+		EXPECT().
+		AddAssignees(mock.Anything, "my-repo", 42, []string{"bob"}).
+		Once().
+		Return(nil)
+
+	mockClient.
+		EXPECT().
+		RequestReviewers(mock.Anything, "my-repo", 42, []string{"alice"}, []string(nil)).
+		Once().
+		Return(nil)
+
+	svc := NewRemediationService(mockClient, WithPullRequestDefaults(models.PullRequestOptions{
+		Labels:    []string{"policy-bot"},
+		Assignees: []string{"bob"},
+	}))
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created", result.Action)
+	assert.NoError(t, result.Error)
+}
+
+func TestRemediate_CreateNewPR_MetadataErrorIsReported(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("workflow content"))
+	}))
+	defer server.Close()
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy: models.PolicyWorkflow{
+			Name:        "dockerfile",
+			PullRequest: models.PullRequestOptions{Assignees: []string{"ghost"}},
+		},
+		Action:         models.PolicyActionCreate,
+		TargetPath:     ".github/workflows/dockerfile.yml",
+		ExpectedSource: server.URL,
+	}
+
+	mockClient.EXPECT().FindPullRequestByBranch(mock.Anything, "my-repo", "chore/dockerfile").Once().Return(nil, nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "main").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateBranch(mock.Anything, "my-repo", "chore/dockerfile", "base-sha-123").Once().Return(nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "chore/dockerfile").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateOrUpdateFile(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml", "chore/dockerfile", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
+	mockClient.EXPECT().CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", false).Once().Return(&gh.PullRequest{
+		Number:  gh.Ptr(42),
+		HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/42"),
+	}, nil)
+
+	mockClient.
+		EXPECT().
+		AddAssignees(mock.Anything, "my-repo", 42, []string{"ghost"}).
+		Once().
+		Return(errors.New("user cannot be assigned"))
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created", result.Action)
+	assert.Equal(t, "https://github.com/org/my-repo/pull/42", result.PRURL)
+	assert.ErrorContains(t, result.Error, "applying PR metadata")
+}
+
+func TestApplyPullRequestMetadata_ReviewersFromCodeowners(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo"},
+		TargetPath: ".github/workflows/dockerfile.yml",
+	}
+
+	// No CODEOWNERS in .github/, fallback to the root file
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/CODEOWNERS", "HEAD").
+		Once().
+		Return("", "", errors.New("not found"))
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", "CODEOWNERS", "HEAD").
+		Once().
+		Return("* @carol\n.github/ @tracker-tv/platform @alice\n", "sha", nil)
+
+	mockClient.
+		EXPECT().
+		RequestReviewers(mock.Anything, "my-repo", 7, []string{"alice"}, []string{"platform"}).
+		Once().
+		Return(nil)
+
+	svc := &remediationService{gh: mockClient}
+	err := svc.applyPullRequestMetadata(ctx, drift, 7, models.PullRequestOptions{
+		Reviewers:               []string{"alice"},
+		ReviewersFromCodeowners: true,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestApplyPullRequestMetadata_TeamSlugs(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		RequestReviewers(mock.Anything, "my-repo", 7, []string(nil), []string{"platform", "security"}).
+		Once().
+		Return(nil)
+
+	svc := &remediationService{gh: mockClient}
+	err := svc.applyPullRequestMetadata(context.Background(), models.PolicyDeviation{Repository: models.Repository{Name: "my-repo"}}, 7, models.PullRequestOptions{
+		TeamReviewers: []string{"tracker-tv/platform", "security", "platform"},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestApplyPullRequestMetadata_Empty(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	svc := &remediationService{gh: mockClient}
+	err := svc.applyPullRequestMetadata(context.Background(), models.PolicyDeviation{}, 7, models.PullRequestOptions{})
+
+	assert.NoError(t, err)
+}
+
+func TestMergePullRequestOptions(t *testing.T) {
+	merged := mergePullRequestOptions(
+		models.PullRequestOptions{Labels: []string{"policy-bot"}, Reviewers: []string{"alice"}, Draft: true},
+		models.PullRequestOptions{Labels: []string{"docker", "policy-bot"}, TeamReviewers: []string{"platform"}},
+	)
+
+	assert.Equal(t, []string{"policy-bot", "docker"}, merged.Labels)
+	assert.Equal(t, []string{"alice"}, merged.Reviewers)
+	assert.Equal(t, []string{"platform"}, merged.TeamReviewers)
+	assert.True(t, merged.Draft)
+	assert.False(t, merged.ReviewersFromCodeowners)
+}
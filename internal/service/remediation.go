@@ -2,20 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 
 	gh "github.com/google/go-github/v80/github"
 	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
 	"github.com/tracker-tv/github-policy-bots/models"
 )
 
 type RemediationResult struct {
-	Drift  models.PolicyDeviation
-	Action string // "created", "updated", "skipped"
-	PRURL  string
-	Error  error
+	Drift           models.PolicyDeviation
+	Action          string // "created", "updated", "skipped", or "reported" for settings left as is
+	PRURL           string
+	AutoMerge       bool   // Auto-merge was enabled on the PR
+	AutoMergeReason string // Why auto-merge requested by the policy could not be enabled
+	Content         string // Content the PR gives the target path, empty when the file is removed
+	Error           error
 }
 
 type RemediationService interface {
@@ -23,24 +30,53 @@ type RemediationService interface {
 }
 
 type remediationService struct {
-	gh         github.Client
-	httpClient *http.Client
+	gh                 github.Client
+	httpClient         *http.Client
+	pullRequestOptions models.PullRequestOptions
 }
 
-func NewRemediationService(gh github.Client) RemediationService {
-	return &remediationService{
+// RemediationOption configures optional behaviour of the remediation service.
+type RemediationOption func(*remediationService)
+
+// WithPullRequestDefaults sets the PR metadata applied to every policy, on top of the policy's own options.
+func WithPullRequestDefaults(opts models.PullRequestOptions) RemediationOption {
+	return func(s *remediationService) {
+		s.pullRequestOptions = opts
+	}
+}
+
+func NewRemediationService(gh github.Client, opts ...RemediationOption) RemediationService {
+	s := &remediationService{
 		gh:         gh,
 		httpClient: http.DefaultClient,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *remediationService) Remediate(ctx context.Context, drift models.PolicyDeviation) (*RemediationResult, error) {
+	// Only the repository owners can fix their configuration and their own
+	// workflows, and exempt deviations are waived. They are reported as is.
+	switch drift.Action {
+	case models.PolicyActionInvalidConfig, models.PolicyActionExempt, models.PolicyActionUnpinned:
+		return &RemediationResult{
+			Drift:  drift,
+			Action: "skipped",
+		}, nil
+	}
+
 	branchName := s.branchName(drift)
 
-	// 1. Fetch expected content from source
-	expectedContent, err := s.fetchExpectedContent(ctx, drift.ExpectedSource)
-	if err != nil {
-		return nil, fmt.Errorf("fetching expected content: %w", err)
+	// 1. Fetch expected content from source, unless it was rendered by Ensure or the file is being removed
+	expectedContent := drift.ExpectedContent
+	if expectedContent == "" && drift.Action != models.PolicyActionDelete {
+		var err error
+		expectedContent, err = s.fetchExpectedContent(ctx, drift.ExpectedSource)
+		if err != nil {
+			return nil, fmt.Errorf("fetching expected content: %w", err)
+		}
 	}
 
 	// 2. Check if PR already exists for this branch
@@ -49,20 +85,60 @@ func (s *remediationService) Remediate(ctx context.Context, drift models.PolicyD
 		return nil, fmt.Errorf("finding existing PR: %w", err)
 	}
 
+	var result *RemediationResult
 	if existingPR != nil {
 		// PR exists - check if content needs update
-		return s.handleExistingPR(ctx, drift, branchName, expectedContent, existingPR)
+		logging.FromContext(ctx).Debug("pull request already open", "branch", branchName, "pr", existingPR.GetHTMLURL())
+		result, err = s.handleExistingPR(ctx, drift, branchName, expectedContent, existingPR)
+		if err == nil && drift.Grouping == models.PRGroupingSingle {
+			s.joinGroupedPR(ctx, drift, existingPR, result)
+		}
+	} else {
+		// 3. No existing PR - create new branch and PR
+		result, err = s.createNewPR(ctx, drift, branchName, expectedContent)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// 3. No existing PR - create new branch and PR
-	return s.createNewPR(ctx, drift, branchName, expectedContent)
+	if drift.Action != models.PolicyActionDelete {
+		result.Content = wrapContent(expectedContent, drift.Policy.Name)
+	}
+	return result, nil
 }
 
+// groupedBranchName is the branch shared by every policy of a repository grouping its changes.
+const groupedBranchName = "chore/policy-bot"
+
 func (s *remediationService) branchName(drift models.PolicyDeviation) string {
-	return fmt.Sprintf("chore/%s", drift.Policy.Name)
+	if drift.Grouping == models.PRGroupingSingle {
+		return groupedBranchName
+	}
+	return policyBranchName(drift.Policy.Name)
+}
+
+func policyBranchName(policy string) string {
+	return fmt.Sprintf("chore/%s", policy)
+}
+
+// IsPolicyBranch reports whether the bot opens pull requests from branch for one of policies.
+func IsPolicyBranch(branch string, policies []models.PolicyWorkflow) bool {
+	if branch == groupedBranchName {
+		return true
+	}
+	return slices.ContainsFunc(policies, func(p models.PolicyWorkflow) bool {
+		return policyBranchName(p.Name) == branch
+	})
 }
 
 func (s *remediationService) handleExistingPR(ctx context.Context, drift models.PolicyDeviation, branchName, expectedContent string, pr *gh.PullRequest) (*RemediationResult, error) {
+	switch drift.Action {
+	case models.PolicyActionDelete:
+		return s.handleExistingDeletePR(ctx, drift, branchName, pr)
+	case models.PolicyActionMigrate:
+		return s.handleExistingMigratePR(ctx, drift, branchName, expectedContent, pr)
+	}
+
 	// Get current content on the PR branch
 	currentContent, fileSHA, err := s.gh.GetFileContent(ctx, drift.Repository.Name, drift.TargetPath, branchName)
 	if err != nil {
@@ -73,7 +149,8 @@ func (s *remediationService) handleExistingPR(ctx context.Context, drift models.
 
 	wrappedContent := wrapContent(expectedContent, drift.Policy.Name)
 
-	if currentContent == wrappedContent {
+	// A branch only differing cosmetically is not rewritten, like the default branch
+	if sameContent(comparisonOf(drift.Policy), currentContent, wrappedContent) {
 		return &RemediationResult{
 			Drift:  drift,
 			Action: "skipped",
@@ -99,15 +176,69 @@ func (s *remediationService) handleExistingPR(ctx context.Context, drift models.
 	}, nil
 }
 
+func (s *remediationService) handleExistingDeletePR(ctx context.Context, drift models.PolicyDeviation, branchName string, pr *gh.PullRequest) (*RemediationResult, error) {
+	_, fileSHA, err := s.gh.GetFileContent(ctx, drift.Repository.Name, drift.TargetPath, branchName)
+	if err != nil {
+		// File is already removed on the PR branch
+		return &RemediationResult{
+			Drift:  drift,
+			Action: "skipped",
+			PRURL:  pr.GetHTMLURL(),
+		}, nil
+	}
+
+	commitMsg := fmt.Sprintf("Remove %s workflow", drift.Policy.Name)
+	if err := s.gh.DeleteFile(ctx, drift.Repository.Name, drift.TargetPath, branchName, commitMsg, fileSHA); err != nil {
+		return nil, fmt.Errorf("deleting file: %w", err)
+	}
+
+	return &RemediationResult{
+		Drift:  drift,
+		Action: "updated",
+		PRURL:  pr.GetHTMLURL(),
+	}, nil
+}
+
+func (s *remediationService) handleExistingMigratePR(ctx context.Context, drift models.PolicyDeviation, branchName, expectedContent string, pr *gh.PullRequest) (*RemediationResult, error) {
+	currentContent, _, err := s.gh.GetFileContent(ctx, drift.Repository.Name, drift.TargetPath, branchName)
+	if err != nil {
+		currentContent = ""
+	}
+
+	var remaining []string
+	for _, path := range drift.PreviousPaths {
+		if _, _, err := s.gh.GetFileContent(ctx, drift.Repository.Name, path, branchName); err == nil {
+			remaining = append(remaining, path)
+		}
+	}
+
+	wrappedContent := wrapContent(expectedContent, drift.Policy.Name)
+	if sameContent(comparisonOf(drift.Policy), currentContent, wrappedContent) && len(remaining) == 0 {
+		return &RemediationResult{
+			Drift:  drift,
+			Action: "skipped",
+			PRURL:  pr.GetHTMLURL(),
+		}, nil
+	}
+
+	commitMsg := fmt.Sprintf("Migrate %s policy", drift.Policy.Name)
+	files := map[string]string{drift.TargetPath: wrappedContent}
+	if err := s.gh.CommitFiles(ctx, drift.Repository.Name, branchName, commitMsg, files, remaining); err != nil {
+		return nil, fmt.Errorf("committing migration: %w", err)
+	}
+
+	return &RemediationResult{
+		Drift:  drift,
+		Action: "updated",
+		PRURL:  pr.GetHTMLURL(),
+	}, nil
+}
+
 func (s *remediationService) createNewPR(ctx context.Context, drift models.PolicyDeviation, branchName, expectedContent string) (*RemediationResult, error) {
 	// 1. Get default branch SHA
-	defaultBranch, err := s.gh.GetBranch(ctx, drift.Repository.Name, "main")
+	defaultBranch, err := getDefaultBranch(ctx, s.gh, drift.Repository.Name)
 	if err != nil {
-		// Try 'master' as fallback
-		defaultBranch, err = s.gh.GetBranch(ctx, drift.Repository.Name, "master")
-		if err != nil {
-			return nil, fmt.Errorf("getting default branch: %w", err)
-		}
+		return nil, fmt.Errorf("getting default branch: %w", err)
 	}
 
 	if defaultBranch == nil || defaultBranch.GetObject() == nil {
@@ -151,50 +282,284 @@ func (s *remediationService) createNewPR(ctx context.Context, drift models.Polic
 		}
 	}
 
-	wrappedContent := wrapContent(expectedContent, drift.Policy.Name)
-	if err := s.gh.CreateOrUpdateFile(ctx, drift.Repository.Name, drift.TargetPath, branchName, commitMsg, wrappedContent, fileSHA); err != nil {
-		return nil, fmt.Errorf("creating file %s on branch %s: %w", drift.TargetPath, branchName, err)
+	switch drift.Action {
+	case models.PolicyActionMigrate:
+		// Move the workflow in one commit so the old and new files never run side by side
+		files := map[string]string{drift.TargetPath: wrapContent(expectedContent, drift.Policy.Name)}
+		if err := s.gh.CommitFiles(ctx, drift.Repository.Name, branchName, commitMsg, files, drift.PreviousPaths); err != nil {
+			return nil, fmt.Errorf("committing migration on branch %s: %w", branchName, err)
+		}
+	case models.PolicyActionDelete:
+		_, sha, err := s.gh.GetFileContent(ctx, drift.Repository.Name, drift.TargetPath, branchName)
+		if err != nil {
+			return nil, fmt.Errorf("getting file %s on branch %s: %w", drift.TargetPath, branchName, err)
+		}
+		if err := s.gh.DeleteFile(ctx, drift.Repository.Name, drift.TargetPath, branchName, commitMsg, sha); err != nil {
+			return nil, fmt.Errorf("deleting file %s on branch %s: %w", drift.TargetPath, branchName, err)
+		}
+	default:
+		wrappedContent := wrapContent(expectedContent, drift.Policy.Name)
+		if err := s.gh.CreateOrUpdateFile(ctx, drift.Repository.Name, drift.TargetPath, branchName, commitMsg, wrappedContent, fileSHA); err != nil {
+			return nil, fmt.Errorf("creating file %s on branch %s: %w", drift.TargetPath, branchName, err)
+		}
 	}
 
 	// 4. Create PR
 	prTitle := fmt.Sprintf("chore(gha): %s %s workflow", actionVerb(drift.Action), drift.Policy.Name)
+	if drift.Grouping == models.PRGroupingSingle {
+		// Later policies commit to the same branch, see handleExistingPR
+		prTitle = "chore(gha): apply policy-bot policies"
+	}
 	prBody := s.buildPRBody(drift)
+	prOptions := mergePullRequestOptions(s.pullRequestOptions, drift.Policy.PullRequest)
 
-	pr, err := s.gh.CreatePullRequest(ctx, drift.Repository.Name, prTitle, prBody, branchName, "main")
+	pr, err := s.gh.CreatePullRequest(ctx, drift.Repository.Name, prTitle, prBody, branchName, "main", prOptions.Draft)
 	if err != nil {
 		return nil, fmt.Errorf("creating PR: %w", err)
 	}
 
-	return &RemediationResult{
+	logging.FromContext(ctx).Info("pull request created", "branch", branchName, "pr", pr.GetHTMLURL())
+
+	result := &RemediationResult{
 		Drift:  drift,
 		Action: "created",
 		PRURL:  pr.GetHTMLURL(),
-	}, nil
+	}
+
+	// 5. Apply labels, assignees and reviewers. The PR already exists at this
+	// point, so a failure is reported on the result instead of failing the remediation.
+	if err := s.applyPullRequestMetadata(ctx, drift, pr.GetNumber(), prOptions); err != nil {
+		result.Error = fmt.Errorf("applying PR metadata: %w", err)
+	}
+
+	// 6. Enable auto-merge for policies trusted to merge once checks pass
+	if drift.Policy.AutoMerge != "" {
+		s.enableAutoMerge(ctx, drift, pr, result)
+	}
+
+	return result, nil
+}
+
+// getDefaultBranch returns the reference of main, or master as a fallback.
+func getDefaultBranch(ctx context.Context, client github.Client, repo string) (*gh.Reference, error) {
+	ref, err := client.GetBranch(ctx, repo, "main")
+	if err != nil {
+		return client.GetBranch(ctx, repo, "master")
+	}
+	return ref, nil
+}
+
+// joinGroupedPR describes drift in the body of the grouped PR and, when its
+// policy joins the PR, applies the metadata and auto-merge of the policy as
+// for a PR of its own. The change is already committed, so failures are
+// reported on the result.
+func (s *remediationService) joinGroupedPR(ctx context.Context, drift models.PolicyDeviation, pr *gh.PullRequest, result *RemediationResult) {
+	_, sections := groupedSections(pr.GetBody())
+	_, joined := sections[drift.Policy.Name]
+
+	if body := buildGroupedPRBody(pr.GetBody(), drift); body != pr.GetBody() {
+		if err := s.gh.EditPullRequestBody(ctx, drift.Repository.Name, pr.GetNumber(), body); err != nil {
+			result.Error = fmt.Errorf("updating PR body: %w", err)
+		}
+	}
+	if joined {
+		return
+	}
+
+	logging.FromContext(ctx).Info("policy joined grouped pull request", "pr", pr.GetHTMLURL())
+	prOptions := mergePullRequestOptions(s.pullRequestOptions, drift.Policy.PullRequest)
+	if err := s.applyPullRequestMetadata(ctx, drift, pr.GetNumber(), prOptions); err != nil && result.Error == nil {
+		result.Error = fmt.Errorf("applying PR metadata: %w", err)
+	}
+	if drift.Policy.AutoMerge != "" {
+		s.enableAutoMerge(ctx, drift, pr, result)
+	}
+}
+
+func (s *remediationService) enableAutoMerge(ctx context.Context, drift models.PolicyDeviation, pr *gh.PullRequest, result *RemediationResult) {
+	err := s.gh.EnableAutoMerge(ctx, pr.GetNodeID(), string(drift.Policy.AutoMerge))
+	switch {
+	case err == nil:
+		result.AutoMerge = true
+	case errors.Is(err, github.ErrAutoMergeUnavailable):
+		result.AutoMergeReason = err.Error()
+	case result.Error == nil:
+		result.Error = fmt.Errorf("enabling auto-merge: %w", err)
+	}
 }
 
+const prFooter = `
+---
+*This is an automated PR. Please review before merging.*
+`
+
 func (s *remediationService) buildPRBody(drift models.PolicyDeviation) string {
+	if drift.Grouping == models.PRGroupingSingle {
+		return buildGroupedPRBody("", drift)
+	}
+	return s.buildPRSummary(drift) + buildPolicySection(drift) + prFooter
+}
+
+func (s *remediationService) buildPRSummary(drift models.PolicyDeviation) string {
+	purpose := "ensure compliance"
+	if drift.Action == models.PolicyActionDelete {
+		purpose = "remove a retired workflow"
+	}
 	return fmt.Sprintf(`## Policy Bot Automated PR
 
-This PR was automatically created by the Policy Bot to ensure compliance.
+This PR was automatically created by the Policy Bot to %s.
+
+`, purpose)
+}
 
-**Policy:** %s
+// buildPolicySection describes the change drift makes.
+func buildPolicySection(drift models.PolicyDeviation) string {
+	if drift.Action == models.PolicyActionDelete {
+		return fmt.Sprintf(`**Policy:** %s
 **Action:** %s
-**Target File:** %s
+**Removed File:** %s
 
----
-*This is an automated PR. Please review before merging.*
-`, drift.Policy.Name, drift.Action, drift.TargetPath)
+The %s policy is no longer enforced and its workflow should not run anymore.
+`, drift.Policy.Name, drift.Action, drift.TargetPath, drift.Policy.Name) + buildSourceChangeSection(drift) + buildOverridesSection(drift)
+	}
+
+	var migration string
+	if drift.Action == models.PolicyActionMigrate {
+		migration = fmt.Sprintf(`
+### Policy renamed
+
+The %s policy was renamed. This PR moves %s to %s in a single commit,
+so the workflow does not run twice.
+`, drift.Policy.Name, strings.Join(drift.PreviousPaths, ", "), drift.TargetPath)
+	}
+
+	return fmt.Sprintf(`**Policy:** %s
+**Action:** %s
+**Target File:** %s%s
+%s`, drift.Policy.Name, drift.Action, drift.TargetPath, comparisonLine(drift), migration) + buildSourceChangeSection(drift) + buildOverridesSection(drift)
+}
+
+// The body of the grouped PR has a section per policy between these markers,
+// so that a policy joining the PR adds its own and keeps the others.
+const (
+	groupedSectionStart = "<!-- policy-bot:policy "
+	groupedSectionEnd   = "<!-- policy-bot:policy-end -->\n"
+)
+
+// buildGroupedPRBody describes every policy of the grouped PR whose body is
+// body, the section of drift added or replaced.
+func buildGroupedPRBody(body string, drift models.PolicyDeviation) string {
+	names, sections := groupedSections(body)
+	if _, ok := sections[drift.Policy.Name]; !ok {
+		names = append(names, drift.Policy.Name)
+	}
+	sections[drift.Policy.Name] = buildPolicySection(drift)
+
+	var b strings.Builder
+	b.WriteString(`## Policy Bot Automated PR
+
+This PR was automatically created by the Policy Bot to ensure compliance.
+The repository groups policy changes in this PR, other policies may add commits to it.
+`)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n%s%s -->\n%s%s", groupedSectionStart, name, sections[name], groupedSectionEnd)
+	}
+	return b.String() + prFooter
+}
+
+// groupedSections returns the policy sections of the body of a grouped PR, by
+// policy name, and the names in order.
+func groupedSections(body string) ([]string, map[string]string) {
+	var names []string
+	sections := make(map[string]string)
+	for {
+		_, rest, ok := strings.Cut(body, groupedSectionStart)
+		if !ok {
+			break
+		}
+		name, rest, ok := strings.Cut(rest, " -->\n")
+		if !ok {
+			break
+		}
+		section, rest, ok := strings.Cut(rest, groupedSectionEnd)
+		if !ok {
+			break
+		}
+		names = append(names, name)
+		sections[name] = section
+		body = rest
+	}
+	return names, sections
+}
+
+// comparisonDescriptions explain what a comparison found when it reports a difference.
+var comparisonDescriptions = map[models.Comparison]string{
+	models.ComparisonExact:      "the content differs",
+	models.ComparisonNormalized: "the content differs beyond line endings and trailing whitespace",
+	models.ComparisonSemantic:   "the parsed documents differ",
+}
+
+// comparisonLine tells which comparison found the workflow outdated, empty when the file was missing.
+func comparisonLine(drift models.PolicyDeviation) string {
+	if drift.Comparison == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n**Comparison:** %s (%s)", drift.Comparison, comparisonDescriptions[drift.Comparison])
+}
+
+// buildSourceChangeSection links to the commits of the policy source that caused the PR.
+func buildSourceChangeSection(drift models.PolicyDeviation) string {
+	change := drift.SourceChange
+	if change == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n### Policy source change\n\nTriggered by [%s@%s...%s](%s).\n",
+		change.Repository, shortSHA(change.Base), shortSHA(change.Head), change.CompareURL())
+}
+
+// shortSHA abbreviates full commit SHAs, leaving branch and tag names as is.
+func shortSHA(ref string) string {
+	if len(ref) == 40 && strings.Trim(ref, "0123456789abcdef") == "" {
+		return ref[:7]
+	}
+	return ref
+}
+
+// buildOverridesSection lists the settings of the repository configuration applied to the policy.
+func buildOverridesSection(drift models.PolicyDeviation) string {
+	if len(drift.Overrides) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n### Repository overrides\n\nApplied from `%s`:\n\n", models.RepositoryConfigPath)
+	for _, override := range drift.Overrides {
+		fmt.Fprintf(&b, "- %s\n", override)
+	}
+	return b.String()
 }
 
 func actionVerb(action models.PolicyAction) string {
-	if action == models.PolicyActionCreate {
+	switch action {
+	case models.PolicyActionCreate:
 		return "add"
+	case models.PolicyActionDelete:
+		return "remove"
+	case models.PolicyActionMigrate:
+		return "migrate"
 	}
 	return "update"
 }
 
+const managedMarker = "# DO NOT EDIT: BEGIN"
+
+// isManaged reports whether content was written by the bot.
+func isManaged(content string) bool {
+	return strings.HasPrefix(content, managedMarker)
+}
+
 func wrapContent(content, policyName string) string {
-	header := fmt.Sprintf(`# DO NOT EDIT: BEGIN
+	header := fmt.Sprintf(managedMarker+`
 # This snippet has been inserted automatically by tracker-tv-bot, do not edit!
 # If changes are needed, update the action %s in
 # https://github.com/tracker-tv/github-actions-ttv.
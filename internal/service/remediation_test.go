@@ -3,13 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	gh "github.com/google/go-github/v80/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tracker-tv/github-policy-bots/internal/github"
 	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
 	"github.com/tracker-tv/github-policy-bots/models"
 )
@@ -86,7 +89,7 @@ func TestRemediate_CreateNewPR_Success(t *testing.T) {
 	// Create PR
 	mockClient.
 		EXPECT().
-		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main").
+		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", false).
 		Once().
 		Return(&gh.PullRequest{
 			Number:  gh.Ptr(42),
@@ -100,6 +103,7 @@ func TestRemediate_CreateNewPR_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "created", result.Action)
 	assert.Equal(t, "https://github.com/org/my-repo/pull/42", result.PRURL)
+	assert.Equal(t, wrapContent(expectedContent, "dockerfile"), result.Content)
 }
 
 func TestRemediate_ExistingPR_ContentMatches_Skip(t *testing.T) {
@@ -485,7 +489,7 @@ func TestRemediate_CreatePRError(t *testing.T) {
 
 	mockClient.
 		EXPECT().
-		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main").
+		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", false).
 		Once().
 		Return(nil, errors.New("PR already exists"))
 
@@ -562,7 +566,7 @@ func TestRemediate_FallbackToMasterBranch(t *testing.T) {
 
 	mockClient.
 		EXPECT().
-		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main").
+		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", false).
 		Once().
 		Return(&gh.PullRequest{
 			Number:  gh.Ptr(1),
@@ -636,7 +640,7 @@ func TestRemediate_BranchAlreadyExists_Continue(t *testing.T) {
 
 	mockClient.
 		EXPECT().
-		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main").
+		CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", false).
 		Once().
 		Return(&gh.PullRequest{
 			Number:  gh.Ptr(1),
@@ -651,6 +655,328 @@ func TestRemediate_BranchAlreadyExists_Continue(t *testing.T) {
 	assert.Equal(t, "created", result.Action)
 }
 
+func TestRemediate_CreateNewPR_EnablesAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("workflow content"))
+	}))
+	defer server.Close()
+
+	drift := models.PolicyDeviation{
+		Repository:     models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:         models.PolicyWorkflow{Name: "dockerfile", AutoMerge: models.MergeMethodSquash},
+		Action:         models.PolicyActionCreate,
+		TargetPath:     ".github/workflows/dockerfile.yml",
+		ExpectedSource: server.URL,
+	}
+
+	mockClient.EXPECT().FindPullRequestByBranch(mock.Anything, "my-repo", "chore/dockerfile").Once().Return(nil, nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "main").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateBranch(mock.Anything, "my-repo", "chore/dockerfile", "base-sha-123").Once().Return(nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "chore/dockerfile").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateOrUpdateFile(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml", "chore/dockerfile", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
+	mockClient.EXPECT().CreatePullRequest(mock.Anything, "my-repo", mock.Anything, mock.Anything, "chore/dockerfile", "main", false).Once().Return(&gh.PullRequest{
+		Number:  gh.Ptr(42),
+		NodeID:  gh.Ptr("PR_kwDO42"),
+		HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/42"),
+	}, nil)
+
+	mockClient.
+		EXPECT().
+		EnableAutoMerge(mock.Anything, "PR_kwDO42", "squash").
+		Once().
+		Return(nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created", result.Action)
+	assert.True(t, result.AutoMerge)
+	assert.Empty(t, result.AutoMergeReason)
+}
+
+func TestEnableAutoMerge_Unavailable(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{Policy: models.PolicyWorkflow{Name: "dockerfile", AutoMerge: models.MergeMethodMerge}}
+	pr := &gh.PullRequest{NodeID: gh.Ptr("PR_kwDO42")}
+
+	mockClient.
+		EXPECT().
+		EnableAutoMerge(mock.Anything, "PR_kwDO42", "merge").
+		Once().
+		Return(fmt.Errorf("%w: Pull request Auto merge is not allowed for this repository", github.ErrAutoMergeUnavailable))
+
+	svc := &remediationService{gh: mockClient}
+	result := &RemediationResult{Action: "created"}
+	svc.enableAutoMerge(context.Background(), drift, pr, result)
+
+	assert.False(t, result.AutoMerge)
+	assert.Contains(t, result.AutoMergeReason, "not allowed for this repository")
+	assert.NoError(t, result.Error)
+}
+
+func TestEnableAutoMerge_Error(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{Policy: models.PolicyWorkflow{Name: "dockerfile", AutoMerge: models.MergeMethodMerge}}
+	pr := &gh.PullRequest{NodeID: gh.Ptr("PR_kwDO42")}
+
+	mockClient.
+		EXPECT().
+		EnableAutoMerge(mock.Anything, "PR_kwDO42", "merge").
+		Once().
+		Return(errors.New("bad credentials"))
+
+	svc := &remediationService{gh: mockClient}
+	result := &RemediationResult{Action: "created"}
+	svc.enableAutoMerge(context.Background(), drift, pr, result)
+
+	assert.False(t, result.AutoMerge)
+	assert.Empty(t, result.AutoMergeReason)
+	assert.ErrorContains(t, result.Error, "enabling auto-merge")
+}
+
+func TestRemediate_Delete_CreateNewPR(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:     models.PolicyWorkflow{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent},
+		Action:     models.PolicyActionDelete,
+		TargetPath: ".github/workflows/docker-publish.yml",
+	}
+
+	mockClient.EXPECT().FindPullRequestByBranch(mock.Anything, "my-repo", "chore/docker-publish").Once().Return(nil, nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "main").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateBranch(mock.Anything, "my-repo", "chore/docker-publish", "base-sha-123").Once().Return(nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "chore/docker-publish").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/docker-publish.yml", "chore/docker-publish").
+		Once().
+		Return("name: publish", "file-sha", nil)
+
+	mockClient.
+		EXPECT().
+		DeleteFile(mock.Anything, "my-repo", ".github/workflows/docker-publish.yml", "chore/docker-publish", "chore(gha): remove docker-publish workflow", "file-sha").
+		Once().
+		Return(nil)
+
+	mockClient.
+		EXPECT().
+		CreatePullRequest(mock.Anything, "my-repo", "chore(gha): remove docker-publish workflow",
+			mock.MatchedBy(func(body string) bool {
+				return strings.Contains(body, "remove a retired workflow") &&
+					strings.Contains(body, "**Removed File:** .github/workflows/docker-publish.yml")
+			}),
+			"chore/docker-publish", "main", false).
+		Once().
+		Return(&gh.PullRequest{
+			Number:  gh.Ptr(5),
+			HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/5"),
+		}, nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created", result.Action)
+	assert.Equal(t, "https://github.com/org/my-repo/pull/5", result.PRURL)
+}
+
+func TestRemediate_Delete_ExistingPR_AlreadyRemoved(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:     models.PolicyWorkflow{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent},
+		Action:     models.PolicyActionDelete,
+		TargetPath: ".github/workflows/docker-publish.yml",
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/docker-publish").
+		Once().
+		Return(&gh.PullRequest{HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/5")}, nil)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/docker-publish.yml", "chore/docker-publish").
+		Once().
+		Return("", "", errors.New("not found"))
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+	assert.Equal(t, "https://github.com/org/my-repo/pull/5", result.PRURL)
+}
+
+func TestRemediate_Delete_ExistingPR_FileStillPresent(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:     models.PolicyWorkflow{Name: "docker-publish", Ensure: models.PolicyEnsureAbsent},
+		Action:     models.PolicyActionDelete,
+		TargetPath: ".github/workflows/docker-publish.yml",
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/docker-publish").
+		Once().
+		Return(&gh.PullRequest{HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/5")}, nil)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/docker-publish.yml", "chore/docker-publish").
+		Once().
+		Return("name: publish", "file-sha", nil)
+
+	mockClient.
+		EXPECT().
+		DeleteFile(mock.Anything, "my-repo", ".github/workflows/docker-publish.yml", "chore/docker-publish", mock.Anything, "file-sha").
+		Once().
+		Return(nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", result.Action)
+}
+
+func TestRemediate_Migrate_CreateNewPR(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	expectedContent := "workflow content"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(expectedContent))
+	}))
+	defer server.Close()
+
+	drift := models.PolicyDeviation{
+		Repository:     models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:         models.PolicyWorkflow{Name: "docker-build", PreviousNames: []string{"dockerfile"}},
+		Action:         models.PolicyActionMigrate,
+		TargetPath:     ".github/workflows/docker-build.yml",
+		ExpectedSource: server.URL,
+		PreviousPaths:  []string{".github/workflows/dockerfile.yml"},
+	}
+
+	mockClient.EXPECT().FindPullRequestByBranch(mock.Anything, "my-repo", "chore/docker-build").Once().Return(nil, nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "main").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+	mockClient.EXPECT().CreateBranch(mock.Anything, "my-repo", "chore/docker-build", "base-sha-123").Once().Return(nil)
+	mockClient.EXPECT().GetBranch(mock.Anything, "my-repo", "chore/docker-build").Once().Return(&gh.Reference{
+		Object: &gh.GitObject{SHA: gh.Ptr("base-sha-123")},
+	}, nil)
+
+	// New file written and old one removed in the same commit
+	mockClient.
+		EXPECT().
+		CommitFiles(mock.Anything, "my-repo", "chore/docker-build", "chore(gha): migrate docker-build workflow",
+			map[string]string{".github/workflows/docker-build.yml": wrapContent(expectedContent, "docker-build")},
+			[]string{".github/workflows/dockerfile.yml"},
+		).
+		Once().
+		Return(nil)
+
+	mockClient.
+		EXPECT().
+		CreatePullRequest(mock.Anything, "my-repo", "chore(gha): migrate docker-build workflow",
+			mock.MatchedBy(func(body string) bool {
+				return strings.Contains(body, "### Policy renamed") &&
+					strings.Contains(body, "moves .github/workflows/dockerfile.yml to .github/workflows/docker-build.yml")
+			}),
+			"chore/docker-build", "main", false).
+		Once().
+		Return(&gh.PullRequest{
+			Number:  gh.Ptr(8),
+			HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/8"),
+		}, nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created", result.Action)
+}
+
+func TestRemediate_Migrate_ExistingPR_UpToDate(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	expectedContent := "workflow content"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(expectedContent))
+	}))
+	defer server.Close()
+
+	drift := models.PolicyDeviation{
+		Repository:     models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:         models.PolicyWorkflow{Name: "docker-build", PreviousNames: []string{"dockerfile"}},
+		Action:         models.PolicyActionMigrate,
+		TargetPath:     ".github/workflows/docker-build.yml",
+		ExpectedSource: server.URL,
+		PreviousPaths:  []string{".github/workflows/dockerfile.yml"},
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/docker-build").
+		Once().
+		Return(&gh.PullRequest{HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/8")}, nil)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/docker-build.yml", "chore/docker-build").
+		Once().
+		Return(wrapContent(expectedContent, "docker-build"), "sha", nil)
+
+	// Old workflow already removed on the PR branch
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml", "chore/docker-build").
+		Once().
+		Return("", "", errors.New("not found"))
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+}
+
+func TestIsManaged(t *testing.T) {
+	assert.True(t, isManaged(wrapContent("name: test\n", "dockerfile")))
+	assert.False(t, isManaged("name: test\n"))
+}
+
 func TestWrapContent(t *testing.T) {
 	content := "name: test\non: push"
 	policyName := "dockerfile"
@@ -667,4 +993,345 @@ func TestWrapContent(t *testing.T) {
 func TestActionVerb(t *testing.T) {
 	assert.Equal(t, "add", actionVerb(models.PolicyActionCreate))
 	assert.Equal(t, "update", actionVerb(models.PolicyActionUpdate))
+	assert.Equal(t, "remove", actionVerb(models.PolicyActionDelete))
+	assert.Equal(t, "migrate", actionVerb(models.PolicyActionMigrate))
+}
+
+func TestRemediate_UsesExpectedContentWithoutFetching(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository:      models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:          models.PolicyWorkflow{Name: "dockerfile", Template: true},
+		Action:          models.PolicyActionUpdate,
+		TargetPath:      ".github/workflows/dockerfile.yml",
+		ExpectedSource:  "http://127.0.0.1:0/unreachable",
+		ExpectedContent: "image: my-repo",
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/dockerfile").
+		Once().
+		Return(&gh.PullRequest{Number: gh.Ptr(10), HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/10")}, nil)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml", "chore/dockerfile").
+		Once().
+		Return(wrapContent("image: my-repo", "dockerfile"), "existing-sha", nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+}
+
+func TestRemediate_InvalidConfig_Skipped(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Action:     models.PolicyActionInvalidConfig,
+		TargetPath: ".github/policy-bot.yml",
+		Reason:     "unknown grouping",
+	}
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(context.Background(), drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+	assert.Empty(t, result.PRURL)
+}
+
+func TestRemediate_GroupedPolicies_UseSharedBranch(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository:      models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:          models.PolicyWorkflow{Name: "dockerfile"},
+		Action:          models.PolicyActionCreate,
+		TargetPath:      ".github/workflows/dockerfile.yml",
+		ExpectedContent: "workflow content",
+		Grouping:        models.PRGroupingSingle,
+		Overrides:       []string{"changes grouped in a single PR"},
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/policy-bot").
+		Once().
+		Return(nil, nil)
+	mockClient.
+		EXPECT().
+		GetBranch(mock.Anything, "my-repo", "main").
+		Once().
+		Return(&gh.Reference{Object: &gh.GitObject{SHA: gh.Ptr("base-sha")}}, nil)
+	mockClient.
+		EXPECT().
+		CreateBranch(mock.Anything, "my-repo", "chore/policy-bot", "base-sha").
+		Once().
+		Return(nil)
+	mockClient.
+		EXPECT().
+		GetBranch(mock.Anything, "my-repo", "chore/policy-bot").
+		Once().
+		Return(&gh.Reference{Object: &gh.GitObject{SHA: gh.Ptr("base-sha")}}, nil)
+	mockClient.
+		EXPECT().
+		CreateOrUpdateFile(mock.Anything, "my-repo", ".github/workflows/dockerfile.yml", "chore/policy-bot", mock.Anything, mock.Anything, mock.Anything).
+		Once().
+		Return(nil)
+	mockClient.
+		EXPECT().
+		CreatePullRequest(mock.Anything, "my-repo", "chore(gha): apply policy-bot policies",
+			mock.MatchedBy(func(body string) bool {
+				return strings.Contains(body, "### Repository overrides") &&
+					strings.Contains(body, "- changes grouped in a single PR")
+			}),
+			"chore/policy-bot", "main", false).
+		Once().
+		Return(&gh.PullRequest{Number: gh.Ptr(7), HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/7")}, nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "created", result.Action)
+}
+
+func TestRemediate_GroupedPolicies_JoinExistingPR(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+	first := models.PolicyDeviation{
+		Repository: repo,
+		Policy:     models.PolicyWorkflow{Name: "dockerfile"},
+		Action:     models.PolicyActionCreate,
+		TargetPath: ".github/workflows/dockerfile.yml",
+		Grouping:   models.PRGroupingSingle,
+	}
+	drift := models.PolicyDeviation{
+		Repository: repo,
+		Policy: models.PolicyWorkflow{
+			Name:        "go",
+			AutoMerge:   models.MergeMethodSquash,
+			PullRequest: models.PullRequestOptions{Labels: []string{"go"}, Reviewers: []string{"alice"}},
+		},
+		Action:          models.PolicyActionCreate,
+		TargetPath:      ".github/workflows/go.yml",
+		ExpectedContent: "workflow content",
+		Grouping:        models.PRGroupingSingle,
+	}
+	pr := &gh.PullRequest{
+		Number:  gh.Ptr(7),
+		NodeID:  gh.Ptr("PR_kwDO7"),
+		Body:    gh.Ptr(buildGroupedPRBody("", first)),
+		HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/7"),
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/policy-bot").
+		Once().
+		Return(pr, nil)
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/go.yml", "chore/policy-bot").
+		Once().
+		Return("", "", errors.New("not found"))
+	mockClient.
+		EXPECT().
+		CreateOrUpdateFile(mock.Anything, "my-repo", ".github/workflows/go.yml", "chore/policy-bot", mock.Anything, mock.Anything, (*string)(nil)).
+		Once().
+		Return(nil)
+	// The body keeps the policies already grouped and describes the one joining
+	mockClient.
+		EXPECT().
+		EditPullRequestBody(mock.Anything, "my-repo", 7, mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "**Policy:** dockerfile") && strings.Contains(body, "**Policy:** go") &&
+				strings.Index(body, "dockerfile") < strings.Index(body, "**Policy:** go")
+		})).
+		Once().
+		Return(nil)
+	mockClient.
+		EXPECT().
+		GetLabel(mock.Anything, "my-repo", "go").
+		Once().
+		Return(&gh.Label{Name: gh.Ptr("go")}, nil, nil)
+	mockClient.
+		EXPECT().
+		AddLabelsToIssue(mock.Anything, "my-repo", 7, []string{"go"}).
+		Once().
+		Return(nil)
+	mockClient.
+		EXPECT().
+		RequestReviewers(mock.Anything, "my-repo", 7, []string{"alice"}, []string(nil)).
+		Once().
+		Return(nil)
+	mockClient.
+		EXPECT().
+		EnableAutoMerge(mock.Anything, "PR_kwDO7", "squash").
+		Once().
+		Return(nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", result.Action)
+	assert.NoError(t, result.Error)
+	assert.True(t, result.AutoMerge)
+}
+
+func TestRemediate_GroupedPolicies_AlreadyJoined(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository:      models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:          models.PolicyWorkflow{Name: "go", PullRequest: models.PullRequestOptions{Labels: []string{"go"}}},
+		Action:          models.PolicyActionCreate,
+		TargetPath:      ".github/workflows/go.yml",
+		ExpectedContent: "workflow content",
+		Grouping:        models.PRGroupingSingle,
+	}
+	pr := &gh.PullRequest{Number: gh.Ptr(7), Body: gh.Ptr(buildGroupedPRBody("", drift))}
+
+	// Neither the body nor the metadata are updated again
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/policy-bot").
+		Once().
+		Return(pr, nil)
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/go.yml", "chore/policy-bot").
+		Once().
+		Return(wrapContent("workflow content", "go"), "sha", nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+}
+
+func TestBuildGroupedPRBody(t *testing.T) {
+	repo := models.Repository{Name: "my-repo"}
+	docker := models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: "dockerfile"}, Action: models.PolicyActionCreate, TargetPath: ".github/workflows/dockerfile.yml"}
+	goCreate := models.PolicyDeviation{Repository: repo, Policy: models.PolicyWorkflow{Name: "go"}, Action: models.PolicyActionCreate, TargetPath: ".github/workflows/go.yml"}
+	goUpdate := goCreate
+	goUpdate.Action = models.PolicyActionUpdate
+	goUpdate.Comparison = models.ComparisonExact
+
+	body := buildGroupedPRBody("", docker)
+	body = buildGroupedPRBody(body, goCreate)
+	body = buildGroupedPRBody(body, goUpdate)
+
+	names, sections := groupedSections(body)
+	assert.Equal(t, []string{"dockerfile", "go"}, names)
+	assert.Equal(t, buildPolicySection(goUpdate), sections["go"])
+	assert.Equal(t, 1, strings.Count(body, "## Policy Bot Automated PR"))
+	assert.True(t, strings.HasSuffix(body, prFooter))
+}
+
+func TestIsPolicyBranch(t *testing.T) {
+	policies := []models.PolicyWorkflow{{Name: "dockerfile"}, {Name: "go"}}
+
+	assert.True(t, IsPolicyBranch("chore/go", policies))
+	assert.True(t, IsPolicyBranch("chore/policy-bot", policies))
+	assert.False(t, IsPolicyBranch("chore/node", policies))
+	assert.False(t, IsPolicyBranch("feature/go", policies))
+}
+
+func TestBuildPRBody_SourceChange(t *testing.T) {
+	svc := &remediationService{}
+	drift := models.PolicyDeviation{
+		Policy:     models.PolicyWorkflow{Name: "go"},
+		Action:     models.PolicyActionUpdate,
+		TargetPath: ".github/workflows/go.yml",
+		SourceChange: &models.SourceChange{
+			Repository: "tracker-tv/github-actions-ttv",
+			Base:       "6113728f27ae82c7b1a177c8d03f9e96e0adf246",
+			Head:       "main",
+		},
+	}
+
+	body := svc.buildPRBody(drift)
+
+	assert.Contains(t, body, "### Policy source change")
+	assert.Contains(t, body, "[tracker-tv/github-actions-ttv@6113728...main](https://github.com/tracker-tv/github-actions-ttv/compare/6113728f27ae82c7b1a177c8d03f9e96e0adf246...main)")
+	assert.NotContains(t, svc.buildPRBody(models.PolicyDeviation{Policy: drift.Policy}), "Policy source change")
+}
+
+func TestRemediate_ExistingPR_CosmeticDifference_Skip(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository:      models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:          models.PolicyWorkflow{Name: "go", Comparison: models.ComparisonNormalized},
+		Action:          models.PolicyActionUpdate,
+		TargetPath:      ".github/workflows/go.yml",
+		ExpectedContent: "on: push\n",
+		Comparison:      models.ComparisonNormalized,
+	}
+
+	mockClient.
+		EXPECT().
+		FindPullRequestByBranch(mock.Anything, "my-repo", "chore/go").
+		Once().
+		Return(&gh.PullRequest{Number: gh.Ptr(10), HTMLURL: gh.Ptr("https://github.com/org/my-repo/pull/10")}, nil)
+
+	// The branch was edited on Windows
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/workflows/go.yml", "chore/go").
+		Once().
+		Return(strings.ReplaceAll(wrapContent("on: push\n", "go"), "\n", "\r\n"), "existing-sha", nil)
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(ctx, drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+}
+
+func TestBuildPRBody_Comparison(t *testing.T) {
+	svc := &remediationService{}
+	drift := models.PolicyDeviation{
+		Policy:     models.PolicyWorkflow{Name: "go", Comparison: models.ComparisonSemantic},
+		Action:     models.PolicyActionUpdate,
+		TargetPath: ".github/workflows/go.yml",
+		Comparison: models.ComparisonSemantic,
+	}
+
+	body := svc.buildPRBody(drift)
+
+	assert.Contains(t, body, "**Target File:** .github/workflows/go.yml\n**Comparison:** semantic (the parsed documents differ)\n")
+	assert.NotContains(t, svc.buildPRBody(models.PolicyDeviation{Policy: drift.Policy, Action: models.PolicyActionCreate}), "Comparison")
+}
+
+func TestRemediate_Unpinned_Skipped(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+
+	drift := models.PolicyDeviation{
+		Repository:      models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Action:          models.PolicyActionUnpinned,
+		TargetPath:      ".github/workflows/release.yml",
+		UnpinnedActions: []string{"actions/checkout@v4"},
+	}
+
+	svc := NewRemediationService(mockClient)
+	result, err := svc.Remediate(context.Background(), drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+	assert.Empty(t, result.PRURL)
 }
@@ -38,6 +38,7 @@ func (s *repositoriesService) ListAll(ctx context.Context) ([]models.Repository,
 			FullName: repo.GetFullName(),
 			Private:  repo.GetPrivate(),
 			Archived: repo.GetArchived(),
+			Topics:   repo.Topics,
 		})
 	}
 
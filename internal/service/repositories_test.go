@@ -30,6 +30,7 @@ func TestListAll_Success(t *testing.T) {
 			FullName: gh.Ptr("org/repo1"),
 			Private:  gh.Ptr(false),
 			Archived: gh.Ptr(false),
+			Topics:   []string{"team-platform"},
 		},
 		{
 			Name:     gh.Ptr("repo2"),
@@ -54,6 +55,7 @@ func TestListAll_Success(t *testing.T) {
 	assert.Equal(t, "org/repo1", result[0].FullName)
 	assert.False(t, result[0].Private)
 	assert.False(t, result[0].Archived)
+	assert.Equal(t, []string{"team-platform"}, result[0].Topics)
 	assert.Equal(t, "repo2", result[1].Name)
 	assert.Equal(t, "org/repo2", result[1].FullName)
 	assert.True(t, result[1].Private)
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+	"gopkg.in/yaml.v3"
+)
+
+// repositoryConfig reads the repository configuration from the default branch.
+// A repository without configuration gets an empty one. An invalid configuration
+// is returned as an invalid-config deviation instead of an error.
+func (s *policyService) repositoryConfig(ctx context.Context, repo models.Repository, repoFiles []string) (models.RepositoryConfig, *models.PolicyDeviation, error) {
+	if !slices.Contains(repoFiles, models.RepositoryConfigPath) {
+		return models.RepositoryConfig{}, nil, nil
+	}
+
+	content, _, err := s.gh.GetFileContent(ctx, repo.Name, models.RepositoryConfigPath, "HEAD")
+	if err != nil {
+		return models.RepositoryConfig{}, nil, fmt.Errorf("reading %s: %w", models.RepositoryConfigPath, err)
+	}
+
+	cfg, err := parseRepositoryConfig([]byte(content))
+	if err == nil {
+		err = s.validateRepositoryConfig(cfg)
+	}
+	if err != nil {
+		return models.RepositoryConfig{}, &models.PolicyDeviation{
+			Repository:     repo,
+			Action:         models.PolicyActionInvalidConfig,
+			TargetPath:     models.RepositoryConfigPath,
+			CurrentContent: content,
+			Reason:         err.Error(),
+		}, nil
+	}
+
+	return cfg, nil, nil
+}
+
+func parseRepositoryConfig(data []byte) (models.RepositoryConfig, error) {
+	var cfg models.RepositoryConfig
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return models.RepositoryConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+func (s *policyService) validateRepositoryConfig(cfg models.RepositoryConfig) error {
+	var errs []error
+
+	switch cfg.Grouping {
+	case "", models.PRGroupingPerPolicy, models.PRGroupingSingle:
+	default:
+		errs = append(errs, fmt.Errorf("unknown grouping %q", cfg.Grouping))
+	}
+
+	names := make([]string, 0, len(cfg.Policies))
+	for name := range cfg.Policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		override := cfg.Policies[name]
+
+		i := slices.IndexFunc(s.workflows, func(wf models.PolicyWorkflow) bool { return wf.Name == name })
+		if i < 0 {
+			errs = append(errs, fmt.Errorf("policies.%s: unknown policy", name))
+			continue
+		}
+		policy := s.workflows[i]
+
+		if override.OptOut && strings.TrimSpace(override.Justification) == "" {
+			errs = append(errs, fmt.Errorf("policies.%s: opt_out requires a justification", name))
+		}
+		if len(override.Parameters) > 0 && !policy.Template {
+			errs = append(errs, fmt.Errorf("policies.%s: parameters require a templated policy", name))
+		}
+		if override.Version != "" {
+			if _, err := pinSource(policy.Source, override.Version); err != nil {
+				errs = append(errs, fmt.Errorf("policies.%s: %w", name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyRepositoryConfig returns policy with the repository configuration
+// applied, along with a description of every setting that changed it.
+func applyRepositoryConfig(policy models.PolicyWorkflow, cfg models.RepositoryConfig) (models.PolicyWorkflow, []string, error) {
+	override := cfg.Policies[policy.Name]
+	var overrides []string
+
+	if override.Version != "" {
+		source, err := pinSource(policy.Source, override.Version)
+		if err != nil {
+			return policy, nil, err
+		}
+		policy.Source = source
+		overrides = append(overrides, fmt.Sprintf("source pinned to `%s`", override.Version))
+	}
+
+	if len(override.Parameters) > 0 {
+		keys := make([]string, 0, len(override.Parameters))
+		for key := range override.Parameters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			overrides = append(overrides, fmt.Sprintf("parameter `%s` = `%s`", key, override.Parameters[key]))
+		}
+	}
+
+	reviewers := mergeUnique(cfg.Reviewers, override.Reviewers)
+	teamReviewers := mergeUnique(cfg.TeamReviewers, override.TeamReviewers)
+	if len(reviewers) > 0 || len(teamReviewers) > 0 {
+		policy.PullRequest = mergePullRequestOptions(policy.PullRequest, models.PullRequestOptions{
+			Reviewers:     reviewers,
+			TeamReviewers: teamReviewers,
+		})
+		overrides = append(overrides, fmt.Sprintf("reviewers: %s", strings.Join(append(reviewers, teamReviewers...), ", ")))
+	}
+
+	if cfg.Grouping == models.PRGroupingSingle {
+		overrides = append(overrides, "changes grouped in a single PR")
+	}
+
+	return policy, overrides, nil
+}
+
+// pinSource points a raw.githubusercontent.com source at ref instead of the
+// branch it tracks, e.g. /org/repo/refs/heads/main/wf.yml becomes /org/repo/<ref>/wf.yml.
+func pinSource(source, ref string) (string, error) {
+	src, err := parseRawSource(source)
+	if err != nil {
+		return "", fmt.Errorf("cannot pin the version of source %s", source)
+	}
+
+	src.url.Path = "/" + strings.Join([]string{src.owner, src.repo, ref, src.path}, "/")
+	return src.url.String(), nil
+}
+
+// rawSource is a policy source served by raw.githubusercontent.com.
+type rawSource struct {
+	url   *url.URL
+	owner string
+	repo  string
+	ref   string // e.g. main or refs/heads/main
+	path  string
+}
+
+func parseRawSource(source string) (rawSource, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return rawSource{}, err
+	}
+	if u.Host != "raw.githubusercontent.com" {
+		return rawSource{}, fmt.Errorf("source %s is not hosted on raw.githubusercontent.com", source)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	refLen := 1
+	if len(segments) > 2 && segments[2] == "refs" {
+		refLen = 3
+	}
+	if len(segments) < 3+refLen {
+		return rawSource{}, fmt.Errorf("source %s has no file path", source)
+	}
+
+	return rawSource{
+		url:   u,
+		owner: segments[0],
+		repo:  segments[1],
+		ref:   strings.Join(segments[2:2+refLen], "/"),
+		path:  strings.Join(segments[2+refLen:], "/"),
+	}, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+const pinnableSource = "https://raw.githubusercontent.com/org/actions/refs/heads/main/workflows/dockerfile.yml"
+
+func TestParseRepositoryConfig(t *testing.T) {
+	data := []byte(`
+grouping: single
+reviewers: [alice]
+policies:
+  dockerfile:
+    version: v1.2.0
+    parameters:
+      registry: ghcr.io
+  go:
+    opt_out: true
+    justification: built with bazel
+`)
+
+	cfg, err := parseRepositoryConfig(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PRGroupingSingle, cfg.Grouping)
+	assert.Equal(t, []string{"alice"}, cfg.Reviewers)
+	assert.Equal(t, "v1.2.0", cfg.Policies["dockerfile"].Version)
+	assert.Equal(t, map[string]string{"registry": "ghcr.io"}, cfg.Policies["dockerfile"].Parameters)
+	assert.True(t, cfg.Policies["go"].OptOut)
+}
+
+func TestParseRepositoryConfig_Empty(t *testing.T) {
+	cfg, err := parseRepositoryConfig([]byte(""))
+
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.Policies)
+}
+
+func TestParseRepositoryConfig_UnknownField(t *testing.T) {
+	_, err := parseRepositoryConfig([]byte("polices: {}\n"))
+
+	assert.Error(t, err)
+}
+
+func TestValidateRepositoryConfig(t *testing.T) {
+	svc := &policyService{workflows: []models.PolicyWorkflow{
+		{Name: "dockerfile", Source: pinnableSource, Template: true},
+		{Name: "go", Source: "http://example.com/go.yml"},
+	}}
+
+	err := svc.validateRepositoryConfig(models.RepositoryConfig{
+		Grouping: "weekly",
+		Policies: map[string]models.RepositoryPolicyConfig{
+			"dockerfile": {OptOut: true},
+			"go":         {Version: "v1", Parameters: map[string]string{"a": "b"}},
+			"python":     {},
+		},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown grouping "weekly"`)
+	assert.Contains(t, err.Error(), "policies.dockerfile: opt_out requires a justification")
+	assert.Contains(t, err.Error(), "policies.go: parameters require a templated policy")
+	assert.Contains(t, err.Error(), "policies.go: cannot pin the version of source")
+	assert.Contains(t, err.Error(), "policies.python: unknown policy")
+}
+
+func TestValidateRepositoryConfig_Valid(t *testing.T) {
+	svc := &policyService{workflows: []models.PolicyWorkflow{
+		{Name: "dockerfile", Source: pinnableSource, Template: true},
+	}}
+
+	err := svc.validateRepositoryConfig(models.RepositoryConfig{
+		Grouping: models.PRGroupingPerPolicy,
+		Policies: map[string]models.RepositoryPolicyConfig{
+			"dockerfile": {Version: "v1", Parameters: map[string]string{"a": "b"}, OptOut: true, Justification: "legacy"},
+		},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestRepositoryConfig_NoFile(t *testing.T) {
+	svc := &policyService{gh: githubMocks.NewMockClient(t)}
+
+	cfg, invalid, err := svc.repositoryConfig(context.Background(), models.Repository{Name: "my-repo"}, []string{"main.go"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, invalid)
+	assert.Empty(t, cfg)
+}
+
+func TestRepositoryConfig_Invalid(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/policy-bot.yml", "HEAD").
+		Once().
+		Return("grouping: [", "sha", nil)
+
+	svc := &policyService{gh: mockClient}
+	repo := models.Repository{Name: "my-repo"}
+
+	_, invalid, err := svc.repositoryConfig(context.Background(), repo, []string{".github/policy-bot.yml"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, invalid)
+	assert.Equal(t, models.PolicyActionInvalidConfig, invalid.Action)
+	assert.Equal(t, ".github/policy-bot.yml", invalid.TargetPath)
+	assert.Equal(t, repo, invalid.Repository)
+	assert.NotEmpty(t, invalid.Reason)
+}
+
+func TestRepositoryConfig_ReadError(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", ".github/policy-bot.yml", "HEAD").
+		Once().
+		Return("", "", errors.New("boom"))
+
+	svc := &policyService{gh: mockClient}
+
+	_, _, err := svc.repositoryConfig(context.Background(), models.Repository{Name: "my-repo"}, []string{".github/policy-bot.yml"})
+
+	assert.Error(t, err)
+}
+
+func TestApplyRepositoryConfig(t *testing.T) {
+	policy := models.PolicyWorkflow{
+		Name:        "dockerfile",
+		Source:      pinnableSource,
+		PullRequest: models.PullRequestOptions{Reviewers: []string{"bob"}},
+	}
+	cfg := models.RepositoryConfig{
+		Grouping:  models.PRGroupingSingle,
+		Reviewers: []string{"alice"},
+		Policies: map[string]models.RepositoryPolicyConfig{
+			"dockerfile": {
+				Version:       "v1.2.0",
+				Parameters:    map[string]string{"registry": "ghcr.io"},
+				TeamReviewers: []string{"org/platform"},
+			},
+		},
+	}
+
+	applied, overrides, err := applyRepositoryConfig(policy, cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://raw.githubusercontent.com/org/actions/v1.2.0/workflows/dockerfile.yml", applied.Source)
+	assert.Equal(t, []string{"bob", "alice"}, applied.PullRequest.Reviewers)
+	assert.Equal(t, []string{"org/platform"}, applied.PullRequest.TeamReviewers)
+	assert.Equal(t, []string{
+		"source pinned to `v1.2.0`",
+		"parameter `registry` = `ghcr.io`",
+		"reviewers: alice, org/platform",
+		"changes grouped in a single PR",
+	}, overrides)
+}
+
+func TestApplyRepositoryConfig_NoOverrides(t *testing.T) {
+	policy := models.PolicyWorkflow{Name: "dockerfile", Source: pinnableSource}
+
+	applied, overrides, err := applyRepositoryConfig(policy, models.RepositoryConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, policy, applied)
+	assert.Empty(t, overrides)
+}
+
+func TestPinSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{pinnableSource, "https://raw.githubusercontent.com/org/actions/v2/workflows/dockerfile.yml"},
+		{"https://raw.githubusercontent.com/org/actions/main/workflows/dockerfile.yml", "https://raw.githubusercontent.com/org/actions/v2/workflows/dockerfile.yml"},
+	}
+
+	for _, tt := range tests {
+		got, err := pinSource(tt.source, "v2")
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := pinSource("https://example.com/workflows/dockerfile.yml", "v2")
+	assert.Error(t, err)
+
+	_, err = pinSource("https://raw.githubusercontent.com/org/actions/main", "v2")
+	assert.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	gh "github.com/google/go-github/v80/github"
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// SettingsService enforces the settings policies, which describe the
+// repository itself rather than its files.
+type SettingsService interface {
+	// Ensure returns a deviation per settings policy the repository does not
+	// follow, among the policies matching its files.
+	Ensure(ctx context.Context, repo models.Repository, repoFiles []string) (Evaluation, error)
+	// Apply changes the settings of a deviation when its policy allows it,
+	// and only reports them otherwise.
+	Apply(ctx context.Context, drift models.PolicyDeviation) (*RemediationResult, error)
+}
+
+type settingsService struct {
+	policies   []models.PolicySettings
+	exemptions []models.Exemption
+	gh         github.Client
+	now        func() time.Time
+}
+
+// SettingsOption configures optional behaviour of the settings service.
+type SettingsOption func(*settingsService)
+
+// WithSettingsExemptions waives the deviations covered by an active exemption.
+func WithSettingsExemptions(exemptions []models.Exemption) SettingsOption {
+	return func(s *settingsService) {
+		s.exemptions = exemptions
+	}
+}
+
+func NewSettingsService(policies []models.PolicySettings, gh github.Client, opts ...SettingsOption) SettingsService {
+	s := &settingsService{policies: policies, gh: gh, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// repositorySetting maps a setting of the policies to the field of gh.Repository holding it.
+type repositorySetting struct {
+	name     string
+	expected func(models.RepositorySettings) *bool
+	field    func(*gh.Repository) **bool
+}
+
+var repositorySettings = []repositorySetting{
+	{"allow_merge_commit", func(s models.RepositorySettings) *bool { return s.AllowMergeCommit }, func(r *gh.Repository) **bool { return &r.AllowMergeCommit }},
+	{"allow_squash_merge", func(s models.RepositorySettings) *bool { return s.AllowSquashMerge }, func(r *gh.Repository) **bool { return &r.AllowSquashMerge }},
+	{"allow_rebase_merge", func(s models.RepositorySettings) *bool { return s.AllowRebaseMerge }, func(r *gh.Repository) **bool { return &r.AllowRebaseMerge }},
+	{"allow_auto_merge", func(s models.RepositorySettings) *bool { return s.AllowAutoMerge }, func(r *gh.Repository) **bool { return &r.AllowAutoMerge }},
+	{"delete_branch_on_merge", func(s models.RepositorySettings) *bool { return s.DeleteBranchOnMerge }, func(r *gh.Repository) **bool { return &r.DeleteBranchOnMerge }},
+	{"has_issues", func(s models.RepositorySettings) *bool { return s.HasIssues }, func(r *gh.Repository) **bool { return &r.HasIssues }},
+	{"has_wiki", func(s models.RepositorySettings) *bool { return s.HasWiki }, func(r *gh.Repository) **bool { return &r.HasWiki }},
+	{"has_projects", func(s models.RepositorySettings) *bool { return s.HasProjects }, func(r *gh.Repository) **bool { return &r.HasProjects }},
+}
+
+func (s *settingsService) Ensure(ctx context.Context, repo models.Repository, repoFiles []string) (Evaluation, error) {
+	var evaluation Evaluation
+	var policies []models.PolicySettings
+	for _, policy := range s.policies {
+		matched, err := matchesFiles(policy.MatchFile, repoFiles)
+		if err != nil {
+			return Evaluation{}, fmt.Errorf("matching %s: %w", policy.Name, err)
+		}
+		if matched {
+			policies = append(policies, policy)
+			evaluation.Applied = append(evaluation.Applied, policy.Name)
+		}
+	}
+	// The settings are only fetched for the repositories a policy applies to
+	if len(policies) == 0 {
+		return evaluation, nil
+	}
+
+	current, err := s.gh.GetRepository(ctx, repo.Name)
+	if err != nil {
+		return Evaluation{}, fmt.Errorf("getting repository settings: %w", err)
+	}
+
+	for _, policy := range policies {
+		changes := diffSettings(policy.Settings, current)
+		if len(changes) == 0 {
+			continue
+		}
+
+		deviation := models.PolicyDeviation{
+			Repository: repo,
+			Policy:     models.PolicyWorkflow{Name: policy.Name, Severity: policy.Severity},
+			Action:     models.PolicyActionSettings,
+			Settings:   changes,
+		}
+		if exemption := activeExemption(s.exemptions, repo, policy.Name, s.now()); exemption != nil {
+			deviation.Action = models.PolicyActionExempt
+			deviation.Exemption = exemption
+		}
+		logging.FromContext(ctx).Debug("settings deviation found", logging.Policy(policy.Name), logging.Action(string(deviation.Action)))
+		evaluation.Deviations = append(evaluation.Deviations, deviation)
+	}
+	return evaluation, nil
+}
+
+func (s *settingsService) Apply(ctx context.Context, drift models.PolicyDeviation) (*RemediationResult, error) {
+	if drift.Action != models.PolicyActionSettings {
+		return &RemediationResult{Drift: drift, Action: "skipped"}, nil
+	}
+
+	i := slices.IndexFunc(s.policies, func(p models.PolicySettings) bool { return p.Name == drift.Policy.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown settings policy %s", drift.Policy.Name)
+	}
+	if !s.policies[i].Apply {
+		return &RemediationResult{Drift: drift, Action: "reported"}, nil
+	}
+
+	// Only the settings that differ are sent, the others are left as is
+	edit := &gh.Repository{}
+	for _, change := range drift.Settings {
+		j := slices.IndexFunc(repositorySettings, func(rs repositorySetting) bool { return rs.name == change.Setting })
+		if j < 0 {
+			return nil, fmt.Errorf("unknown setting %s", change.Setting)
+		}
+		*repositorySettings[j].field(edit) = repositorySettings[j].expected(s.policies[i].Settings)
+	}
+
+	if _, err := s.gh.EditRepository(ctx, drift.Repository.Name, edit); err != nil {
+		return nil, fmt.Errorf("editing repository settings: %w", err)
+	}
+	return &RemediationResult{Drift: drift, Action: "updated"}, nil
+}
+
+// DescribeSettings lists changes on a line, e.g. "has_wiki: true -> false, has_projects: true -> false".
+func DescribeSettings(changes []models.SettingChange) string {
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, change.String())
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// diffSettings returns the settings of current that differ from expected.
+// A setting the API does not return, e.g. without admin access, is left out.
+func diffSettings(expected models.RepositorySettings, current *gh.Repository) []models.SettingChange {
+	var changes []models.SettingChange
+	for _, rs := range repositorySettings {
+		want := rs.expected(expected)
+		got := *rs.field(current)
+		if want == nil || got == nil || *want == *got {
+			continue
+		}
+		changes = append(changes, models.SettingChange{Setting: rs.name, Current: strconv.FormatBool(*got), Expected: strconv.FormatBool(*want)})
+	}
+	return changes
+}
+
+// matchesFiles reports whether a file matches pattern, an empty pattern
+// matching every repository.
+func matchesFiles(pattern string, files []string) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+	for _, file := range files {
+		matched, err := doublestar.Match(pattern, file)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func mergeSettingsPolicy(apply bool) models.PolicySettings {
+	return models.PolicySettings{
+		Name: "merge-settings",
+		Settings: models.RepositorySettings{
+			AllowMergeCommit:    gh.Ptr(false),
+			AllowSquashMerge:    gh.Ptr(true),
+			DeleteBranchOnMerge: gh.Ptr(true),
+			HasWiki:             gh.Ptr(false),
+		},
+		Apply:    apply,
+		Severity: models.SeverityError,
+	}
+}
+
+func TestSettingsEnsure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	repo := models.Repository{Name: "my-repo", FullName: "org/my-repo"}
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{
+		AllowMergeCommit:    gh.Ptr(true),
+		AllowSquashMerge:    gh.Ptr(true),
+		DeleteBranchOnMerge: gh.Ptr(false),
+		// has_wiki is not returned, it is left out of the diff
+	}, nil)
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, mockClient)
+	evaluation, err := svc.Ensure(ctx, repo, []string{"go.mod"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PolicyDeviation{{
+		Repository: repo,
+		Policy:     models.PolicyWorkflow{Name: "merge-settings", Severity: models.SeverityError},
+		Action:     models.PolicyActionSettings,
+		Settings: []models.SettingChange{
+			{Setting: "allow_merge_commit", Current: "true", Expected: "false"},
+			{Setting: "delete_branch_on_merge", Current: "false", Expected: "true"},
+		},
+	}}, evaluation.Deviations)
+	assert.Equal(t, []string{"merge-settings"}, evaluation.Applied)
+}
+
+func TestSettingsEnsure_Compliant(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{
+		AllowMergeCommit:    gh.Ptr(false),
+		AllowSquashMerge:    gh.Ptr(true),
+		DeleteBranchOnMerge: gh.Ptr(true),
+		HasWiki:             gh.Ptr(false),
+	}, nil)
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, mockClient)
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Equal(t, []string{"merge-settings"}, evaluation.Applied)
+}
+
+func TestSettingsEnsure_NoMatchingPolicy(t *testing.T) {
+	policy := mergeSettingsPolicy(true)
+	policy.MatchFile = "**/Dockerfile*"
+
+	// The settings are not fetched when no policy applies
+	svc := NewSettingsService([]models.PolicySettings{policy}, githubMocks.NewMockClient(t))
+	evaluation, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, []string{"go.mod"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, evaluation.Deviations)
+	assert.Empty(t, evaluation.Applied)
+}
+
+func TestSettingsEnsure_Exempt(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+	exemptions := []models.Exemption{
+		{Repository: "my-repo", Policy: "merge-settings", Reason: "wiki in use", Approver: "platform", Expires: time.Now().AddDate(0, 1, 0)},
+	}
+
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(&gh.Repository{HasWiki: gh.Ptr(true)}, nil)
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, mockClient, WithSettingsExemptions(exemptions))
+	evaluation, err := svc.Ensure(ctx, models.Repository{Name: "my-repo"}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Deviations, 1)
+	assert.Equal(t, models.PolicyActionExempt, evaluation.Deviations[0].Action)
+	assert.Equal(t, &exemptions[0], evaluation.Deviations[0].Exemption)
+	assert.Equal(t, []models.SettingChange{{Setting: "has_wiki", Current: "true", Expected: "false"}}, evaluation.Deviations[0].Settings)
+}
+
+func TestSettingsEnsure_Error(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.EXPECT().GetRepository(mock.Anything, "my-repo").Once().Return(nil, errors.New("403 Forbidden"))
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, mockClient)
+	_, err := svc.Ensure(context.Background(), models.Repository{Name: "my-repo"}, nil)
+
+	assert.EqualError(t, err, "getting repository settings: 403 Forbidden")
+}
+
+func settingsDeviation() models.PolicyDeviation {
+	return models.PolicyDeviation{
+		Repository: models.Repository{Name: "my-repo", FullName: "org/my-repo"},
+		Policy:     models.PolicyWorkflow{Name: "merge-settings"},
+		Action:     models.PolicyActionSettings,
+		Settings: []models.SettingChange{
+			{Setting: "allow_merge_commit", Current: "true", Expected: "false"},
+			{Setting: "has_wiki", Current: "true", Expected: "false"},
+		},
+	}
+}
+
+func TestSettingsApply(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.EXPECT().EditRepository(mock.Anything, "my-repo", &gh.Repository{
+		AllowMergeCommit: gh.Ptr(false),
+		HasWiki:          gh.Ptr(false),
+	}).Once().Return(&gh.Repository{}, nil)
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, mockClient)
+	result, err := svc.Apply(ctx, settingsDeviation())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", result.Action)
+	assert.Equal(t, settingsDeviation(), result.Drift)
+}
+
+func TestSettingsApply_ReportOnly(t *testing.T) {
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(false)}, githubMocks.NewMockClient(t))
+	result, err := svc.Apply(context.Background(), settingsDeviation())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "reported", result.Action)
+}
+
+func TestSettingsApply_Exempt(t *testing.T) {
+	drift := settingsDeviation()
+	drift.Action = models.PolicyActionExempt
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, githubMocks.NewMockClient(t))
+	result, err := svc.Apply(context.Background(), drift)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "skipped", result.Action)
+}
+
+func TestSettingsApply_Error(t *testing.T) {
+	mockClient := githubMocks.NewMockClient(t)
+	mockClient.EXPECT().EditRepository(mock.Anything, "my-repo", mock.Anything).Once().Return(nil, errors.New("422 Validation Failed"))
+
+	svc := NewSettingsService([]models.PolicySettings{mergeSettingsPolicy(true)}, mockClient)
+	_, err := svc.Apply(context.Background(), settingsDeviation())
+
+	assert.EqualError(t, err, "editing repository settings: 422 Validation Failed")
+}
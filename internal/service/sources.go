@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tracker-tv/github-policy-bots/internal/github"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+type SourceService interface {
+	ChangedPolicies(ctx context.Context, change models.SourceChange) ([]models.PolicyWorkflow, error)
+}
+
+type sourceService struct {
+	workflows []models.PolicyWorkflow
+	gh        github.Client
+	org       string
+}
+
+// NewSourceService finds the changed policies of workflows in the source
+// repositories of org, the organization of gh.
+func NewSourceService(workflows []models.PolicyWorkflow, gh github.Client, org string) SourceService {
+	return &sourceService{workflows: workflows, gh: gh, org: org}
+}
+
+// ChangedPolicies returns the policies whose source file was changed by change.
+// The source repository must belong to the organization of the client.
+func (s *sourceService) ChangedPolicies(ctx context.Context, change models.SourceChange) ([]models.PolicyWorkflow, error) {
+	owner, repo, ok := strings.Cut(change.Repository, "/")
+	if !ok {
+		return nil, fmt.Errorf("source repository %q is not a full name", change.Repository)
+	}
+	// The client compares commits in its organization, the same name elsewhere is another repository
+	if !strings.EqualFold(owner, s.org) {
+		return nil, fmt.Errorf("source repository %s does not belong to the organization %s", change.Repository, s.org)
+	}
+
+	files, err := s.gh.ChangedFiles(ctx, repo, change.Base, change.Head)
+	if err != nil {
+		return nil, fmt.Errorf("comparing %s: %w", change.Repository, err)
+	}
+
+	var changed []models.PolicyWorkflow
+	for _, policy := range s.workflows {
+		src, err := parseRawSource(policy.Source)
+		if err != nil {
+			continue
+		}
+		if !strings.EqualFold(src.owner+"/"+src.repo, change.Repository) {
+			continue
+		}
+		if slices.Contains(files, src.path) {
+			changed = append(changed, policy)
+		}
+	}
+	return changed, nil
+}
+
+// ParseSourceChange parses a change given as owner/repo@base..head or owner/repo@base...head.
+func ParseSourceChange(s string) (models.SourceChange, error) {
+	repo, refs, ok := strings.Cut(s, "@")
+	base, head, ok2 := strings.Cut(refs, "..")
+	head = strings.TrimPrefix(head, ".")
+	if !ok || !ok2 || !strings.Contains(repo, "/") || base == "" || head == "" {
+		return models.SourceChange{}, fmt.Errorf("invalid source change %q, expected owner/repo@base..head", s)
+	}
+	return models.SourceChange{Repository: repo, Base: base, Head: head}, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestChangedPolicies(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "dockerfile", Source: "https://raw.githubusercontent.com/org/actions/refs/heads/main/workflows/dockerfile.yml"},
+		{Name: "go", Source: "https://raw.githubusercontent.com/org/actions/main/workflows/go.yml"},
+		{Name: "node", Source: "https://raw.githubusercontent.com/org/actions/main/workflows/node.yml"},
+		{Name: "other", Source: "https://raw.githubusercontent.com/org/other/main/workflows/go.yml"},
+		{Name: "external", Source: "https://example.com/workflows/go.yml"},
+	}
+
+	mockClient.
+		EXPECT().
+		ChangedFiles(mock.Anything, "actions", "abc", "def").
+		Once().
+		Return([]string{"workflows/dockerfile.yml", "workflows/go.yml", "README.md"}, nil)
+
+	svc := NewSourceService(workflows, mockClient, "org")
+	changed, err := svc.ChangedPolicies(ctx, models.SourceChange{Repository: "org/actions", Base: "abc", Head: "def"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PolicyWorkflow{workflows[0], workflows[1]}, changed)
+}
+
+func TestChangedPolicies_CompareError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		ChangedFiles(mock.Anything, "actions", "abc", "def").
+		Once().
+		Return(nil, errors.New("not found"))
+
+	svc := NewSourceService(nil, mockClient, "org")
+	changed, err := svc.ChangedPolicies(ctx, models.SourceChange{Repository: "org/actions", Base: "abc", Head: "def"})
+
+	assert.EqualError(t, err, "comparing org/actions: not found")
+	assert.Nil(t, changed)
+}
+
+func TestChangedPolicies_OtherOwner(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	workflows := []models.PolicyWorkflow{
+		{Name: "go", Source: "https://raw.githubusercontent.com/fork/actions/main/workflows/go.yml"},
+	}
+
+	// Nothing is compared, actions of the organization is another repository
+	svc := NewSourceService(workflows, mockClient, "org")
+	changed, err := svc.ChangedPolicies(ctx, models.SourceChange{Repository: "fork/actions", Base: "abc", Head: "def"})
+
+	assert.EqualError(t, err, "source repository fork/actions does not belong to the organization org")
+	assert.Nil(t, changed)
+}
+
+func TestParseSourceChange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    models.SourceChange
+		wantErr bool
+	}{
+		{in: "org/actions@abc..def", want: models.SourceChange{Repository: "org/actions", Base: "abc", Head: "def"}},
+		{in: "org/actions@v1.2.0...main", want: models.SourceChange{Repository: "org/actions", Base: "v1.2.0", Head: "main"}},
+		{in: "actions@abc..def", wantErr: true},
+		{in: "org/actions@abc", wantErr: true},
+		{in: "org/actions@..def", wantErr: true},
+		{in: "org/actions", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSourceChange(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
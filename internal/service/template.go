@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// Template actions use [[ ]] so GitHub Actions expressions (${{ }}) in the
+// sources are left untouched.
+const (
+	templateLeftDelim  = "[["
+	templateRightDelim = "]]"
+)
+
+var goDirective = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"base": path.Base,
+	"dir":  path.Dir,
+}
+
+// templateData builds the variables available to templated policy sources:
+//
+//	.Repository    models.Repository of the repository being rendered
+//	.Policy        name of the policy
+//	.Files         every file of the repository
+//	.MatchedFiles  files matching the policy's match_file, e.g. Dockerfile paths
+//	.ImageName     container image name derived from the repository name
+//	.GoVersion     go directive of go.mod, only when go.mod exists
+//	.NodeVersion   content of .nvmrc, only when .nvmrc exists
+//	.Params        parameters set by the repository in .github/policy-bot.yml
+//
+// Optional variables are left out rather than empty, so a template relying on
+// them fails to render for repositories that do not provide them.
+func (s *policyService) templateData(ctx context.Context, repo models.Repository, repoFiles []string, policy models.PolicyWorkflow, params map[string]string) (map[string]any, error) {
+	matchedFiles, err := s.matchingFiles(repoFiles, policy.MatchFile)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{
+		"Repository":   repo,
+		"Policy":       policy.Name,
+		"Files":        repoFiles,
+		"MatchedFiles": matchedFiles,
+		"ImageName":    imageName(repo),
+		"Params":       params,
+	}
+
+	extractors := []struct {
+		file    string
+		key     string
+		extract func(string) string
+	}{
+		{file: "go.mod", key: "GoVersion", extract: extractGoVersion},
+		{file: ".nvmrc", key: "NodeVersion", extract: strings.TrimSpace},
+	}
+
+	for _, e := range extractors {
+		if !slices.Contains(repoFiles, e.file) {
+			continue
+		}
+		content, _, err := s.gh.GetFileContent(ctx, repo.Name, e.file, "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", e.file, err)
+		}
+		if value := e.extract(content); value != "" {
+			data[e.key] = value
+		}
+	}
+
+	return data, nil
+}
+
+func renderTemplate(name, source string, data map[string]any) (string, error) {
+	tmpl, err := parseTemplate(name, source)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func parseTemplate(name, source string) (*template.Template, error) {
+	return template.New(name).
+		Delims(templateLeftDelim, templateRightDelim).
+		Funcs(templateFuncs).
+		Option("missingkey=error").
+		Parse(source)
+}
+
+func extractGoVersion(gomod string) string {
+	match := goDirective.FindStringSubmatch(gomod)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+func imageName(repo models.Repository) string {
+	return strings.ToLower(repo.Name)
+}
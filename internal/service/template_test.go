@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	githubMocks "github.com/tracker-tv/github-policy-bots/internal/github/mocks"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	source := "image: [[ .ImageName ]]\nfiles: [[ join .MatchedFiles \",\" ]]\nref: ${{ github.ref }}\n"
+	data := map[string]any{
+		"ImageName":    "my-repo",
+		"MatchedFiles": []string{"Dockerfile", "api/Dockerfile"},
+	}
+
+	rendered, err := renderTemplate("dockerfile", source, data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image: my-repo\nfiles: Dockerfile,api/Dockerfile\nref: ${{ github.ref }}\n", rendered)
+}
+
+func TestRenderTemplate_MissingKey(t *testing.T) {
+	_, err := renderTemplate("go", "go-version: [[ .GoVersion ]]", map[string]any{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GoVersion")
+}
+
+func TestRenderTemplate_ParseError(t *testing.T) {
+	_, err := renderTemplate("go", "go-version: [[ .GoVersion ", map[string]any{})
+
+	assert.Error(t, err)
+}
+
+func TestExtractGoVersion(t *testing.T) {
+	assert.Equal(t, "1.25.4", extractGoVersion("module example.com/m\n\ngo 1.25.4\n\nrequire x v1.0.0\n"))
+	assert.Empty(t, extractGoVersion("module example.com/m\n"))
+}
+
+func TestTemplateData(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "My-Repo", "go.mod", "HEAD").
+		Once().
+		Return("module example.com/m\n\ngo 1.25.4\n", "sha", nil)
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "My-Repo", ".nvmrc", "HEAD").
+		Once().
+		Return("22\n", "sha", nil)
+
+	repo := models.Repository{Name: "My-Repo", FullName: "org/My-Repo"}
+	repoFiles := []string{"Dockerfile", "api/Dockerfile", "go.mod", ".nvmrc"}
+	policy := models.PolicyWorkflow{Name: "dockerfile", MatchFile: "**/Dockerfile*"}
+
+	svc := &policyService{gh: mockClient}
+	data, err := svc.templateData(ctx, repo, repoFiles, policy, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, repo, data["Repository"])
+	assert.Equal(t, "dockerfile", data["Policy"])
+	assert.Equal(t, []string{"Dockerfile", "api/Dockerfile"}, data["MatchedFiles"])
+	assert.Equal(t, "my-repo", data["ImageName"])
+	assert.Equal(t, "1.25.4", data["GoVersion"])
+	assert.Equal(t, "22", data["NodeVersion"])
+}
+
+func TestTemplateData_OptionalFilesMissing(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	svc := &policyService{gh: mockClient}
+	data, err := svc.templateData(ctx, models.Repository{Name: "my-repo"}, []string{"Dockerfile"}, models.PolicyWorkflow{MatchFile: "Dockerfile"}, nil)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, data, "GoVersion")
+	assert.NotContains(t, data, "NodeVersion")
+}
+
+func TestTemplateData_ReadError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := githubMocks.NewMockClient(t)
+
+	mockClient.
+		EXPECT().
+		GetFileContent(mock.Anything, "my-repo", "go.mod", "HEAD").
+		Once().
+		Return("", "", errors.New("boom"))
+
+	svc := &policyService{gh: mockClient}
+	_, err := svc.templateData(ctx, models.Repository{Name: "my-repo"}, []string{"go.mod"}, models.PolicyWorkflow{MatchFile: "go.mod"}, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reading go.mod")
+}
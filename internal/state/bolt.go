@@ -0,0 +1,169 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	runsBucket         = []byte("runs")
+	evaluationsBucket  = []byte("evaluations")
+	repositoriesBucket = []byte("repositories")
+)
+
+// boltStore keeps the history in a BoltDB file. Runs are keyed by their ID
+// and evaluations by run ID, so keys sort in run order. Every repository has
+// its own bucket of evaluations to read its history without a full scan.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens the store at path, creating it if needed.
+func OpenBolt(path string) (Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening state store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{runsBucket, evaluationsBucket, repositoriesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing state store: %w", err)
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) Record(_ context.Context, run Run, evaluations []Evaluation) (Run, error) {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		runs := tx.Bucket(runsBucket)
+		id, err := runs.NextSequence()
+		if err != nil {
+			return err
+		}
+		run.ID = id
+
+		if err := put(runs, itob(id), run); err != nil {
+			return err
+		}
+
+		for _, e := range evaluations {
+			e.RunID = id
+			key := evaluationKey(id, e.Repository, e.Policy)
+			if err := put(tx.Bucket(evaluationsBucket), key, e); err != nil {
+				return err
+			}
+
+			repo, err := tx.Bucket(repositoriesBucket).CreateBucketIfNotExists([]byte(e.Repository))
+			if err != nil {
+				return err
+			}
+			if err := put(repo, key, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Run{}, fmt.Errorf("recording run: %w", err)
+	}
+	return run, nil
+}
+
+func (s *boltStore) Runs(_ context.Context, since time.Time) ([]Run, error) {
+	var runs []Run
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).ForEach(func(_, v []byte) error {
+			var run Run
+			if err := json.Unmarshal(v, &run); err != nil {
+				return err
+			}
+			if !run.StartedAt.Before(since) {
+				runs = append(runs, run)
+			}
+			return nil
+		})
+	})
+	return runs, err
+}
+
+func (s *boltStore) Evaluations(ctx context.Context, since time.Time) ([]Evaluation, error) {
+	runs, err := s.Runs(ctx, since)
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+
+	var evaluations []Evaluation
+	err = s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(evaluationsBucket).Cursor()
+		for k, v := c.Seek(itob(runs[0].ID)); k != nil; k, v = c.Next() {
+			var e Evaluation
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			evaluations = append(evaluations, e)
+		}
+		return nil
+	})
+	return evaluations, err
+}
+
+func (s *boltStore) History(_ context.Context, repository string) ([]Evaluation, error) {
+	var evaluations []Evaluation
+	err := s.db.View(func(tx *bolt.Tx) error {
+		repo := tx.Bucket(repositoriesBucket).Bucket([]byte(repository))
+		if repo == nil {
+			return nil
+		}
+		return repo.ForEach(func(_, v []byte) error {
+			var e Evaluation
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			evaluations = append(evaluations, e)
+			return nil
+		})
+	})
+	return evaluations, err
+}
+
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+func put(b *bolt.Bucket, key []byte, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, data)
+}
+
+// itob encodes a run ID so that keys sort in numeric order.
+func itob(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return b
+}
+
+func evaluationKey(runID uint64, repository, policy string) []byte {
+	var key bytes.Buffer
+	key.Write(itob(runID))
+	key.WriteString(repository)
+	key.WriteByte(0)
+	key.WriteString(policy)
+	return key.Bytes()
+}
@@ -0,0 +1,66 @@
+package state
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 10, d, 6, 0, 0, 0, time.UTC)
+}
+
+func TestBoltStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	store, err := OpenBolt(path)
+	assert.NoError(t, err)
+
+	first, err := store.Record(ctx, Run{StartedAt: day(1), FinishedAt: day(1).Add(time.Minute)}, []Evaluation{
+		{EvaluatedAt: day(1), Repository: "org/api", Policy: "go", Status: report.StatusRemediated, PRURL: "pr/1"},
+		{EvaluatedAt: day(1), Repository: "org/web", Policy: "node", Status: report.StatusCompliant},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), first.ID)
+
+	second, err := store.Record(ctx, Run{StartedAt: day(2), FinishedAt: day(2).Add(time.Minute)}, []Evaluation{
+		{EvaluatedAt: day(2), Repository: "org/api", Policy: "go", Status: report.StatusCompliant},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), second.ID)
+	assert.NoError(t, store.Close())
+
+	// The history survives reopening the store
+	store, err = OpenBolt(path)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	runs, err := store.Runs(ctx, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, []uint64{runs[0].ID, runs[1].ID})
+
+	runs, err = store.Runs(ctx, day(2))
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+
+	evaluations, err := store.Evaluations(ctx, day(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []Evaluation{
+		{RunID: 2, EvaluatedAt: day(2), Repository: "org/api", Policy: "go", Status: report.StatusCompliant},
+	}, evaluations)
+
+	history, err := store.History(ctx, "org/api")
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "pr/1", history[0].PRURL)
+	assert.Equal(t, uint64(2), history[1].RunID)
+
+	history, err = store.History(ctx, "org/unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
@@ -0,0 +1,132 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+)
+
+// Drift is a repository out of compliance with a policy since its first
+// evaluation in that state. Exempt results count as compliant.
+type Drift struct {
+	Repository string
+	Policy     string
+	Status     report.Status // Status in the latest evaluation
+	Since      time.Time
+	PRURL      string // Latest pull request opened for the drift
+}
+
+// Age is how long the repository has been drifting at now.
+func (d Drift) Age(now time.Time) time.Duration {
+	return now.Sub(d.Since)
+}
+
+func inCompliance(status report.Status) bool {
+	return status == report.StatusCompliant || status == report.StatusExempt
+}
+
+// Drifts returns the repositories whose latest evaluation of a policy is out
+// of compliance, longest drifting first. evaluations must be oldest first.
+func Drifts(evaluations []Evaluation) []Drift {
+	type key struct{ repository, policy string }
+	current := make(map[key]*Drift)
+
+	for _, e := range evaluations {
+		k := key{e.Repository, e.Policy}
+		if inCompliance(e.Status) {
+			delete(current, k)
+			continue
+		}
+
+		d, ok := current[k]
+		if !ok {
+			d = &Drift{Repository: e.Repository, Policy: e.Policy, Since: e.EvaluatedAt}
+			current[k] = d
+		}
+		d.Status = e.Status
+		if e.PRURL != "" {
+			d.PRURL = e.PRURL
+		}
+	}
+
+	drifts := make([]Drift, 0, len(current))
+	for _, d := range current {
+		drifts = append(drifts, *d)
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		if !drifts[i].Since.Equal(drifts[j].Since) {
+			return drifts[i].Since.Before(drifts[j].Since)
+		}
+		if drifts[i].Repository != drifts[j].Repository {
+			return drifts[i].Repository < drifts[j].Repository
+		}
+		return drifts[i].Policy < drifts[j].Policy
+	})
+	return drifts
+}
+
+// Adoption is the share of repositories following a policy in a run.
+type Adoption struct {
+	RunID     uint64
+	StartedAt time.Time
+	Policy    string
+	Compliant int // Compliant or exempt
+	Total     int
+}
+
+// Rate is the fraction of repositories following the policy, 0 when none was evaluated.
+func (a Adoption) Rate() float64 {
+	if a.Total == 0 {
+		return 0
+	}
+	return float64(a.Compliant) / float64(a.Total)
+}
+
+// Adoptions returns the adoption of every policy in every run, in run order
+// and by policy name. Runs of a single repository are left out, they do not
+// measure the organization.
+func Adoptions(runs []Run) []Adoption {
+	var adoptions []Adoption
+	for _, run := range runs {
+		if run.Repository != "" {
+			continue
+		}
+
+		policies := make([]string, 0, len(run.Results))
+		for policy := range run.Results {
+			policies = append(policies, policy)
+		}
+		sort.Strings(policies)
+
+		for _, policy := range policies {
+			a := Adoption{RunID: run.ID, StartedAt: run.StartedAt, Policy: policy}
+			for status, count := range run.Results[policy] {
+				a.Total += count
+				if inCompliance(status) {
+					a.Compliant += count
+				}
+			}
+			adoptions = append(adoptions, a)
+		}
+	}
+	return adoptions
+}
+
+// PullRequestsOpened counts, per policy, the pull requests first seen at or
+// after since. evaluations must be oldest first and go back before since.
+// A pull request grouping several policies counts for the first one.
+func PullRequestsOpened(evaluations []Evaluation, since time.Time) map[string]int {
+	seen := make(map[string]bool)
+	opened := make(map[string]int)
+	for _, e := range evaluations {
+		if e.PRURL == "" || seen[e.PRURL] {
+			continue
+		}
+		seen[e.PRURL] = true
+		if !e.EvaluatedAt.Before(since) {
+			opened[e.Policy]++
+		}
+	}
+	return opened
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+)
+
+func TestDrifts(t *testing.T) {
+	evaluations := []Evaluation{
+		{EvaluatedAt: day(1), Repository: "org/api", Policy: "go", Status: report.StatusCompliant},
+		{EvaluatedAt: day(1), Repository: "org/web", Policy: "node", Status: report.StatusDrifted},
+		{EvaluatedAt: day(2), Repository: "org/api", Policy: "go", Status: report.StatusRemediated, PRURL: "pr/1"},
+		{EvaluatedAt: day(2), Repository: "org/web", Policy: "node", Status: report.StatusCompliant},
+		{EvaluatedAt: day(3), Repository: "org/api", Policy: "go", Status: report.StatusSkipped},
+		{EvaluatedAt: day(3), Repository: "org/cli", Policy: "go", Status: report.StatusErrored},
+		{EvaluatedAt: day(3), Repository: "org/old", Policy: "go", Status: report.StatusExempt},
+	}
+
+	drifts := Drifts(evaluations)
+
+	assert.Equal(t, []Drift{
+		{Repository: "org/api", Policy: "go", Status: report.StatusSkipped, Since: day(2), PRURL: "pr/1"},
+		{Repository: "org/cli", Policy: "go", Status: report.StatusErrored, Since: day(3)},
+	}, drifts)
+	assert.Equal(t, 48*time.Hour, drifts[0].Age(day(4)))
+}
+
+func TestAdoptions(t *testing.T) {
+	runs := []Run{
+		{ID: 1, StartedAt: day(1), Results: map[string]map[report.Status]int{
+			"node": {report.StatusCompliant: 1, report.StatusDrifted: 3},
+			"go":   {report.StatusCompliant: 2, report.StatusExempt: 1, report.StatusRemediated: 1},
+		}},
+		{ID: 2, StartedAt: day(2), Results: map[string]map[report.Status]int{
+			"node": {report.StatusCompliant: 4},
+		}},
+		// A single repository evaluated on a webhook
+		{ID: 3, StartedAt: day(3), Repository: "org/api", Results: map[string]map[report.Status]int{
+			"node": {report.StatusDrifted: 1},
+		}},
+	}
+
+	adoptions := Adoptions(runs)
+
+	assert.Equal(t, []Adoption{
+		{RunID: 1, StartedAt: day(1), Policy: "go", Compliant: 3, Total: 4},
+		{RunID: 1, StartedAt: day(1), Policy: "node", Compliant: 1, Total: 4},
+		{RunID: 2, StartedAt: day(2), Policy: "node", Compliant: 4, Total: 4},
+	}, adoptions)
+	assert.Equal(t, 0.75, adoptions[0].Rate())
+	assert.Zero(t, Adoption{}.Rate())
+}
+
+func TestPullRequestsOpened(t *testing.T) {
+	evaluations := []Evaluation{
+		{EvaluatedAt: day(1), Repository: "org/api", Policy: "go", PRURL: "pr/1"},
+		{EvaluatedAt: day(8), Repository: "org/api", Policy: "go", PRURL: "pr/1"},
+		{EvaluatedAt: day(8), Repository: "org/web", Policy: "node", PRURL: "pr/2"},
+		{EvaluatedAt: day(9), Repository: "org/web", Policy: "node", PRURL: "pr/2"},
+		{EvaluatedAt: day(9), Repository: "org/cli", Policy: "go", PRURL: "pr/3"},
+		{EvaluatedAt: day(9), Repository: "org/cli", Policy: "node"},
+	}
+
+	assert.Equal(t, map[string]int{"go": 1, "node": 1}, PullRequestsOpened(evaluations, day(7)))
+	assert.Equal(t, map[string]int{"go": 2, "node": 1}, PullRequestsOpened(evaluations, time.Time{}))
+}
@@ -0,0 +1,81 @@
+// Package state keeps the history of runs, so compliance can be followed
+// over time: since when a repository drifts from a policy, which pull
+// requests the bot opened and how policies are adopted.
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+)
+
+// Run summarizes a run of the bot.
+type Run struct {
+	ID         uint64                           `json:"id"`
+	StartedAt  time.Time                        `json:"started_at"`
+	FinishedAt time.Time                        `json:"finished_at"`
+	Results    map[string]map[report.Status]int `json:"results"`              // Repositories per policy and status
+	Repository string                           `json:"repository,omitempty"` // Full name of the only repository evaluated, e.g. on a webhook
+}
+
+// Evaluation is the result of a policy for a repository in a run.
+type Evaluation struct {
+	RunID       uint64        `json:"run_id"`
+	EvaluatedAt time.Time     `json:"evaluated_at"`
+	Repository  string        `json:"repository"` // Full name
+	Policy      string        `json:"policy"`
+	Status      report.Status `json:"status"`
+	Action      string        `json:"action,omitempty"`
+	PRURL       string        `json:"pr_url,omitempty"`
+	ContentHash string        `json:"content_hash,omitempty"` // SHA-256 of the content the policy expects
+}
+
+type Store interface {
+	// Record saves a run with its evaluations and returns it with its ID set.
+	Record(ctx context.Context, run Run, evaluations []Evaluation) (Run, error)
+	// Runs returns the runs started at or after since, oldest first.
+	Runs(ctx context.Context, since time.Time) ([]Run, error)
+	// Evaluations returns the evaluations of the runs started at or after since, oldest first.
+	Evaluations(ctx context.Context, since time.Time) ([]Evaluation, error)
+	// History returns the evaluations of a repository, by full name, oldest first.
+	History(ctx context.Context, repository string) ([]Evaluation, error)
+	Close() error
+}
+
+// FromReport returns the run and evaluations to record for r.
+func FromReport(r *report.Report) (Run, []Evaluation) {
+	run := Run{
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		Results:    make(map[string]map[report.Status]int),
+	}
+
+	var evaluations []Evaluation
+	for _, repo := range r.Repositories {
+		for _, p := range repo.Policies {
+			if run.Results[p.Name] == nil {
+				run.Results[p.Name] = make(map[report.Status]int)
+			}
+			run.Results[p.Name][p.Status]++
+
+			evaluation := Evaluation{
+				EvaluatedAt: r.StartedAt,
+				Repository:  repo.FullName,
+				Policy:      p.Name,
+				Status:      p.Status,
+				Action:      p.Action,
+				PRURL:       p.PRURL,
+			}
+			if p.Content != "" {
+				sum := sha256.Sum256([]byte(p.Content))
+				evaluation.ContentHash = hex.EncodeToString(sum[:])
+			}
+			evaluations = append(evaluations, evaluation)
+		}
+	}
+
+	return run, evaluations
+}
@@ -0,0 +1,41 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/internal/report"
+)
+
+func TestFromReport(t *testing.T) {
+	r := &report.Report{
+		StartedAt:  day(1),
+		FinishedAt: day(1).Add(time.Minute),
+		Repositories: []report.Repository{
+			{FullName: "org/api", Policies: []report.Policy{
+				{Name: "go", Status: report.StatusRemediated, Action: "update", PRURL: "pr/1", Content: "on: push\n"},
+				{Name: "node", Status: report.StatusCompliant},
+			}},
+			{FullName: "org/web", Policies: []report.Policy{
+				{Name: "node", Status: report.StatusCompliant},
+			}},
+		},
+	}
+
+	run, evaluations := FromReport(r)
+
+	assert.Equal(t, map[string]map[report.Status]int{
+		"go":   {report.StatusRemediated: 1},
+		"node": {report.StatusCompliant: 2},
+	}, run.Results)
+
+	sum := sha256.Sum256([]byte("on: push\n"))
+	assert.Equal(t, []Evaluation{
+		{EvaluatedAt: day(1), Repository: "org/api", Policy: "go", Status: report.StatusRemediated, Action: "update", PRURL: "pr/1", ContentHash: hex.EncodeToString(sum[:])},
+		{EvaluatedAt: day(1), Repository: "org/api", Policy: "node", Status: report.StatusCompliant},
+		{EvaluatedAt: day(1), Repository: "org/web", Policy: "node", Status: report.StatusCompliant},
+	}, evaluations)
+}
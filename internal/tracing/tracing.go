@@ -0,0 +1,92 @@
+// Package tracing sets up OpenTelemetry tracing and provides helpers to start
+// spans with the attributes shared across the bot. Spans are no-ops until
+// Setup installs an exporter.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/tracker-tv/github-policy-bots"
+	serviceName         = "github-policy-bots"
+)
+
+// Attribute keys shared by spans, mirroring the log attributes.
+const (
+	KeyRepo   = attribute.Key("policybot.repo")
+	KeyPolicy = attribute.Key("policybot.policy")
+	KeyAction = attribute.Key("policybot.action")
+	KeyPath   = attribute.Key("policybot.path")
+)
+
+func Repo(fullName string) attribute.KeyValue { return KeyRepo.String(fullName) }
+func Policy(name string) attribute.KeyValue   { return KeyPolicy.String(name) }
+func Action(action string) attribute.KeyValue { return KeyAction.String(action) }
+func Path(path string) attribute.KeyValue     { return KeyPath.String(path) }
+
+// Setup installs a global tracer provider exporting spans with exporter:
+// "otlp" (configured through the standard OTEL_EXPORTER_OTLP_* variables),
+// "stdout" (pretty-printed to w) or "" to disable tracing. The returned
+// function flushes pending spans and must be called before exiting.
+func Setup(ctx context.Context, exporter string, w io.Writer) (func(context.Context) error, error) {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+
+	switch exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		spanExporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s exporter: %w", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(spanExporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartClient starts a span for an outgoing request to a remote service.
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
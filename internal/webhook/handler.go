@@ -0,0 +1,95 @@
+// Package webhook receives GitHub webhooks and queues the repositories they
+// affect for evaluation.
+package webhook
+
+import (
+	"net/http"
+
+	gh "github.com/google/go-github/v80/github"
+	"github.com/tracker-tv/github-policy-bots/internal/logging"
+	"github.com/tracker-tv/github-policy-bots/internal/service"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+type Handler struct {
+	secret   []byte
+	queue    *Queue
+	policies []models.PolicyWorkflow
+}
+
+// NewHandler returns a handler verifying deliveries against secret and
+// queueing affected repositories. policies identify the bot's pull requests.
+func NewHandler(secret []byte, queue *Queue, policies []models.PolicyWorkflow) *Handler {
+	return &Handler{secret: secret, queue: queue, policies: policies}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context()).With("event", gh.WebHookType(r), "delivery", gh.DeliveryID(r))
+
+	payload, err := gh.ValidatePayload(r, h.secret)
+	if err != nil {
+		logger.Warn("rejected webhook", logging.Error(err))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := gh.ParseWebHook(gh.WebHookType(r), payload)
+	if err != nil {
+		logger.Warn("could not parse webhook", logging.Error(err))
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	repo, ok := h.affectedRepository(event)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h.queue.Add(repo) {
+		logger.Info("repository queued", logging.Repo(repo.FullName))
+	} else {
+		logger.Debug("repository already queued", logging.Repo(repo.FullName))
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// affectedRepository returns the repository to evaluate again after event, if any:
+// on a push to its default branch, when it is created, or when a bot pull request is closed.
+func (h *Handler) affectedRepository(event any) (models.Repository, bool) {
+	switch e := event.(type) {
+	case *gh.PushEvent:
+		repo := e.GetRepo()
+		if e.GetRef() != "refs/heads/"+repo.GetDefaultBranch() {
+			return models.Repository{}, false
+		}
+		return models.Repository{
+			Name:     repo.GetName(),
+			FullName: repo.GetFullName(),
+			Private:  repo.GetPrivate(),
+			Archived: repo.GetArchived(),
+			Topics:   repo.Topics,
+		}, true
+	case *gh.RepositoryEvent:
+		if e.GetAction() != "created" {
+			return models.Repository{}, false
+		}
+		return repository(e.GetRepo()), true
+	case *gh.PullRequestEvent:
+		if e.GetAction() != "closed" || !service.IsPolicyBranch(e.GetPullRequest().GetHead().GetRef(), h.policies) {
+			return models.Repository{}, false
+		}
+		return repository(e.GetRepo()), true
+	}
+	return models.Repository{}, false
+}
+
+func repository(repo *gh.Repository) models.Repository {
+	return models.Repository{
+		Name:     repo.GetName(),
+		FullName: repo.GetFullName(),
+		Private:  repo.GetPrivate(),
+		Archived: repo.GetArchived(),
+		Topics:   repo.Topics,
+	}
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+const secret = "webhook-secret"
+
+func delivery(t *testing.T, event, fixture, key string) *http.Request {
+	t.Helper()
+	payload, err := os.ReadFile(filepath.Join("testdata", fixture))
+	assert.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestHandler(t *testing.T) {
+	api := models.Repository{Name: "api", FullName: "tracker-tv/api", Private: true}
+	web := models.Repository{Name: "web", FullName: "tracker-tv/web"}
+
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		status  int
+		queued  []models.Repository
+	}{
+		{"push to default branch", "push", "push.json", http.StatusAccepted, []models.Repository{api}},
+		{"push to other branch", "push", "push_feature_branch.json", http.StatusNoContent, nil},
+		{"repository created", "repository", "repository_created.json", http.StatusAccepted, []models.Repository{web}},
+		{"repository renamed", "repository", "repository_renamed.json", http.StatusNoContent, nil},
+		{"bot pull request closed", "pull_request", "pull_request_closed.json", http.StatusAccepted, []models.Repository{api}},
+		{"other pull request closed", "pull_request", "pull_request_closed_feature.json", http.StatusNoContent, nil},
+		{"ping", "ping", "ping.json", http.StatusNoContent, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := NewQueue()
+			h := NewHandler([]byte(secret), queue, []models.PolicyWorkflow{{Name: "go"}})
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, delivery(t, tt.event, tt.fixture, secret))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.queued, drain(queue))
+		})
+	}
+}
+
+func TestHandler_InvalidSignature(t *testing.T) {
+	queue := NewQueue()
+	h := NewHandler([]byte(secret), queue, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, delivery(t, "push", "push.json", "wrong-secret"))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Zero(t, queue.Len())
+}
+
+func TestHandler_MissingSignature(t *testing.T) {
+	queue := NewQueue()
+	h := NewHandler([]byte(secret), queue, nil)
+
+	req := delivery(t, "push", "push.json", secret)
+	req.Header.Del("X-Hub-Signature-256")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Zero(t, queue.Len())
+}
+
+func TestHandler_DeduplicatesRepository(t *testing.T) {
+	queue := NewQueue()
+	h := NewHandler([]byte(secret), queue, []models.PolicyWorkflow{{Name: "go"}})
+
+	h.ServeHTTP(httptest.NewRecorder(), delivery(t, "push", "push.json", secret))
+	h.ServeHTTP(httptest.NewRecorder(), delivery(t, "pull_request", "pull_request_closed.json", secret))
+
+	assert.Equal(t, 1, queue.Len())
+}
+
+// drain returns the repositories waiting in q, in order.
+func drain(q *Queue) []models.Repository {
+	var repos []models.Repository
+	for q.Len() > 0 {
+		repo, _ := q.next()
+		q.done(repo.FullName)
+		repos = append(repos, repo)
+	}
+	return repos
+}
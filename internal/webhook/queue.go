@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+// Queue holds the repositories waiting to be evaluated. A repository queued
+// several times before a worker picks it up is evaluated once, and never by
+// two workers at the same time: an event received while it is being evaluated
+// queues it again for when the current evaluation finishes.
+type Queue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending map[string]models.Repository
+	order   []string
+	active  map[string]bool
+	closed  bool
+}
+
+func NewQueue() *Queue {
+	q := &Queue{
+		pending: make(map[string]models.Repository),
+		active:  make(map[string]bool),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Add queues repo and reports whether it was not already waiting.
+func (q *Queue) Add(repo models.Repository) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.pending[repo.FullName]; ok {
+		q.pending[repo.FullName] = repo
+		return false
+	}
+
+	q.pending[repo.FullName] = repo
+	q.order = append(q.order, repo.FullName)
+	q.cond.Signal()
+	return true
+}
+
+// Len returns the number of repositories waiting to be evaluated.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.order)
+}
+
+// Run calls process for queued repositories from workers goroutines until ctx
+// is done. Repositories still waiting at that point are dropped, the ones being
+// evaluated get up to grace to finish: the context given to process is only
+// cancelled grace after ctx.
+func (q *Queue) Run(ctx context.Context, workers int, grace time.Duration, process func(context.Context, models.Repository)) {
+	work, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		q.closed = true
+		q.cond.Broadcast()
+		q.mu.Unlock()
+		time.AfterFunc(grace, cancel)
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Go(func() {
+			for {
+				repo, ok := q.next()
+				if !ok {
+					return
+				}
+				process(work, repo)
+				q.done(repo.FullName)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// next blocks until a repository that is not being evaluated is waiting, or the queue is closed.
+func (q *Queue) next() (models.Repository, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if q.closed {
+			return models.Repository{}, false
+		}
+		for i, name := range q.order {
+			if q.active[name] {
+				continue
+			}
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			repo := q.pending[name]
+			delete(q.pending, name)
+			q.active[name] = true
+			return repo, true
+		}
+		q.cond.Wait()
+	}
+}
+
+func (q *Queue) done(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.active, name)
+	q.cond.Broadcast()
+}
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tracker-tv/github-policy-bots/models"
+)
+
+func TestQueue_Add_Deduplicates(t *testing.T) {
+	q := NewQueue()
+
+	assert.True(t, q.Add(models.Repository{Name: "api", FullName: "org/api"}))
+	assert.True(t, q.Add(models.Repository{Name: "web", FullName: "org/web"}))
+	assert.False(t, q.Add(models.Repository{Name: "api", FullName: "org/api", Archived: true}))
+
+	assert.Equal(t, []models.Repository{
+		{Name: "api", FullName: "org/api", Archived: true},
+		{Name: "web", FullName: "org/web"},
+	}, drain(q))
+}
+
+func TestQueue_Run_SerializesRepository(t *testing.T) {
+	q := NewQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan string)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var processed []string
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, 4, time.Second, func(ctx context.Context, repo models.Repository) {
+			started <- repo.FullName
+			<-release
+			mu.Lock()
+			processed = append(processed, repo.FullName)
+			mu.Unlock()
+		})
+	}()
+
+	q.Add(models.Repository{FullName: "org/api"})
+	assert.Equal(t, "org/api", <-started)
+
+	// Queued again while being evaluated: waits for the first evaluation
+	q.Add(models.Repository{FullName: "org/api"})
+	select {
+	case name := <-started:
+		t.Fatalf("%s evaluated concurrently", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release <- struct{}{}
+	assert.Equal(t, "org/api", <-started)
+	release <- struct{}{}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(processed) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestQueue_Run_StopsWithContext(t *testing.T) {
+	q := NewQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, 2, time.Second, func(context.Context, models.Repository) {})
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+func TestQueue_Run_FinishesInProgress(t *testing.T) {
+	q := NewQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var evaluationErr, drainedErr error
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, 1, 50*time.Millisecond, func(ctx context.Context, repo models.Repository) {
+			close(started)
+			<-release
+			evaluationErr = ctx.Err()
+			<-ctx.Done()
+			drainedErr = ctx.Err()
+		})
+	}()
+
+	q.Add(models.Repository{FullName: "org/api"})
+	q.Add(models.Repository{FullName: "org/web"})
+	<-started
+
+	// The evaluation in progress keeps a live context after the signal, until the grace period ends
+	cancel()
+	close(release)
+	<-done
+
+	assert.NoError(t, evaluationErr)
+	assert.ErrorIs(t, drainedErr, context.Canceled)
+	assert.Equal(t, 1, q.Len(), "org/web is dropped")
+}
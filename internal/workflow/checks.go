@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckNames returns the name of the check every job of a workflow reports on
+// pull requests: its name, or its ID when it has none. A workflow not
+// triggered by every pull_request or pull_request_target reports none. Jobs whose
+// check name is only known at run time are left out: names with expressions,
+// matrix jobs suffixed with their matrix values and reusable workflow calls
+// prefixed with the called jobs.
+func CheckNames(content string) ([]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("the workflow is not a mapping")
+	}
+
+	if !onPullRequest(value(doc.Content[0], "on")) {
+		return nil, nil
+	}
+
+	jobs := value(doc.Content[0], "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var names []string
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		id, job := jobs.Content[i].Value, jobs.Content[i+1]
+		if job.Kind != yaml.MappingNode || value(job, "uses") != nil {
+			continue
+		}
+		if strategy := value(job, "strategy"); strategy != nil && strategy.Kind == yaml.MappingNode && value(strategy, "matrix") != nil {
+			continue
+		}
+
+		name := id
+		if n := value(job, "name"); n != nil && n.Kind == yaml.ScalarNode && n.Value != "" {
+			name = n.Value
+		}
+		if strings.Contains(name, "${{") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// onPullRequest reports whether the triggers of a workflow, one event, a list
+// of them or a mapping of events to their filters, run it on every pull
+// request. A trigger filtered on branches or paths skips some, which would
+// wait forever on its checks.
+func onPullRequest(on *yaml.Node) bool {
+	if on == nil {
+		return false
+	}
+
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return isPullRequest(on.Value)
+	case yaml.SequenceNode:
+		return slices.ContainsFunc(on.Content, func(event *yaml.Node) bool { return isPullRequest(event.Value) })
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			if isPullRequest(on.Content[i].Value) && !filtered(on.Content[i+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isPullRequest(event string) bool {
+	return event == "pull_request" || event == "pull_request_target"
+}
+
+// filtered reports whether the filters of a trigger restrict it to some branches or paths.
+func filtered(filters *yaml.Node) bool {
+	if filters.Kind != yaml.MappingNode {
+		return false
+	}
+	for _, key := range []string{"branches", "branches-ignore", "paths", "paths-ignore"} {
+		if value(filters, key) != nil {
+			return true
+		}
+	}
+	return false
+}
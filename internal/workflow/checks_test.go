@@ -0,0 +1,74 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNames(t *testing.T) {
+	names, err := CheckNames(`on: pull_request
+jobs:
+  lint:
+    name: Lint
+    runs-on: ubuntu-latest
+    steps:
+      - run: make lint
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test
+  build:
+    name: Build ${{ github.ref_name }}
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Lint", "test"}, names)
+}
+
+func TestCheckNames_SkipsRuntimeNames(t *testing.T) {
+	// Reusable workflow calls and matrix jobs report checks named at run time
+	names, err := CheckNames(valid)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test"}, names)
+}
+
+func TestCheckNames_Triggers(t *testing.T) {
+	const jobs = "jobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make test\n"
+	tests := []struct {
+		name     string
+		on       string
+		expected []string
+	}{
+		{"event", "on: pull_request\n", []string{"test"}},
+		{"list", "on: [push, pull_request_target]\n", []string{"test"}},
+		{"mapping", "on:\n  push:\n  pull_request:\n    types: [opened, synchronize]\n", []string{"test"}},
+		// Filtered triggers skip some pull requests, which would wait forever on the checks
+		{"branches", "on:\n  pull_request:\n    branches: [main]\n", nil},
+		{"paths", "on:\n  pull_request:\n    paths: ['**.go']\n", nil},
+		{"paths-ignore", "on:\n  pull_request_target:\n    paths-ignore: [docs/**]\n", nil},
+		{"filtered and unfiltered", "on:\n  pull_request:\n    paths: ['**.go']\n  pull_request_target:\n", []string{"test"}},
+		// The checks of a workflow which does not run on pull requests never report on them
+		{"push only", "on: push\n", nil},
+		{"schedule", "on:\n  schedule:\n    - cron: '0 0 * * *'\n", nil},
+		{"missing", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := CheckNames(tt.on + jobs)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestCheckNames_Invalid(t *testing.T) {
+	_, err := CheckNames("- not\n- a workflow\n")
+
+	assert.EqualError(t, err, "the workflow is not a mapping")
+}
@@ -0,0 +1,397 @@
+// Package workflow validates GitHub Actions workflows before the bot commits
+// them, so a broken policy source fails the run instead of every repository,
+// and reads the checks they report.
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a single reason a workflow is invalid.
+type Problem struct {
+	Line    int // 1-based, 0 when the problem is not tied to a line
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// Errors is returned by Validate with every problem of a workflow.
+type Errors []Problem
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, p := range e {
+		messages = append(messages, p.String())
+	}
+	return "invalid workflow: " + strings.Join(messages, "; ")
+}
+
+// jobIDPattern is the syntax GitHub accepts for job IDs.
+var jobIDPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Validate checks that content is a GitHub Actions workflow: a YAML mapping
+// with triggers under "on" and at least one job, every job running steps on a
+// runner or calling a reusable workflow, "needs" referencing other jobs without
+// cycles, and well-formed ${{ }} expressions.
+func Validate(content string) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return Errors{{Message: err.Error()}}
+	}
+	if len(doc.Content) == 0 {
+		return Errors{{Message: "the workflow is empty"}}
+	}
+
+	v := &validator{}
+	v.workflow(doc.Content[0])
+	if len(v.problems) > 0 {
+		return v.problems
+	}
+	return nil
+}
+
+type validator struct {
+	problems Errors
+}
+
+func (v *validator) add(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Line: node.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) workflow(root *yaml.Node) {
+	if root.Kind != yaml.MappingNode {
+		v.add(root, "the workflow must be a mapping")
+		return
+	}
+	v.expressions(root)
+
+	if on := value(root, "on"); on == nil {
+		v.add(root, `missing "on"`)
+	} else if isEmpty(on) {
+		v.add(on, `"on" has no trigger`)
+	}
+
+	jobs := value(root, "jobs")
+	switch {
+	case jobs == nil:
+		v.add(root, `missing "jobs"`)
+	case jobs.Kind != yaml.MappingNode || len(jobs.Content) == 0:
+		v.add(jobs, `"jobs" must map job IDs to jobs`)
+	default:
+		v.jobs(jobs)
+	}
+}
+
+func (v *validator) jobs(jobs *yaml.Node) {
+	needs := make(map[string][]string)
+	var ids []string
+	for i := 0; i < len(jobs.Content); i += 2 {
+		key, job := jobs.Content[i], jobs.Content[i+1]
+		ids = append(ids, key.Value)
+		if !jobIDPattern.MatchString(key.Value) {
+			v.add(key, "job ID %q must start with a letter or _ and contain only letters, digits, - and _", key.Value)
+		}
+		if job.Kind != yaml.MappingNode {
+			v.add(job, "job %s must be a mapping", key.Value)
+			needs[key.Value] = nil
+			continue
+		}
+		v.job(key, job)
+		needs[key.Value] = v.needs(key.Value, job)
+	}
+
+	for _, id := range ids {
+		for _, need := range needs[id] {
+			if _, ok := needs[need]; !ok {
+				v.add(value(jobs, id), "job %s needs unknown job %s", id, need)
+			}
+		}
+	}
+	if cycle := findCycle(ids, needs); cycle != nil {
+		v.add(value(jobs, cycle[0]), "jobs need each other: %s", strings.Join(cycle, " -> "))
+	}
+}
+
+func (v *validator) job(key, job *yaml.Node) {
+	id := key.Value
+	runsOn, uses := value(job, "runs-on"), value(job, "uses")
+	switch {
+	case runsOn != nil && uses != nil:
+		v.add(key, `job %s has both "runs-on" and "uses"`, id)
+		return
+	case runsOn == nil && uses == nil:
+		v.add(key, `job %s needs "runs-on" or "uses"`, id)
+		return
+	case uses != nil:
+		if value(job, "steps") != nil {
+			v.add(key, `job %s calls a reusable workflow and cannot have "steps"`, id)
+		}
+		if uses.Kind != yaml.ScalarNode || uses.Value == "" {
+			v.add(uses, `job %s: "uses" must name a reusable workflow`, id)
+		}
+	}
+
+	if cond := value(job, "if"); cond != nil {
+		v.condition(cond)
+	}
+	if runsOn == nil {
+		return
+	}
+	if isEmpty(runsOn) {
+		v.add(runsOn, `job %s: "runs-on" is empty`, id)
+	}
+
+	steps := value(job, "steps")
+	if steps == nil || steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		v.add(key, `job %s needs a list of "steps"`, id)
+		return
+	}
+	for i, step := range steps.Content {
+		if step.Kind != yaml.MappingNode {
+			v.add(step, "job %s: step %d must be a mapping", id, i+1)
+			continue
+		}
+		uses, run := value(step, "uses"), value(step, "run")
+		switch {
+		case uses != nil && run != nil:
+			v.add(step, `job %s: step %d has both "uses" and "run"`, id, i+1)
+		case uses == nil && run == nil:
+			v.add(step, `job %s: step %d needs "uses" or "run"`, id, i+1)
+		}
+		if cond := value(step, "if"); cond != nil {
+			v.condition(cond)
+		}
+	}
+}
+
+// needs returns the jobs job needs, a single ID or a list of them.
+func (v *validator) needs(id string, job *yaml.Node) []string {
+	node := value(job, "needs")
+	if node == nil {
+		return nil
+	}
+
+	var ids []*yaml.Node
+	switch node.Kind {
+	case yaml.ScalarNode:
+		ids = []*yaml.Node{node}
+	case yaml.SequenceNode:
+		ids = node.Content
+	default:
+		v.add(node, `job %s: "needs" must be a job ID or a list of them`, id)
+		return nil
+	}
+
+	var needs []string
+	for _, n := range ids {
+		switch {
+		case n.Kind != yaml.ScalarNode:
+			v.add(n, `job %s: "needs" must be a job ID or a list of them`, id)
+		case n.Value == id:
+			v.add(n, "job %s needs itself", id)
+		default:
+			needs = append(needs, n.Value)
+		}
+	}
+	return needs
+}
+
+// condition checks an "if", which is an expression even without ${{ }}.
+func (v *validator) condition(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || strings.Contains(node.Value, "${{") {
+		return
+	}
+	if err := checkExpression(node.Value); err != nil {
+		v.add(node, "invalid expression %q: %v", node.Value, err)
+	}
+}
+
+// expressions checks every ${{ }} of node and its children.
+func (v *validator) expressions(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		for _, err := range scanExpressions(node.Value) {
+			v.add(node, "%v", err)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		v.expressions(child)
+	}
+}
+
+// scanExpressions checks the ${{ }} expressions embedded in s.
+func scanExpressions(s string) []error {
+	var errs []error
+	for {
+		start := strings.Index(s, "${{")
+		if start < 0 {
+			return errs
+		}
+		s = s[start+len("${{"):]
+
+		end := expressionEnd(s)
+		if end < 0 {
+			return append(errs, fmt.Errorf("unclosed expression, missing }}"))
+		}
+		expr := s[:end]
+		if err := checkExpression(expr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid expression ${{%s}}: %w", expr, err))
+		}
+		s = s[end+len("}}"):]
+	}
+}
+
+// expressionEnd returns the index of the }} closing the expression at the
+// start of s, ignoring the ones inside string literals, or -1.
+func expressionEnd(s string) int {
+	inString := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'':
+			inString = !inString // '' escapes a quote and toggles twice
+		case !inString && strings.HasPrefix(s[i:], "}}"):
+			return i
+		}
+	}
+	return -1
+}
+
+// checkExpression checks the tokens of a GitHub Actions expression: literals,
+// identifiers, property and index accesses, function calls and operators.
+func checkExpression(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("empty expression")
+	}
+
+	var open []byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		case c == '\'':
+			end := strings.IndexByte(expr[i+1:], '\'')
+			for end >= 0 && strings.HasPrefix(expr[i+1+end:], "''") {
+				next := strings.IndexByte(expr[i+1+end+2:], '\'')
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += 2 + next
+			}
+			if end < 0 {
+				return fmt.Errorf("unterminated string")
+			}
+			i += end + 1
+		case c == '(' || c == '[':
+			open = append(open, c)
+		case c == ')' || c == ']':
+			want := byte('(')
+			if c == ']' {
+				want = '['
+			}
+			if len(open) == 0 || open[len(open)-1] != want {
+				return fmt.Errorf("unexpected %q", c)
+			}
+			open = open[:len(open)-1]
+		case c == '&' || c == '|':
+			if i+1 >= len(expr) || expr[i+1] != c {
+				return fmt.Errorf("unexpected %q, use %c%c", c, c, c)
+			}
+			i++
+		case c == '=':
+			if i+1 >= len(expr) || expr[i+1] != '=' {
+				return fmt.Errorf("unexpected '=', use ==")
+			}
+			i++
+		case c == '!' || c == '<' || c == '>':
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				i++
+			}
+		case c == '.' || c == ',' || c == '*' || c == '_' || c == '-' || c == '+':
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		default:
+			return fmt.Errorf("unexpected %q", c)
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("unclosed %q", open[len(open)-1])
+	}
+	return nil
+}
+
+// findCycle returns the first cycle of jobs needing each other, or nil.
+func findCycle(ids []string, needs map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+		for _, need := range needs[id] {
+			switch state[need] {
+			case visiting:
+				for i, p := range path {
+					if p == need {
+						return append(append([]string{}, path[i:]...), need)
+					}
+				}
+			case unvisited:
+				if _, ok := needs[need]; !ok {
+					continue
+				}
+				if cycle := visit(need); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+
+	for _, id := range ids {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// value returns the value of key in mapping, or nil.
+func value(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// isEmpty reports whether node is null, an empty string or an empty collection.
+func isEmpty(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Tag == "!!null" || node.Value == ""
+	case yaml.AliasNode:
+		return false
+	default:
+		return len(node.Content) == 0
+	}
+}
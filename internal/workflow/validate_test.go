@@ -0,0 +1,114 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const valid = `name: go
+on:
+  push:
+    branches: [main]
+  pull_request:
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: go test ./...
+        if: github.event_name == 'push' && !cancelled()
+  release:
+    needs: [test]
+    if: ${{ startsWith(github.ref, 'refs/tags/') }}
+    uses: tracker-tv/github-actions-ttv/.github/workflows/release.yml@main
+    secrets: inherit
+  notify:
+    needs: release
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest]
+    steps:
+      - run: echo "${{ needs.release.outputs['version'] }} ${{ format('{0}}}', 'x') }}"
+`
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(valid))
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty", content: "", want: "the workflow is empty"},
+		{name: "not yaml", content: "on: [push\n", want: "yaml:"},
+		{name: "not a mapping", content: "- on\n", want: "line 1: the workflow must be a mapping"},
+		{name: "missing on", content: "jobs:\n  a:\n    runs-on: x\n    steps: [{run: a}]\n", want: `line 1: missing "on"`},
+		{name: "empty on", content: "on:\njobs:\n  a:\n    runs-on: x\n    steps: [{run: a}]\n", want: `"on" has no trigger`},
+		{name: "missing jobs", content: "on: push\n", want: `line 1: missing "jobs"`},
+		{name: "no job", content: "on: push\njobs: {}\n", want: `line 2: "jobs" must map job IDs to jobs`},
+		{name: "invalid job ID", content: "on: push\njobs:\n  1st:\n    runs-on: x\n    steps: [{run: a}]\n", want: `line 3: job ID "1st" must start with a letter`},
+		{name: "job not a mapping", content: "on: push\njobs:\n  a: x\n", want: "line 3: job a must be a mapping"},
+		{name: "no runner", content: "on: push\njobs:\n  a:\n    steps: [{run: a}]\n", want: `line 3: job a needs "runs-on" or "uses"`},
+		{name: "runner and reusable workflow", content: "on: push\njobs:\n  a:\n    runs-on: x\n    uses: o/r/.github/workflows/w.yml@main\n", want: `job a has both "runs-on" and "uses"`},
+		{name: "reusable workflow with steps", content: "on: push\njobs:\n  a:\n    uses: o/r/.github/workflows/w.yml@main\n    steps: [{run: a}]\n", want: `job a calls a reusable workflow and cannot have "steps"`},
+		{name: "empty runner", content: "on: push\njobs:\n  a:\n    runs-on: ''\n    steps: [{run: a}]\n", want: `job a: "runs-on" is empty`},
+		{name: "no steps", content: "on: push\njobs:\n  a:\n    runs-on: x\n", want: `job a needs a list of "steps"`},
+		{name: "empty step", content: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - name: a\n", want: `line 6: job a: step 1 needs "uses" or "run"`},
+		{name: "step with uses and run", content: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - {uses: a, run: b}\n", want: `job a: step 1 has both "uses" and "run"`},
+		{name: "unknown need", content: "on: push\njobs:\n  a:\n    needs: [b]\n    runs-on: x\n    steps: [{run: a}]\n", want: "line 4: job a needs unknown job b"},
+		{name: "needs itself", content: "on: push\njobs:\n  a:\n    needs: a\n    runs-on: x\n    steps: [{run: a}]\n", want: "line 4: job a needs itself"},
+		{name: "invalid needs", content: "on: push\njobs:\n  a:\n    needs: {b: c}\n    runs-on: x\n    steps: [{run: a}]\n", want: `job a: "needs" must be a job ID or a list of them`},
+		{
+			name:    "cycle",
+			content: "on: push\njobs:\n  a:\n    needs: c\n    runs-on: x\n    steps: [{run: a}]\n  b:\n    needs: a\n    runs-on: x\n    steps: [{run: a}]\n  c:\n    needs: b\n    runs-on: x\n    steps: [{run: a}]\n",
+			want:    "line 4: jobs need each other: a -> c -> b -> a",
+		},
+		{name: "unclosed expression", content: "on: push\njobs:\n  a:\n    runs-on: x\n    steps: [{run: 'echo ${{ github.sha }'}]\n", want: "line 5: unclosed expression, missing }}"},
+		{name: "empty expression", content: "on: push\njobs:\n  a:\n    runs-on: ${{ }}\n    steps: [{run: a}]\n", want: "invalid expression ${{ }}: empty expression"},
+		{name: "unbalanced parens", content: "on: push\njobs:\n  a:\n    runs-on: ${{ fromJSON(inputs.os }}\n    steps: [{run: a}]\n", want: `unclosed '('`},
+		{name: "unterminated string", content: "on: push\njobs:\n  a:\n    runs-on: x\n    steps: [{run: \"${{ format('a) }}\"}]\n", want: "unclosed expression"},
+		{name: "double quotes", content: "on: push\njobs:\n  a:\n    runs-on: ${{ \"x\" }}\n    steps: [{run: a}]\n", want: `unexpected '"'`},
+		{name: "assignment", content: "on: push\njobs:\n  a:\n    runs-on: x\n    if: github.ref = 'main'\n    steps: [{run: a}]\n", want: `line 5: invalid expression "github.ref = 'main'": unexpected '=', use ==`},
+		{name: "single ampersand", content: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - run: a\n        if: success() & always()\n", want: "line 7: invalid expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.content)
+
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	err := Validate("on: push\njobs:\n  a:\n    runs-on: x\n  b:\n    needs: c\n    runs-on: x\n    steps: [{run: a}]\n")
+
+	var problems Errors
+	if !assert.True(t, errors.As(err, &problems)) {
+		return
+	}
+	assert.Equal(t, Errors{
+		{Line: 3, Message: `job a needs a list of "steps"`},
+		{Line: 6, Message: "job b needs unknown job c"},
+	}, problems)
+	assert.EqualError(t, err, `invalid workflow: line 3: job a needs a list of "steps"; line 6: job b needs unknown job c`)
+}
+
+func TestCheckExpression(t *testing.T) {
+	for _, expr := range []string{
+		"github.event.pull_request.labels.*.name",
+		"contains(github.event.pull_request.labels.*.name, 'it''s ok')",
+		"matrix.os != 'windows-latest' || inputs.force",
+		"steps.build.outputs['image-tag'] >= 1.5e3",
+		"!(github.event_name == 'schedule')",
+	} {
+		assert.NoError(t, checkExpression(expr), expr)
+	}
+	assert.EqualError(t, checkExpression("fromJSON(x]"), `unexpected ']'`)
+	assert.EqualError(t, checkExpression("'it''s"), "unterminated string")
+}
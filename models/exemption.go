@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Exemption waives a policy for a repository until it expires.
+type Exemption struct {
+	Repository string
+	Policy     string
+	Reason     string
+	Approver   string    // Who granted the exemption
+	Expires    time.Time // Last day the exemption applies
+}
@@ -0,0 +1,15 @@
+package models
+
+// ActionUsage is an action or reusable workflow at a version, and the workflows using it.
+type ActionUsage struct {
+	Action  string // owner/repo, followed by the path of the action or workflow in the repository
+	Version string // Ref after the @: a tag, a branch or a commit SHA
+	Tag     string // Version in the comment of a ref pinned to a commit SHA, e.g. "v4"
+	Uses    []ActionUse
+}
+
+// ActionUse is a workflow using an action.
+type ActionUse struct {
+	Repository string // Full name of the repository
+	Path       string // e.g. ".github/workflows/ci.yml"
+}
@@ -3,21 +3,105 @@ package models
 type PolicyAction string
 
 const (
-	PolicyActionCreate PolicyAction = "create"
-	PolicyActionUpdate PolicyAction = "update"
+	PolicyActionCreate  PolicyAction = "create"
+	PolicyActionUpdate  PolicyAction = "update"
+	PolicyActionDelete  PolicyAction = "delete"
+	PolicyActionMigrate PolicyAction = "migrate"
+
+	// PolicyActionInvalidConfig reports a repository configuration the bot cannot apply.
+	// It is not remediated by a PR, the repository owners have to fix it.
+	PolicyActionInvalidConfig PolicyAction = "invalid-config"
+
+	// PolicyActionExempt reports a deviation waived by an active exemption.
+	PolicyActionExempt PolicyAction = "exempt"
+
+	// PolicyActionUnpinned reports third-party actions referenced by tag or
+	// branch in a workflow no policy manages. It is only reported, the bot
+	// does not change workflows it does not own.
+	PolicyActionUnpinned PolicyAction = "unpinned"
+
+	// PolicyActionSettings reports repository settings that differ from a
+	// settings policy. It is applied through the API rather than a PR.
+	PolicyActionSettings PolicyAction = "settings"
+
+	// PolicyActionProtection reports a default branch protected less than a
+	// protection policy requires. It is applied through the API rather than a PR.
+	PolicyActionProtection PolicyAction = "protection"
+)
+
+type PolicyEnsure string
+
+const (
+	PolicyEnsurePresent PolicyEnsure = "present"
+	PolicyEnsureAbsent  PolicyEnsure = "absent"
+)
+
+type MergeMethod string
+
+const (
+	MergeMethodMerge  MergeMethod = "merge"
+	MergeMethodSquash MergeMethod = "squash"
+	MergeMethodRebase MergeMethod = "rebase"
+)
+
+// Severity of a deviation, as reported to code scanning.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Comparison is how the workflow of a repository is compared with the one a policy expects.
+type Comparison string
+
+const (
+	ComparisonExact      Comparison = "exact"      // Byte for byte
+	ComparisonNormalized Comparison = "normalized" // Ignoring line endings, trailing whitespace and trailing blank lines
+	ComparisonSemantic   Comparison = "semantic"   // Comparing the parsed YAML or JSON documents
 )
 
 type PolicyWorkflow struct {
-	Name      string `json:"name"`
-	MatchFile string `json:"match_file"`
-	Source    string `json:"source"`
+	Name          string             `json:"name"`
+	MatchFile     string             `json:"match_file"`
+	Source        string             `json:"source"`
+	PullRequest   PullRequestOptions `json:"pull_request,omitempty"`
+	AutoMerge     MergeMethod        `json:"auto_merge,omitempty"`      // Enables auto-merge with this method on the PRs of the policy
+	Ensure        PolicyEnsure       `json:"ensure,omitempty"`          // "present" (default) or "absent" to remove the workflow
+	OnlyIfManaged bool               `json:"only_if_managed,omitempty"` // With "absent", only remove files carrying the bot's DO-NOT-EDIT header
+	PreviousNames []string           `json:"previous_names,omitempty"`  // Former policy names whose workflows are moved to the current path
+	Template      bool               `json:"template,omitempty"`        // Render the source as a text/template with per-repository variables
+	Severity      Severity           `json:"severity,omitempty"`        // Severity of a deviation, "warning" when empty
+	Comparison    Comparison         `json:"comparison,omitempty"`      // How the workflow is compared, "exact" when empty
+}
+
+// PullRequestOptions describes the metadata applied to pull requests opened by the bot.
+type PullRequestOptions struct {
+	Labels                  []string `json:"labels,omitempty"`
+	Assignees               []string `json:"assignees,omitempty"`
+	Reviewers               []string `json:"reviewers,omitempty"`
+	TeamReviewers           []string `json:"team_reviewers,omitempty"`
+	Draft                   bool     `json:"draft,omitempty"`
+	ReviewersFromCodeowners bool     `json:"reviewers_from_codeowners,omitempty"`
 }
 
 type PolicyDeviation struct {
-	Repository     Repository
-	Policy         PolicyWorkflow
-	Action         PolicyAction
-	TargetPath     string // e.g., ".github/workflows/dockerfile.yml"
-	ExpectedSource string // URL to fetch expected content
-	CurrentContent string // Current content (empty for create)
+	Repository      Repository
+	Policy          PolicyWorkflow // Only the name and severity of a settings or protection policy
+	Action          PolicyAction
+	TargetPath      string   // e.g., ".github/workflows/dockerfile.yml"
+	ExpectedSource  string   // URL to fetch expected content
+	ExpectedContent string   // Source content rendered for the repository, when already computed by Ensure
+	CurrentContent  string   // Current content (empty for create)
+	PreviousPaths   []string // Workflows of former policy names to remove (migrate only)
+	Overrides       []string // Settings of the repository configuration applied to the policy
+	Grouping        PRGrouping
+	Comparison      Comparison        // Comparison that found the workflow differs (update and migrate only)
+	Reason          string            // Why the repository configuration is invalid (invalid-config only)
+	Exemption       *Exemption        // Exemption waiving the deviation (exempt only)
+	UnpinnedActions []string          // References not pinned to a commit SHA, e.g. "actions/checkout@v4" (unpinned only)
+	Settings        []SettingChange   // Settings that differ from the policy (settings and protection, or exempt from them)
+	Protection      *BranchProtection // Protection the default branch is expected to have (protection only)
+	SourceChange    *SourceChange     // Change to the policy sources that triggered the run, if any
 }
@@ -0,0 +1,34 @@
+package models
+
+// ProtectionTarget is how a protection policy protects the default branch.
+type ProtectionTarget string
+
+const (
+	ProtectionTargetBranch  ProtectionTarget = "branch-protection" // Classic branch protection
+	ProtectionTargetRuleset ProtectionTarget = "ruleset"           // Repository ruleset named after the policy
+)
+
+// PolicyProtection declares the minimum protection of the default branch of
+// the repositories a policy applies to. Stricter protections are compliant.
+type PolicyProtection struct {
+	Name              string           `json:"name"`
+	MatchFile         string           `json:"match_file,omitempty"`         // Only repositories with a matching file, every repository when empty
+	Target            ProtectionTarget `json:"target,omitempty"`             // "branch-protection" (default) or "ruleset"
+	RequiredApprovals int              `json:"required_approvals,omitempty"` // Approving reviews required on PRs, 1 to 6, none when 0
+	RequiredChecks    []string         `json:"required_checks,omitempty"`    // Status checks required on PRs
+	ManagedChecks     bool             `json:"managed_checks,omitempty"`     // Also require the jobs of the workflows the policies manage in the repository
+	Enforcement       string           `json:"enforcement,omitempty"`        // Enforcement of a ruleset, "active" (default) or "evaluate"
+	LinearHistory     bool             `json:"linear_history,omitempty"`     // Forbid merge commits
+	EnforceAdmins     bool             `json:"enforce_admins,omitempty"`     // Apply the protection to administrators, with no bypass
+	Apply             bool             `json:"apply,omitempty"`              // Change the protection, only report the drift when false
+	Severity          Severity         `json:"severity,omitempty"`           // Severity of a deviation, "warning" when empty
+}
+
+// BranchProtection is the protection of a branch, as far as protection policies describe it.
+type BranchProtection struct {
+	Branch            string
+	RequiredApprovals int
+	RequiredChecks    []string
+	LinearHistory     bool
+	EnforceAdmins     bool
+}
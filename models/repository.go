@@ -5,4 +5,5 @@ type Repository struct {
 	FullName string
 	Private  bool
 	Archived bool
+	Topics   []string
 }
@@ -0,0 +1,28 @@
+package models
+
+// RepositoryConfigPath is where a repository keeps its configuration of the bot.
+const RepositoryConfigPath = ".github/policy-bot.yml"
+
+type PRGrouping string
+
+const (
+	PRGroupingPerPolicy PRGrouping = "per-policy" // One PR per policy (default)
+	PRGroupingSingle    PRGrouping = "single"     // One PR for every policy of the repository
+)
+
+// RepositoryConfig is the optional configuration a repository gives the bot in RepositoryConfigPath.
+type RepositoryConfig struct {
+	Grouping      PRGrouping                        `yaml:"grouping,omitempty"`
+	Reviewers     []string                          `yaml:"reviewers,omitempty"`      // Added to every PR opened in the repository
+	TeamReviewers []string                          `yaml:"team_reviewers,omitempty"` // Added to every PR opened in the repository
+	Policies      map[string]RepositoryPolicyConfig `yaml:"policies,omitempty"`       // Keyed by policy name
+}
+
+type RepositoryPolicyConfig struct {
+	OptOut        bool              `yaml:"opt_out,omitempty"`        // Do not enforce the policy in this repository
+	Justification string            `yaml:"justification,omitempty"`  // Why the repository opts out, required with opt_out
+	Parameters    map[string]string `yaml:"parameters,omitempty"`     // Exposed as .Params to templated sources
+	Version       string            `yaml:"version,omitempty"`        // Git ref the policy source is pinned to
+	Reviewers     []string          `yaml:"reviewers,omitempty"`      // Added to the policy's PRs
+	TeamReviewers []string          `yaml:"team_reviewers,omitempty"` // Added to the policy's PRs
+}
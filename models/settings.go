@@ -0,0 +1,38 @@
+package models
+
+import "fmt"
+
+// PolicySettings declares the settings a repository is expected to have,
+// next to the workflows of PolicyWorkflow.
+type PolicySettings struct {
+	Name      string             `json:"name"`
+	MatchFile string             `json:"match_file,omitempty"` // Only repositories with a matching file, every repository when empty
+	Settings  RepositorySettings `json:"settings"`
+	Apply     bool               `json:"apply,omitempty"`    // Change the repository settings, only report the drift when false
+	Severity  Severity           `json:"severity,omitempty"` // Severity of a deviation, "warning" when empty
+}
+
+// RepositorySettings are the settings of a repository a policy can enforce,
+// named after the GitHub API. A nil setting is left as is.
+type RepositorySettings struct {
+	AllowMergeCommit    *bool `json:"allow_merge_commit,omitempty"`
+	AllowSquashMerge    *bool `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge    *bool `json:"allow_rebase_merge,omitempty"`
+	AllowAutoMerge      *bool `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge *bool `json:"delete_branch_on_merge,omitempty"`
+	HasIssues           *bool `json:"has_issues,omitempty"`
+	HasWiki             *bool `json:"has_wiki,omitempty"`
+	HasProjects         *bool `json:"has_projects,omitempty"`
+}
+
+// SettingChange is a setting of a repository that differs from its policy.
+type SettingChange struct {
+	Setting  string // e.g. "allow_merge_commit"
+	Current  string
+	Expected string
+}
+
+// String describes the change, e.g. "has_wiki: true -> false".
+func (c SettingChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Setting, c.Current, c.Expected)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "fmt"
+
+// SourceChange is a range of commits pushed to a repository holding policy sources.
+type SourceChange struct {
+	Repository string // Full name, e.g. tracker-tv/github-actions-ttv
+	Base       string
+	Head       string
+}
+
+// CompareURL links to the diff of the change on GitHub.
+func (c SourceChange) CompareURL() string {
+	return fmt.Sprintf("https://github.com/%s/compare/%s...%s", c.Repository, c.Base, c.Head)
+}